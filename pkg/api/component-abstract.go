@@ -7,6 +7,9 @@ type ComponentAbstract struct {
 	ManagedByVersion string       `json:"managedByVersion"`
 	RunningIn        RunningModes `json:"runningIn"`
 	Type             string       `json:"projectType"`
+	// Platform is the platform on which the component is running, "cluster" or "podman".
+	// It is empty for a component defined in the local devfile but not running on any platform
+	Platform string `json:"platform,omitempty"`
 }
 
 const (
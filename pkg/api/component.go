@@ -45,13 +45,15 @@ func (o RunningModes) String() string {
 
 // Component describes the state of a devfile component
 type Component struct {
-	DevfilePath       string           `json:"devfilePath,omitempty"`
-	DevfileData       *DevfileData     `json:"devfileData,omitempty"`
-	DevForwardedPorts []ForwardedPort  `json:"devForwardedPorts,omitempty"`
-	RunningIn         RunningModes     `json:"runningIn"`
-	Ingresses         []ConnectionData `json:"ingresses,omitempty"`
-	Routes            []ConnectionData `json:"routes,omitempty"`
-	ManagedBy         string           `json:"managedBy"`
+	DevfilePath       string          `json:"devfilePath,omitempty"`
+	DevfileData       *DevfileData    `json:"devfileData,omitempty"`
+	DevForwardedPorts []ForwardedPort `json:"devForwardedPorts,omitempty"`
+	RunningIn         RunningModes    `json:"runningIn"`
+	// RunningOn indicates the modes in which the component is running, for each platform ("cluster" or "podman")
+	RunningOn map[string]RunningModes `json:"runningOn,omitempty"`
+	Ingresses []ConnectionData        `json:"ingresses,omitempty"`
+	Routes    []ConnectionData        `json:"routes,omitempty"`
+	ManagedBy string                  `json:"managedBy"`
 }
 
 type ForwardedPort struct {
@@ -0,0 +1,243 @@
+package apiserver
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+
+	"github.com/redhat-developer/odo/pkg/api"
+)
+
+const (
+	// maxResults is the number of results of the last updates of the component returned by the API
+	maxResults = 10
+	// maxPendingActions is the number of actions requested through the API and not yet processed by the session.
+	// The requests for more actions are rejected
+	maxPendingActions = 10
+	// tokenBytes is the number of random bytes of the token required to access the API
+	tokenBytes = 32
+)
+
+// loopbackHosts are the names of the loopback interface accepted in the Host header of the requests,
+// in addition to the host of the listener address
+var loopbackHosts = map[string]bool{
+	"localhost": true,
+	"127.0.0.1": true,
+	"::1":       true,
+}
+
+// Server serves the API of an odo dev session
+type Server struct {
+	listener net.Listener
+	server   *http.Server
+	// token is the bearer token required in the requests to the API, generated for each server
+	token string
+	// forwardedPorts returns the ports forwarded by the session
+	forwardedPorts func() ([]api.ForwardedPort, error)
+	actions        chan Action
+
+	mu      sync.Mutex
+	state   string
+	debug   bool
+	results []Result
+}
+
+// Start starts serving the API on address, in the form host:port, until ctx is done.
+// A random port is used if the port is 0.
+// The requests must pass the token returned by Token as a bearer token, and are rejected if they come from a browser
+func Start(ctx context.Context, address string, forwardedPorts func() ([]api.ForwardedPort, error)) (*Server, error) {
+	token, err := generateToken()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate the API token: %w", err)
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen on %s: %w", address, err)
+	}
+	o := &Server{
+		listener:       listener,
+		token:          token,
+		forwardedPorts: forwardedPorts,
+		actions:        make(chan Action, maxPendingActions),
+	}
+	o.server = &http.Server{
+		Handler:           o.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := o.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			klog.V(4).Infof("API server stopped: %v", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = o.server.Close()
+	}()
+	return o, nil
+}
+
+// Address returns the address on which the API is served, in the form host:port
+func (o *Server) Address() string {
+	return o.listener.Addr().String()
+}
+
+// Token returns the bearer token required in the requests to the API
+func (o *Server) Token() string {
+	return o.token
+}
+
+// Actions returns the channel on which the actions requested through the API are sent.
+// It returns nil if the server is nil, so the caller can receive from the channel without checking the server is started
+func (o *Server) Actions() <-chan Action {
+	if o == nil {
+		return nil
+	}
+	return o.actions
+}
+
+// SetState sets the state of the component, and the kind of the command executed, run or debug if debug is true
+func (o *Server) SetState(state string, debug bool) {
+	if o == nil {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.state = state
+	o.debug = debug
+}
+
+// AddResult adds the result of an update of the component, only the last results being kept
+func (o *Server) AddResult(result Result) {
+	if o == nil {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.results = append(o.results, result)
+	if len(o.results) > maxResults {
+		o.results = o.results[len(o.results)-maxResults:]
+	}
+}
+
+func (o *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/status", o.handleStatus)
+	for _, action := range []Action{ActionSync, ActionRestart, ActionDebug, ActionRun, ActionStop} {
+		mux.HandleFunc("/api/v1/"+string(action), o.handleAction(action))
+	}
+	return o.authorize(mux)
+}
+
+// authorize rejects the requests sent by a browser, the requests sent to another host than the listener address
+// or a loopback host on the listener port, which protects against DNS rebinding, and the requests not passing the token of the server
+func (o *Server) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Origin") != "" {
+			writeError(w, http.StatusForbidden, errors.New("cross-origin requests are not allowed"))
+			return
+		}
+		if !o.isAllowedHost(r.Host) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("host %q not allowed, use %s", r.Host, o.Address()))
+			return
+		}
+		expected := "Bearer " + o.token
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(expected)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (o *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed, use GET", r.Method))
+		return
+	}
+	fwPorts, err := o.forwardedPorts()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("unable to get the forwarded ports: %w", err))
+		return
+	}
+	if fwPorts == nil {
+		fwPorts = []api.ForwardedPort{}
+	}
+
+	o.mu.Lock()
+	status := Status{
+		State:          o.state,
+		Command:        "run",
+		ForwardedPorts: fwPorts,
+		LastResults:    append([]Result{}, o.results...),
+	}
+	if o.debug {
+		status.Command = "debug"
+	}
+	o.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (o *Server) handleAction(action Action) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed, use POST", r.Method))
+			return
+		}
+		select {
+		case o.actions <- action:
+			writeJSON(w, http.StatusAccepted, ActionResponse{Action: action})
+		default:
+			writeError(w, http.StatusServiceUnavailable, errors.New("too many actions are pending, retry later"))
+		}
+	}
+}
+
+// isAllowedHost returns true if host, in the form host:port, is the listener address,
+// or a loopback host with the port of the listener address
+func (o *Server) isAllowedHost(host string) bool {
+	if host == o.Address() {
+		return true
+	}
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil {
+		return false
+	}
+	_, listenerPort, err := net.SplitHostPort(o.Address())
+	if err != nil {
+		return false
+	}
+	return port == listenerPort && loopbackHosts[strings.ToLower(hostname)]
+}
+
+// generateToken returns a random token, encoded in hexadecimal
+func generateToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		klog.V(4).Infof("unable to write API response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Message: err.Error()})
+}
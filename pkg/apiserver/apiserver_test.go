@@ -0,0 +1,322 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/redhat-developer/odo/pkg/api"
+)
+
+func TestServer_status(t *testing.T) {
+	fwPort := api.ForwardedPort{
+		ContainerName: "runtime",
+		LocalAddress:  "127.0.0.1",
+		LocalPort:     40001,
+		ContainerPort: 3000,
+	}
+	startTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		forwardedPorts func() ([]api.ForwardedPort, error)
+		update         func(server *Server)
+		wantStatusCode int
+		want           Status
+	}{
+		{
+			name: "no update",
+			forwardedPorts: func() ([]api.ForwardedPort, error) {
+				return nil, nil
+			},
+			update:         func(server *Server) {},
+			wantStatusCode: http.StatusOK,
+			want: Status{
+				Command:        "run",
+				ForwardedPorts: []api.ForwardedPort{},
+				LastResults:    []Result{},
+			},
+		},
+		{
+			name: "debugging with forwarded ports",
+			forwardedPorts: func() ([]api.ForwardedPort, error) {
+				return []api.ForwardedPort{fwPort}, nil
+			},
+			update: func(server *Server) {
+				server.SetState("Ready", true)
+				server.AddResult(Result{
+					StartTime: startTime,
+					EndTime:   startTime.Add(time.Second),
+					Command:   "debug",
+					Restart:   true,
+					Success:   true,
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			want: Status{
+				State:          "Ready",
+				Command:        "debug",
+				ForwardedPorts: []api.ForwardedPort{fwPort},
+				LastResults: []Result{
+					{
+						StartTime: startTime,
+						EndTime:   startTime.Add(time.Second),
+						Command:   "debug",
+						Restart:   true,
+						Success:   true,
+					},
+				},
+			},
+		},
+		{
+			name: "only the last results are returned",
+			forwardedPorts: func() ([]api.ForwardedPort, error) {
+				return nil, nil
+			},
+			update: func(server *Server) {
+				server.SetState("SyncOutdated", false)
+				for i := 0; i < maxResults+2; i++ {
+					server.AddResult(Result{
+						Command:      "run",
+						ChangedFiles: []string{fmt.Sprintf("file%d", i)},
+					})
+				}
+			},
+			wantStatusCode: http.StatusOK,
+			want: func() Status {
+				status := Status{
+					State:          "SyncOutdated",
+					Command:        "run",
+					ForwardedPorts: []api.ForwardedPort{},
+				}
+				for i := 2; i < maxResults+2; i++ {
+					status.LastResults = append(status.LastResults, Result{
+						Command:      "run",
+						ChangedFiles: []string{fmt.Sprintf("file%d", i)},
+					})
+				}
+				return status
+			}(),
+		},
+		{
+			name: "error getting the forwarded ports",
+			forwardedPorts: func() ([]api.ForwardedPort, error) {
+				return nil, errors.New("an error")
+			},
+			update:         func(server *Server) {},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			server, err := Start(ctx, "127.0.0.1:0", tt.forwardedPorts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.update(server)
+
+			resp, err := doRequest(server, http.MethodGet, "/api/v1/status", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("status code = %d, want %d", resp.StatusCode, tt.wantStatusCode)
+			}
+			if resp.StatusCode != http.StatusOK {
+				return
+			}
+			var got Status
+			if err = json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("status mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServer_actions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server, err := Start(ctx, "127.0.0.1:0", func() ([]api.ForwardedPort, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, action := range []Action{ActionSync, ActionRestart, ActionDebug, ActionRun, ActionStop} {
+		resp, err := doRequest(server, http.MethodPost, "/api/v1/"+string(action), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusAccepted {
+			t.Errorf("status code for action %s = %d, want %d", action, resp.StatusCode, http.StatusAccepted)
+		}
+		if got := <-server.Actions(); got != action {
+			t.Errorf("action = %s, want %s", got, action)
+		}
+	}
+
+	resp, err := doRequest(server, http.MethodGet, "/api/v1/sync", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("status code for GET = %d, want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+
+	// the actions not processed by the session are limited
+	for i := 0; i < maxPendingActions; i++ {
+		server.actions <- ActionSync
+	}
+	resp, err = doRequest(server, http.MethodPost, "/api/v1/sync", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status code with pending actions = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+}
+
+func TestServer_authorize(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server, err := Start(ctx, "127.0.0.1:0", func() ([]api.ForwardedPort, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, port, err := net.SplitHostPort(server.Address())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		setHeaders     func(r *http.Request)
+		wantStatusCode int
+		wantAction     bool
+	}{
+		{
+			name:           "valid token",
+			setHeaders:     func(r *http.Request) {},
+			wantStatusCode: http.StatusAccepted,
+			wantAction:     true,
+		},
+		{
+			name: "no token",
+			setHeaders: func(r *http.Request) {
+				r.Header.Del("Authorization")
+			},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name: "invalid token",
+			setHeaders: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer invalid")
+			},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name: "request from a browser",
+			setHeaders: func(r *http.Request) {
+				r.Header.Set("Origin", "http://example.com")
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "request to another host",
+			setHeaders: func(r *http.Request) {
+				r.Host = "example.com"
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "request to another host on the listener port",
+			setHeaders: func(r *http.Request) {
+				r.Host = "example.com:" + port
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "request to localhost",
+			setHeaders: func(r *http.Request) {
+				r.Host = "localhost:" + port
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantAction:     true,
+		},
+		{
+			name: "request to the IPv6 loopback address",
+			setHeaders: func(r *http.Request) {
+				r.Host = "[::1]:" + port
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantAction:     true,
+		},
+		{
+			name: "request to localhost on another port",
+			setHeaders: func(r *http.Request) {
+				r.Host = "localhost:1"
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := doRequest(server, http.MethodPost, "/api/v1/stop", tt.setHeaders)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("status code = %d, want %d", resp.StatusCode, tt.wantStatusCode)
+			}
+			select {
+			case action := <-server.Actions():
+				if !tt.wantAction {
+					t.Errorf("unexpected action %s", action)
+				}
+			default:
+				if tt.wantAction {
+					t.Errorf("no action requested")
+				}
+			}
+		})
+	}
+
+	if other, err := Start(ctx, "127.0.0.1:0", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if other.Token() == server.Token() {
+		t.Errorf("the same token %q is generated for different servers", server.Token())
+	}
+}
+
+// doRequest sends a request with the token of server to path, after modifying its headers with setHeaders if not nil
+func doRequest(server *Server, method string, path string, setHeaders func(r *http.Request)) (*http.Response, error) {
+	req, err := http.NewRequest(method, "http://"+server.Address()+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+server.Token())
+	if setHeaders != nil {
+		setHeaders(req)
+	}
+	return http.DefaultClient.Do(req)
+}
@@ -0,0 +1,2 @@
+// Package apiserver implements a local HTTP API to get the status of a running odo dev session, and to control it
+package apiserver
@@ -0,0 +1,60 @@
+package apiserver
+
+import (
+	"time"
+
+	"github.com/redhat-developer/odo/pkg/api"
+)
+
+// Action is an action requested to the odo dev session through the API
+type Action string
+
+const (
+	// ActionSync applies the local changes to the component
+	ActionSync Action = "sync"
+	// ActionRestart stops the application, then runs the build and run (or debug) commands again
+	ActionRestart Action = "restart"
+	// ActionDebug switches to the debug command
+	ActionDebug Action = "debug"
+	// ActionRun switches to the run command
+	ActionRun Action = "run"
+	// ActionStop stops the session and deletes the resources of the component
+	ActionStop Action = "stop"
+)
+
+// Status is the status of the odo dev session returned by the API
+type Status struct {
+	// State is the state of the component
+	State string `json:"state"`
+	// Command is the kind of the command executed by the component, run or debug
+	Command string `json:"command"`
+	// ForwardedPorts are the ports forwarded by the session
+	ForwardedPorts []api.ForwardedPort `json:"forwardedPorts"`
+	// LastResults are the results of the last updates of the component, the most recent being the last one
+	LastResults []Result `json:"lastResults"`
+}
+
+// Result is the result of an update of the component: synchronization of the files,
+// then execution of the build and run (or debug) commands when needed
+type Result struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	// Command is the kind of the command executed after the synchronization, run or debug
+	Command      string   `json:"command"`
+	ChangedFiles []string `json:"changedFiles,omitempty"`
+	DeletedFiles []string `json:"deletedFiles,omitempty"`
+	// Restart is true if the command has been restarted, even if no file has changed
+	Restart bool   `json:"restart"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ActionResponse is the response of the API to a requested action
+type ActionResponse struct {
+	Action Action `json:"action"`
+}
+
+// ErrorResponse is the response of the API when a request fails
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
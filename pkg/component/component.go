@@ -2,6 +2,7 @@ package component
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -19,8 +20,10 @@ import (
 	"github.com/redhat-developer/odo/pkg/api"
 	"github.com/redhat-developer/odo/pkg/kclient"
 	odolabels "github.com/redhat-developer/odo/pkg/labels"
+	"github.com/redhat-developer/odo/pkg/log"
 	odocontext "github.com/redhat-developer/odo/pkg/odo/context"
 	"github.com/redhat-developer/odo/pkg/platform"
+	"github.com/redhat-developer/odo/pkg/podman"
 	"github.com/redhat-developer/odo/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
@@ -112,6 +115,17 @@ func Log(platformClient platform.Client, componentName string, appName string, f
 // `odo list`
 // that are both odo and non-odo components.
 func ListAllClusterComponents(client kclient.ClientInterface, namespace string) ([]api.ComponentAbstract, error) {
+	return listAllComponents(client, namespace, platform.Cluster)
+}
+
+// ListAllPodmanComponents returns a list of all "components" running on Podman
+func ListAllPodmanComponents(client podman.Client) ([]api.ComponentAbstract, error) {
+	return listAllComponents(client, "", platform.Podman)
+}
+
+// listAllComponents returns a list of all "components" found on the platform accessed with client,
+// in the given namespace if the platform supports namespaces
+func listAllComponents(client platform.Client, namespace string, platformName string) ([]api.ComponentAbstract, error) {
 
 	// Get all the dynamic resources available
 	resourceList, err := client.GetAllResourcesFromSelector("", namespace)
@@ -147,7 +161,8 @@ func ListAllClusterComponents(client kclient.ClientInterface, namespace string)
 		}
 
 		// Get the component type (if there is any..)
-		componentType, err := odolabels.GetProjectType(nil, annotations)
+		// Podman pods do not have annotations, the type is only set as a label
+		componentType, err := odolabels.GetProjectType(labels, annotations)
 		if err != nil || componentType == "" {
 			componentType = api.TypeUnknown
 		}
@@ -170,6 +185,7 @@ func ListAllClusterComponents(client kclient.ClientInterface, namespace string)
 			ManagedBy:        managedBy,
 			Type:             componentType,
 			ManagedByVersion: managedByVersion,
+			Platform:         platformName,
 		}
 		mode := odolabels.GetMode(labels)
 		componentFound := false
@@ -204,16 +220,35 @@ func ListAllClusterComponents(client kclient.ClientInterface, namespace string)
 	return components, nil
 }
 
-func ListAllComponents(client kclient.ClientInterface, namespace string, devObj *parser.DevfileObj, componentName string) ([]api.ComponentAbstract, string, error) {
+// ListAllComponents returns the components running on the cluster, if kubeClient is not nil, and on Podman, if podmanClient is not nil,
+// and the component defined in the local devfile, if any.
+// A component running on both platforms is returned once per platform.
+// When components are also listed on the cluster, a failure to list the components running on Podman
+// is reported as a warning, so the components running on the cluster are still returned.
+func ListAllComponents(kubeClient kclient.ClientInterface, podmanClient podman.Client, namespace string, devObj *parser.DevfileObj, componentName string) ([]api.ComponentAbstract, string, error) {
 	var devfileComponents []api.ComponentAbstract
 	var err error
-	if client != nil {
-		devfileComponents, err = ListAllClusterComponents(client, namespace)
+	if kubeClient != nil {
+		devfileComponents, err = ListAllClusterComponents(kubeClient, namespace)
 		if err != nil {
 			return nil, "", err
 		}
 	}
 
+	if podmanClient != nil {
+		var podmanComponents []api.ComponentAbstract
+		podmanComponents, err = ListAllPodmanComponents(podmanClient)
+		switch {
+		case err != nil && kubeClient == nil:
+			return nil, "", fmt.Errorf("unable to list components running on Podman: %w", err)
+		case err != nil:
+			klog.V(4).Infof("unable to list components running on Podman: %v", err)
+			log.Warning("Unable to access Podman, the components running on Podman are not listed. Run with `-v <DEBUG_LEVEL_0-9>` to know more.")
+		default:
+			devfileComponents = append(devfileComponents, podmanComponents...)
+		}
+	}
+
 	localComponent := api.ComponentAbstract{
 		Name:      componentName,
 		ManagedBy: "",
@@ -235,7 +270,7 @@ func ListAllComponents(client kclient.ClientInterface, namespace string, devObj
 
 func getResourcesForComponent(
 	ctx context.Context,
-	client kclient.ClientInterface,
+	client platform.Client,
 	name string,
 	namespace string,
 ) ([]unstructured.Unstructured, error) {
@@ -261,14 +296,79 @@ func GetRunningModes(ctx context.Context, client kclient.ClientInterface, name s
 	if client == nil {
 		return nil, nil
 	}
+	return getRunningModes(ctx, client, name, client.GetCurrentNamespace(), NewNoComponentFoundError(name, client.GetCurrentNamespace()))
+}
+
+// GetPodmanRunningModes returns the list of modes on which a "name" component is running on Podman,
+// based on the "odo.dev/mode" label of the pods
+func GetPodmanRunningModes(ctx context.Context, client podman.Client, name string) (api.RunningModes, error) {
+	if client == nil {
+		return nil, nil
+	}
+	return getRunningModes(ctx, client, name, "", NewNoComponentFoundOnPodmanError(name))
+}
+
+// GetRunningModesOnPlatforms returns the running modes of a "name" component on each platform for which a client is provided,
+// indexed by platform. Platforms on which the component is not found are not part of the result.
+// A NoComponentFoundError is returned if the component is not found on any of the platforms
+func GetRunningModesOnPlatforms(ctx context.Context, kubeClient kclient.ClientInterface, podmanClient podman.Client, name string) (map[string]api.RunningModes, error) {
+	var notFoundErr error
+	result := map[string]api.RunningModes{}
+
+	if kubeClient != nil {
+		modes, err := GetRunningModes(ctx, kubeClient, name)
+		if err != nil {
+			if !errors.As(err, &NoComponentFoundError{}) {
+				return nil, err
+			}
+			notFoundErr = err
+		} else if modes != nil {
+			result[platform.Cluster] = modes
+		}
+	}
+
+	if podmanClient != nil {
+		modes, err := GetPodmanRunningModes(ctx, podmanClient, name)
+		if err != nil {
+			if !errors.As(err, &NoComponentFoundError{}) {
+				return nil, err
+			}
+			notFoundErr = err
+		} else if modes != nil {
+			result[platform.Podman] = modes
+		}
+	}
+
+	if len(result) == 0 && notFoundErr != nil {
+		return nil, notFoundErr
+	}
+	return result, nil
+}
+
+// MergeRunningModes returns the modes on which a component is running on any platform
+func MergeRunningModes(modesByPlatform map[string]api.RunningModes) api.RunningModes {
+	if len(modesByPlatform) == 0 {
+		return nil
+	}
+	result := api.NewRunningModes()
+	for _, modes := range modesByPlatform {
+		for mode, running := range modes {
+			if running {
+				result.AddRunningMode(mode)
+			}
+		}
+	}
+	return result
+}
 
-	list, err := getResourcesForComponent(ctx, client, name, client.GetCurrentNamespace())
+func getRunningModes(ctx context.Context, client platform.Client, name string, namespace string, notFoundErr error) (api.RunningModes, error) {
+	list, err := getResourcesForComponent(ctx, client, name, namespace)
 	if err != nil {
 		return nil, nil
 	}
 
 	if len(list) == 0 {
-		return nil, NewNoComponentFoundError(name, client.GetCurrentNamespace())
+		return nil, notFoundErr
 	}
 
 	mapResult := api.NewRunningModes()
@@ -293,11 +393,23 @@ func Contains(component api.ComponentAbstract, components []api.ComponentAbstrac
 	return false
 }
 
-// GetDevfileInfoFromCluster extracts information from the labels and annotations of resources to rebuild a Devfile
-func GetDevfileInfoFromCluster(ctx context.Context, client kclient.ClientInterface, name string) (parser.DevfileObj, error) {
-	list, err := getResourcesForComponent(ctx, client, name, client.GetCurrentNamespace())
-	if err != nil {
-		return parser.DevfileObj{}, nil
+// GetDevfileInfo extracts information from the labels and annotations of resources running on the cluster,
+// if kubeClient is not nil, and on Podman, if podmanClient is not nil, to rebuild a Devfile
+func GetDevfileInfo(ctx context.Context, kubeClient kclient.ClientInterface, podmanClient podman.Client, name string) (parser.DevfileObj, error) {
+	var list []unstructured.Unstructured
+	if kubeClient != nil {
+		resources, err := getResourcesForComponent(ctx, kubeClient, name, kubeClient.GetCurrentNamespace())
+		if err != nil {
+			return parser.DevfileObj{}, nil
+		}
+		list = append(list, resources...)
+	}
+	if podmanClient != nil {
+		resources, err := getResourcesForComponent(ctx, podmanClient, name, "")
+		if err != nil {
+			return parser.DevfileObj{}, nil
+		}
+		list = append(list, resources...)
 	}
 
 	if len(list) == 0 {
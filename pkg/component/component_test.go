@@ -28,6 +28,7 @@ import (
 	"github.com/redhat-developer/odo/pkg/labels"
 	"github.com/redhat-developer/odo/pkg/libdevfile"
 	odocontext "github.com/redhat-developer/odo/pkg/odo/context"
+	"github.com/redhat-developer/odo/pkg/podman"
 	"github.com/redhat-developer/odo/pkg/testingutil"
 	"github.com/redhat-developer/odo/pkg/util"
 
@@ -85,6 +86,7 @@ func TestListAllClusterComponents(t *testing.T) {
 				ManagedByVersion: "",
 				RunningIn:        nil,
 				Type:             "Unknown",
+				Platform:         "cluster",
 			}},
 			wantErr: false,
 		},
@@ -125,12 +127,14 @@ func TestListAllClusterComponents(t *testing.T) {
 				ManagedByVersion: "",
 				RunningIn:        nil,
 				Type:             "Unknown",
+				Platform:         "cluster",
 			}, {
 				Name:             "svc1",
 				ManagedBy:        "odo",
 				ManagedByVersion: "v3.0.0-beta3",
 				RunningIn:        nil,
 				Type:             "nodejs",
+				Platform:         "cluster",
 			}},
 			wantErr: false,
 		},
@@ -156,7 +160,8 @@ func TestListAllClusterComponents(t *testing.T) {
 					"dev":    true,
 					"deploy": true,
 				},
-				Type: "nodejs",
+				Type:     "nodejs",
+				Platform: "cluster",
 			}},
 			wantErr: false,
 		},
@@ -176,6 +181,159 @@ func TestListAllClusterComponents(t *testing.T) {
 	}
 }
 
+func TestListAllComponents(t *testing.T) {
+	const odoVersion = "v3.0.0-beta3"
+	commonLabels := labels.Builder().WithComponentName("comp1").WithManager("odo").WithManagedByVersion(odoVersion)
+
+	resDev := getUnstructured("depDev", "deployment", "v1", "odo", odoVersion, "nodejs", "my-ns")
+	resDev.SetLabels(commonLabels.WithMode("Dev").Labels())
+
+	podDev := unstructured.Unstructured{}
+	podDev.SetName("comp1-app")
+	podDev.SetKind("Pod")
+	podDev.SetLabels(labels.Builder().WithComponentName("comp1").WithManager("odo").WithManagedByVersion(odoVersion).WithMode("Dev").WithProjectType("nodejs").Labels())
+
+	type args struct {
+		kubeClient    func(ctrl *gomock.Controller) kclient.ClientInterface
+		podmanClient  func(ctrl *gomock.Controller) podman.Client
+		componentName string
+	}
+	tests := []struct {
+		name                   string
+		args                   args
+		want                   []api.ComponentAbstract
+		wantComponentInDevfile string
+		wantErr                bool
+	}{
+		{
+			name: "component running on cluster and podman",
+			args: args{
+				kubeClient: func(ctrl *gomock.Controller) kclient.ClientInterface {
+					client := kclient.NewMockClientInterface(ctrl)
+					client.EXPECT().GetAllResourcesFromSelector("", "my-ns").Return([]unstructured.Unstructured{resDev}, nil)
+					return client
+				},
+				podmanClient: func(ctrl *gomock.Controller) podman.Client {
+					client := podman.NewMockClient(ctrl)
+					client.EXPECT().GetAllResourcesFromSelector("", "").Return([]unstructured.Unstructured{podDev}, nil)
+					return client
+				},
+				componentName: "comp1",
+			},
+			want: []api.ComponentAbstract{
+				{
+					Name:             "comp1",
+					ManagedBy:        "odo",
+					ManagedByVersion: odoVersion,
+					RunningIn:        api.RunningModes{"dev": true, "deploy": false},
+					Type:             "nodejs",
+					Platform:         "cluster",
+				},
+				{
+					Name:             "comp1",
+					ManagedBy:        "odo",
+					ManagedByVersion: odoVersion,
+					RunningIn:        api.RunningModes{"dev": true, "deploy": false},
+					Type:             "nodejs",
+					Platform:         "podman",
+				},
+			},
+			wantComponentInDevfile: "comp1",
+		},
+		{
+			name: "component running on podman only, local component not running",
+			args: args{
+				kubeClient: func(ctrl *gomock.Controller) kclient.ClientInterface {
+					return nil
+				},
+				podmanClient: func(ctrl *gomock.Controller) podman.Client {
+					client := podman.NewMockClient(ctrl)
+					client.EXPECT().GetAllResourcesFromSelector("", "").Return([]unstructured.Unstructured{podDev}, nil)
+					return client
+				},
+				componentName: "local",
+			},
+			want: []api.ComponentAbstract{
+				{
+					Name:             "comp1",
+					ManagedBy:        "odo",
+					ManagedByVersion: odoVersion,
+					RunningIn:        api.RunningModes{"dev": true, "deploy": false},
+					Type:             "nodejs",
+					Platform:         "podman",
+				},
+				{
+					Name:      "local",
+					RunningIn: api.RunningModes{"dev": false, "deploy": false},
+				},
+			},
+			wantComponentInDevfile: "local",
+		},
+		{
+			name: "error listing podman components",
+			args: args{
+				kubeClient: func(ctrl *gomock.Controller) kclient.ClientInterface {
+					return nil
+				},
+				podmanClient: func(ctrl *gomock.Controller) podman.Client {
+					client := podman.NewMockClient(ctrl)
+					client.EXPECT().GetAllResourcesFromSelector("", "").Return(nil, errors.New("podman not found"))
+					return client
+				},
+				componentName: "comp1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "error listing podman components, components running on cluster are returned",
+			args: args{
+				kubeClient: func(ctrl *gomock.Controller) kclient.ClientInterface {
+					client := kclient.NewMockClientInterface(ctrl)
+					client.EXPECT().GetAllResourcesFromSelector("", "my-ns").Return([]unstructured.Unstructured{resDev}, nil)
+					return client
+				},
+				podmanClient: func(ctrl *gomock.Controller) podman.Client {
+					client := podman.NewMockClient(ctrl)
+					client.EXPECT().GetAllResourcesFromSelector("", "").Return(nil, errors.New("podman not found"))
+					return client
+				},
+				componentName: "comp1",
+			},
+			want: []api.ComponentAbstract{
+				{
+					Name:             "comp1",
+					ManagedBy:        "odo",
+					ManagedByVersion: odoVersion,
+					RunningIn:        api.RunningModes{"dev": true, "deploy": false},
+					Type:             "nodejs",
+					Platform:         "cluster",
+				},
+			},
+			wantComponentInDevfile: "comp1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			var podmanClient podman.Client
+			if tt.args.podmanClient != nil {
+				podmanClient = tt.args.podmanClient(ctrl)
+			}
+			got, gotComponentInDevfile, err := ListAllComponents(tt.args.kubeClient(ctrl), podmanClient, "my-ns", nil, tt.args.componentName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListAllComponents error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ListAllComponents() mismatch (-want +got):\n%s", diff)
+			}
+			if gotComponentInDevfile != tt.wantComponentInDevfile {
+				t.Errorf("ListAllComponents() componentInDevfile = %q, want %q", gotComponentInDevfile, tt.wantComponentInDevfile)
+			}
+		})
+	}
+}
+
 func TestGetComponentTypeFromDevfileMetadata(t *testing.T) {
 	tests := []devfilepkg.DevfileMetadata{
 		{
@@ -21,20 +21,23 @@ import (
 	"github.com/redhat-developer/odo/pkg/libdevfile"
 	"github.com/redhat-developer/odo/pkg/log"
 	odocontext "github.com/redhat-developer/odo/pkg/odo/context"
+	"github.com/redhat-developer/odo/pkg/podman"
 	"github.com/redhat-developer/odo/pkg/util"
 )
 
 type DeleteComponentClient struct {
-	kubeClient kclient.ClientInterface
-	execClient exec.Client
+	kubeClient   kclient.ClientInterface
+	podmanClient podman.Client
+	execClient   exec.Client
 }
 
 var _ Client = (*DeleteComponentClient)(nil)
 
-func NewDeleteComponentClient(kubeClient kclient.ClientInterface, execClient exec.Client) *DeleteComponentClient {
+func NewDeleteComponentClient(kubeClient kclient.ClientInterface, podmanClient podman.Client, execClient exec.Client) *DeleteComponentClient {
 	return &DeleteComponentClient{
-		kubeClient: kubeClient,
-		execClient: execClient,
+		kubeClient:   kubeClient,
+		podmanClient: podmanClient,
+		execClient:   execClient,
 	}
 }
 
@@ -21,6 +21,7 @@ import (
 	"github.com/redhat-developer/odo/pkg/kclient"
 	odolabels "github.com/redhat-developer/odo/pkg/labels"
 	odocontext "github.com/redhat-developer/odo/pkg/odo/context"
+	"github.com/redhat-developer/odo/pkg/podman"
 	odoTestingUtil "github.com/redhat-developer/odo/pkg/testingutil"
 	"github.com/redhat-developer/odo/pkg/util"
 )
@@ -116,7 +117,7 @@ func TestDeleteComponentClient_ListClusterResourcesToDelete(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			kubeClient := tt.fields.kubeClient(ctrl)
 			execClient := exec.NewExecClient(kubeClient)
-			do := NewDeleteComponentClient(kubeClient, execClient)
+			do := NewDeleteComponentClient(kubeClient, nil, execClient)
 			ctx := odocontext.WithApplication(context.TODO(), "app")
 			got, err := do.ListClusterResourcesToDelete(ctx, tt.args.componentName, tt.args.namespace)
 			if (err != nil) != tt.wantErr {
@@ -233,7 +234,7 @@ func TestDeleteComponentClient_DeleteResources(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			kubeClient := tt.fields.kubeClient(ctrl)
 			execClient := exec.NewExecClient(kubeClient)
-			do := NewDeleteComponentClient(kubeClient, execClient)
+			do := NewDeleteComponentClient(kubeClient, nil, execClient)
 			got := do.DeleteResources(tt.args.resources, false)
 			if diff := cmp.Diff(tt.want, got); diff != "" {
 				t.Errorf("DeleteComponentClient.DeleteResources() mismatch (-want +got):\n%s", diff)
@@ -706,7 +707,7 @@ func TestDeleteComponentClient_ExecutePreStopEvents(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			kubeClient := tt.fields.kubeClient(ctrl)
 			execClient := exec.NewExecClient(kubeClient)
-			do := NewDeleteComponentClient(kubeClient, execClient)
+			do := NewDeleteComponentClient(kubeClient, nil, execClient)
 			if err := do.ExecutePreStopEvents(tt.args.devfileObj, tt.args.appName, tt.args.devfileObj.GetMetadataName()); (err != nil) != tt.wantErr {
 				t.Errorf("DeleteComponent() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -730,3 +731,160 @@ func getGVR(group, version, resource string) schema.GroupVersionResource {
 		Resource: resource,
 	}
 }
+
+func TestDeleteComponentClient_ListPodmanResourcesToDelete(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-component-app",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	volumes := []corev1.Volume{
+		{
+			Name: "odo-projects",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "odo-projects-my-component-app",
+				},
+			},
+		},
+	}
+	podWithVolumes := *pod.DeepCopy()
+	podWithVolumes.Spec.Volumes = volumes
+
+	selector := "app.kubernetes.io/instance=my-component,app.kubernetes.io/managed-by=odo,app.kubernetes.io/part-of=app"
+	tests := []struct {
+		name         string
+		podmanClient func(ctrl *gomock.Controller) podman.Client
+		want         []corev1.Pod
+		wantErr      bool
+	}{
+		{
+			name: "no pod found",
+			podmanClient: func(ctrl *gomock.Controller) podman.Client {
+				client := podman.NewMockClient(ctrl)
+				client.EXPECT().GetPodsMatchingSelector(selector).Return(&corev1.PodList{}, nil)
+				return client
+			},
+			want: nil,
+		},
+		{
+			name: "pod found, with its volumes",
+			podmanClient: func(ctrl *gomock.Controller) podman.Client {
+				client := podman.NewMockClient(ctrl)
+				client.EXPECT().GetPodsMatchingSelector(selector).Return(&corev1.PodList{Items: []corev1.Pod{pod}}, nil)
+				client.EXPECT().KubeGenerate("my-component-app").Return(&corev1.Pod{
+					Spec: corev1.PodSpec{
+						Volumes: volumes,
+					},
+				}, nil)
+				return client
+			},
+			want: []corev1.Pod{podWithVolumes},
+		},
+		{
+			name: "error generating pod definition",
+			podmanClient: func(ctrl *gomock.Controller) podman.Client {
+				client := podman.NewMockClient(ctrl)
+				client.EXPECT().GetPodsMatchingSelector(selector).Return(&corev1.PodList{Items: []corev1.Pod{pod}}, nil)
+				client.EXPECT().KubeGenerate("my-component-app").Return(nil, errors.New("an error"))
+				return client
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			do := NewDeleteComponentClient(nil, tt.podmanClient(ctrl), nil)
+			got, err := do.ListPodmanResourcesToDelete(appName, "my-component")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeleteComponentClient.ListPodmanResourcesToDelete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DeleteComponentClient.ListPodmanResourcesToDelete() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeleteComponentClient_DeletePodmanResources(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-component-app",
+		},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: "odo-projects",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "odo-projects-my-component-app",
+						},
+					},
+				},
+				{
+					Name: "odo-shared-data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "odo-shared-data-my-component-app",
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name         string
+		podmanClient func(ctrl *gomock.Controller) podman.Client
+		want         []string
+	}{
+		{
+			name: "pod and volumes deleted",
+			podmanClient: func(ctrl *gomock.Controller) podman.Client {
+				client := podman.NewMockClient(ctrl)
+				client.EXPECT().PodStop("my-component-app").Return(nil)
+				client.EXPECT().PodRm("my-component-app").Return(nil)
+				client.EXPECT().VolumeRm("odo-projects-my-component-app").Return(nil)
+				client.EXPECT().VolumeRm("odo-shared-data-my-component-app").Return(nil)
+				return client
+			},
+			want: nil,
+		},
+		{
+			name: "volume failed to be deleted",
+			podmanClient: func(ctrl *gomock.Controller) podman.Client {
+				client := podman.NewMockClient(ctrl)
+				client.EXPECT().PodStop("my-component-app").Return(nil)
+				client.EXPECT().PodRm("my-component-app").Return(nil)
+				client.EXPECT().VolumeRm("odo-projects-my-component-app").Return(errors.New("an error"))
+				client.EXPECT().VolumeRm("odo-shared-data-my-component-app").Return(nil)
+				return client
+			},
+			want: []string{"odo-projects-my-component-app"},
+		},
+		{
+			name: "pod failed to be deleted, volumes are not deleted",
+			podmanClient: func(ctrl *gomock.Controller) podman.Client {
+				client := podman.NewMockClient(ctrl)
+				client.EXPECT().PodStop("my-component-app").Return(nil)
+				client.EXPECT().PodRm("my-component-app").Return(errors.New("an error"))
+				return client
+			},
+			want: []string{"my-component-app"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			do := NewDeleteComponentClient(nil, tt.podmanClient(ctrl), nil)
+			got := do.DeletePodmanResources([]corev1.Pod{pod})
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DeleteComponentClient.DeletePodmanResources() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
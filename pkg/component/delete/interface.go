@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/devfile/library/pkg/devfile/parser"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -19,4 +20,10 @@ type Client interface {
 	// and a bool that indicates if the devfile component has been pushed to the innerloop
 	// the mode indicates which component to list, either Dev, Deploy or Any (using constant labels.Component*Mode)
 	ListResourcesToDeleteFromDevfile(devfileObj parser.DevfileObj, appName string, componentName string, mode string) (bool, []unstructured.Unstructured, error)
+	// ListPodmanResourcesToDelete returns the pods of the given odo component running on Podman, with the volumes they use
+	ListPodmanResourcesToDelete(appName string, componentName string) ([]corev1.Pod, error)
+	// DeletePodmanResources stops and deletes the pods and the volumes they use, and returns the names of the pods and volumes that failed to be deleted
+	DeletePodmanResources(pods []corev1.Pod) []string
+	// ExecutePodmanPreStopEvents executes preStop events if any, as a precondition to deleting a devfile component running on Podman
+	ExecutePodmanPreStopEvents(devfileObj parser.DevfileObj, appName string, componentName string) error
 }
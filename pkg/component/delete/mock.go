@@ -10,6 +10,7 @@ import (
 
 	parser "github.com/devfile/library/pkg/devfile/parser"
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
 	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -36,6 +37,20 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// DeletePodmanResources mocks base method.
+func (m *MockClient) DeletePodmanResources(pods []v1.Pod) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePodmanResources", pods)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// DeletePodmanResources indicates an expected call of DeletePodmanResources.
+func (mr *MockClientMockRecorder) DeletePodmanResources(pods interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePodmanResources", reflect.TypeOf((*MockClient)(nil).DeletePodmanResources), pods)
+}
+
 // DeleteResources mocks base method.
 func (m *MockClient) DeleteResources(resources []unstructured.Unstructured, wait bool) []unstructured.Unstructured {
 	m.ctrl.T.Helper()
@@ -50,6 +65,20 @@ func (mr *MockClientMockRecorder) DeleteResources(resources, wait interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResources", reflect.TypeOf((*MockClient)(nil).DeleteResources), resources, wait)
 }
 
+// ExecutePodmanPreStopEvents mocks base method.
+func (m *MockClient) ExecutePodmanPreStopEvents(devfileObj parser.DevfileObj, appName, componentName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecutePodmanPreStopEvents", devfileObj, appName, componentName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecutePodmanPreStopEvents indicates an expected call of ExecutePodmanPreStopEvents.
+func (mr *MockClientMockRecorder) ExecutePodmanPreStopEvents(devfileObj, appName, componentName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecutePodmanPreStopEvents", reflect.TypeOf((*MockClient)(nil).ExecutePodmanPreStopEvents), devfileObj, appName, componentName)
+}
+
 // ExecutePreStopEvents mocks base method.
 func (m *MockClient) ExecutePreStopEvents(devfileObj parser.DevfileObj, appName, componentName string) error {
 	m.ctrl.T.Helper()
@@ -79,6 +108,21 @@ func (mr *MockClientMockRecorder) ListClusterResourcesToDelete(ctx, componentNam
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClusterResourcesToDelete", reflect.TypeOf((*MockClient)(nil).ListClusterResourcesToDelete), ctx, componentName, namespace)
 }
 
+// ListPodmanResourcesToDelete mocks base method.
+func (m *MockClient) ListPodmanResourcesToDelete(appName, componentName string) ([]v1.Pod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPodmanResourcesToDelete", appName, componentName)
+	ret0, _ := ret[0].([]v1.Pod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPodmanResourcesToDelete indicates an expected call of ListPodmanResourcesToDelete.
+func (mr *MockClientMockRecorder) ListPodmanResourcesToDelete(appName, componentName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPodmanResourcesToDelete", reflect.TypeOf((*MockClient)(nil).ListPodmanResourcesToDelete), appName, componentName)
+}
+
 // ListResourcesToDeleteFromDevfile mocks base method.
 func (m *MockClient) ListResourcesToDeleteFromDevfile(devfileObj parser.DevfileObj, appName, componentName, mode string) (bool, []unstructured.Unstructured, error) {
 	m.ctrl.T.Helper()
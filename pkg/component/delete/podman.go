@@ -0,0 +1,90 @@
+package delete
+
+import (
+	"github.com/devfile/library/pkg/devfile/parser"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog"
+
+	"github.com/redhat-developer/odo/pkg/component"
+	odolabels "github.com/redhat-developer/odo/pkg/labels"
+	"github.com/redhat-developer/odo/pkg/libdevfile"
+	"github.com/redhat-developer/odo/pkg/log"
+)
+
+// ListPodmanResourcesToDelete returns the pods of the given odo component running on Podman, with the volumes they use
+func (do *DeleteComponentClient) ListPodmanResourcesToDelete(appName string, componentName string) ([]corev1.Pod, error) {
+	selector := odolabels.GetSelector(componentName, appName, odolabels.ComponentAnyMode, false)
+	list, err := do.podmanClient.GetPodsMatchingSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []corev1.Pod
+	for _, pod := range list.Items {
+		// The list of pods does not contain the volumes used by the pods
+		generated, err := do.podmanClient.KubeGenerate(pod.GetName())
+		if err != nil {
+			return nil, err
+		}
+		pod.Spec.Volumes = generated.Spec.Volumes
+		result = append(result, pod)
+	}
+	return result, nil
+}
+
+// DeletePodmanResources stops and deletes the pods and the volumes they use.
+// It returns the names of the pods and volumes that failed to be deleted
+func (do *DeleteComponentClient) DeletePodmanResources(pods []corev1.Pod) []string {
+	var failed []string
+	for _, pod := range pods {
+		err := do.podmanClient.PodStop(pod.GetName())
+		if err != nil {
+			klog.V(3).Infof("failed to stop pod %q: %v", pod.GetName(), err)
+		}
+		err = do.podmanClient.PodRm(pod.GetName())
+		if err != nil {
+			klog.V(3).Infof("failed to delete pod %q: %v", pod.GetName(), err)
+			failed = append(failed, pod.GetName())
+			// volumes cannot be deleted while used by the pod
+			continue
+		}
+
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim == nil {
+				continue
+			}
+			volumeName := volume.PersistentVolumeClaim.ClaimName
+			err = do.podmanClient.VolumeRm(volumeName)
+			if err != nil {
+				klog.V(3).Infof("failed to delete volume %q: %v", volumeName, err)
+				failed = append(failed, volumeName)
+			}
+		}
+	}
+	return failed
+}
+
+// ExecutePodmanPreStopEvents executes preStop events if any, as a precondition to deleting a devfile component running on Podman
+func (do *DeleteComponentClient) ExecutePodmanPreStopEvents(devfileObj parser.DevfileObj, appName string, componentName string) error {
+	if !libdevfile.HasPreStopEvents(devfileObj) {
+		return nil
+	}
+
+	klog.V(3).Infof("Checking component status for %q", componentName)
+	selector := odolabels.GetSelector(componentName, appName, odolabels.ComponentDevMode, false)
+	pod, err := do.podmanClient.GetRunningPodFromSelector(selector)
+	if err != nil {
+		klog.V(3).Infof("Running pod for %q not found; cause: %v", componentName, err)
+		log.Warningf("Running pod not found on Podman. Run `odo delete component -v <DEBUG_LEVEL_0-9>` to know more.")
+		return nil
+	}
+
+	klog.V(4).Infof("Executing %q event commands for component %q", libdevfile.PreStop, componentName)
+	// ignore the failures if any; delete should not fail because preStop events failed to execute
+	err = libdevfile.ExecPreStopEvents(devfileObj, component.NewExecHandler(do.podmanClient, do.execClient, appName, componentName, pod.Name, "", false))
+	if err != nil {
+		klog.V(4).Infof("Failed to execute %q event commands for component %q, cause: %v", libdevfile.PreStop, componentName, err.Error())
+	}
+
+	return nil
+}
@@ -8,6 +8,8 @@ import (
 type NoComponentFoundError struct {
 	name      string
 	namespace string
+	// onPodman indicates that the component was searched on Podman, where there is no namespace
+	onPodman bool
 }
 
 func NewNoComponentFoundError(name string, namespace string) NoComponentFoundError {
@@ -16,6 +18,17 @@ func NewNoComponentFoundError(name string, namespace string) NoComponentFoundErr
 		namespace: namespace,
 	}
 }
+
+func NewNoComponentFoundOnPodmanError(name string) NoComponentFoundError {
+	return NoComponentFoundError{
+		name:     name,
+		onPodman: true,
+	}
+}
+
 func (e NoComponentFoundError) Error() string {
+	if e.onPodman {
+		return fmt.Sprintf("no component found with name %q on podman", e.name)
+	}
 	return fmt.Sprintf("no component found with name %q in the namespace %q", e.name, e.namespace)
 }
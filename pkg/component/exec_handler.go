@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	parsercommon "github.com/devfile/library/pkg/devfile/parser/data/v2/common"
 
 	"github.com/redhat-developer/odo/pkg/exec"
 	"github.com/redhat-developer/odo/pkg/libdevfile"
@@ -62,9 +63,11 @@ func (o *execHandler) Execute(command v1alpha2.Command) error {
 	stdoutWriter, stdoutChannel, stderrWriter, stderrChannel := logger.CreateContainerOutputWriter()
 
 	cmdline := getCmdline(command)
+	logger.DevFileCommandExecutionBegin(command.Id, command.Exec.Component, command.Exec.CommandLine, getGroupKind(command), machineoutput.TimestampNow())
 	_, _, err := o.execClient.ExecuteCommand(cmdline, o.podName, command.Exec.Component, o.show, stdoutWriter, stderrWriter)
 
 	closeWriterAndWaitForAck(stdoutWriter, stdoutChannel, stderrWriter, stderrChannel)
+	logger.DevFileCommandExecutionComplete(command.Id, command.Exec.Component, command.Exec.CommandLine, getGroupKind(command), machineoutput.TimestampNow(), err)
 
 	spinner.End(err == nil)
 	if err != nil {
@@ -83,6 +86,15 @@ func (o *execHandler) Execute(command v1alpha2.Command) error {
 	return err
 }
 
+// getGroupKind returns the kind of the group of command, or an empty string if the command is not part of a group
+func getGroupKind(command v1alpha2.Command) string {
+	group := parsercommon.GetGroup(command)
+	if group == nil {
+		return ""
+	}
+	return string(group.Kind)
+}
+
 func getCmdline(command v1alpha2.Command) []string {
 	// deal with environment variables
 	var cmdLine string
@@ -10,6 +10,7 @@ import (
 
 	"github.com/redhat-developer/odo/pkg/exec"
 	"github.com/redhat-developer/odo/pkg/log"
+	"github.com/redhat-developer/odo/pkg/machineoutput"
 	"github.com/redhat-developer/odo/pkg/platform"
 	"github.com/redhat-developer/odo/pkg/remotecmd"
 	"github.com/redhat-developer/odo/pkg/task"
@@ -30,6 +31,7 @@ func ExecuteRunCommand(
 	componentName string,
 ) error {
 	remoteProcessHandler := remotecmd.NewKubeExecProcessHandler(execClient)
+	logger := machineoutput.NewMachineEventLoggingClient()
 
 	statusHandlerFunc := func(s *log.Status) remotecmd.CommandOutputHandler {
 		return func(status remotecmd.RemoteProcessStatus, stdout []string, stderr []string, err error) {
@@ -37,11 +39,18 @@ func ExecuteRunCommand(
 			case remotecmd.Starting:
 				// Creating with no spin because the command could be long-running, and we cannot determine when it will end.
 				s.Start(fmt.Sprintf("Executing the application (command: %s)", devfileCmd.Id), true)
+				logger.DevFileCommandExecutionBegin(devfileCmd.Id, devfileCmd.Exec.Component, devfileCmd.Exec.CommandLine,
+					getGroupKind(devfileCmd), machineoutput.TimestampNow())
 			case remotecmd.Stopped, remotecmd.Errored:
 				s.EndWithStatus(fmt.Sprintf("Finished executing the application (command: %s)", devfileCmd.Id), status == remotecmd.Stopped)
 				if err != nil {
 					klog.V(2).Infof("error while running background command: %v", err)
 				}
+				if status == remotecmd.Errored && err == nil {
+					err = fmt.Errorf("command %q exited with an error", devfileCmd.Id)
+				}
+				logger.DevFileCommandExecutionComplete(devfileCmd.Id, devfileCmd.Exec.Component, devfileCmd.Exec.CommandLine,
+					getGroupKind(devfileCmd), machineoutput.TimestampNow(), err)
 			}
 		}
 	}
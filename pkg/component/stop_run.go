@@ -0,0 +1,51 @@
+package component
+
+import (
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser"
+	"k8s.io/klog"
+
+	"github.com/redhat-developer/odo/pkg/exec"
+	"github.com/redhat-developer/odo/pkg/libdevfile"
+	"github.com/redhat-developer/odo/pkg/remotecmd"
+)
+
+// StopRunAndDebugCommands stops the processes of the run command runCmd and of the debug command debugCmd
+// running in the specified pod, or of the default commands if the names are empty.
+// The commands not defined in the Devfile are ignored.
+func StopRunAndDebugCommands(execClient exec.Client, devfileObj parser.DevfileObj, runCmd, debugCmd, podName string) error {
+	handler := stopHandler{
+		execClient: execClient,
+		podName:    podName,
+	}
+	err := libdevfile.ExecuteCommandByNameAndKind(devfileObj, runCmd, devfilev1.RunCommandGroupKind, handler, true)
+	if err != nil {
+		return err
+	}
+	return libdevfile.ExecuteCommandByNameAndKind(devfileObj, debugCmd, devfilev1.DebugCommandGroupKind, handler, true)
+}
+
+// stopHandler stops the processes of the exec commands, and ignores the other commands
+type stopHandler struct {
+	execClient exec.Client
+	podName    string
+}
+
+var _ libdevfile.Handler = (*stopHandler)(nil)
+
+func (a stopHandler) ApplyImage(devfilev1.Component) error {
+	return nil
+}
+
+func (a stopHandler) ApplyKubernetes(devfilev1.Component) error {
+	return nil
+}
+
+func (a stopHandler) Execute(devfileCmd devfilev1.Command) error {
+	cmdDef, err := devfileCommandToRemoteCmdDefinition(devfileCmd)
+	if err != nil {
+		return err
+	}
+	klog.V(2).Infof("stopping command %s", devfileCmd.Id)
+	return remotecmd.NewKubeExecProcessHandler(a.execClient).StopProcessForCommand(cmdDef, a.podName, devfileCmd.Exec.Component)
+}
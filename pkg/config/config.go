@@ -7,16 +7,19 @@ import (
 )
 
 type Configuration struct {
+	ContainerHost         *string `env:"CONTAINER_HOST,noinit"`
 	DevfileProxy          *string `env:"DEVFILE_PROXY,noinit"`
 	DockerCmd             string  `env:"DOCKER_CMD,default=docker"`
 	Globalodoconfig       *string `env:"GLOBALODOCONFIG,noinit"`
 	OdoDebugTelemetryFile *string `env:"ODO_DEBUG_TELEMETRY_FILE,noinit"`
 	OdoDisableTelemetry   *bool   `env:"ODO_DISABLE_TELEMETRY,noinit"`
 	OdoLogLevel           *int    `env:"ODO_LOG_LEVEL,noinit"`
+	OdoPodmanBackend      string  `env:"ODO_PODMAN_BACKEND,default=cli"`
 	OdoTrackingConsent    *string `env:"ODO_TRACKING_CONSENT,noinit"`
 	PodmanCmd             string  `env:"PODMAN_CMD,default=podman"`
 	TelemetryCaller       string  `env:"TELEMETRY_CALLER,default="`
 	OdoExperimentalMode   bool    `env:"ODO_EXPERIMENTAL_MODE,default=false"`
+	XdgRuntimeDir         *string `env:"XDG_RUNTIME_DIR,noinit"`
 }
 
 // GetConfiguration initializes a Configuration for odo by using the system environment.
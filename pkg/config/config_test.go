@@ -14,6 +14,7 @@ func TestDefaultValues(t *testing.T) {
 
 	checkDefaultStringValue(t, "DockerCmd", cfg.DockerCmd, "docker")
 	checkDefaultStringValue(t, "PodmanCmd", cfg.PodmanCmd, "podman")
+	checkDefaultStringValue(t, "OdoPodmanBackend", cfg.OdoPodmanBackend, "cli")
 	checkDefaultStringValue(t, "TelemetryCaller", cfg.TelemetryCaller, "")
 	checkDefaultBoolValue(t, "OdoExperimentalMode", cfg.OdoExperimentalMode, false)
 
@@ -24,6 +25,8 @@ func TestDefaultValues(t *testing.T) {
 	checkNilString(t, "OdoDebugTelemetryFile", cfg.OdoDebugTelemetryFile)
 	checkNilBool(t, "OdoDisableTelemetry", cfg.OdoDisableTelemetry)
 	checkNilString(t, "OdoTrackingConsent", cfg.OdoTrackingConsent)
+	checkNilString(t, "ContainerHost", cfg.ContainerHost)
+	checkNilString(t, "XdgRuntimeDir", cfg.XdgRuntimeDir)
 
 }
 
@@ -0,0 +1,327 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser"
+	devfilefs "github.com/devfile/library/pkg/testingutil/filesystem"
+
+	"github.com/redhat-developer/odo/pkg/component"
+	"github.com/redhat-developer/odo/pkg/devfile/image"
+	odolabels "github.com/redhat-developer/odo/pkg/labels"
+	"github.com/redhat-developer/odo/pkg/libdevfile"
+	"github.com/redhat-developer/odo/pkg/log"
+	odocontext "github.com/redhat-developer/odo/pkg/odo/context"
+	"github.com/redhat-developer/odo/pkg/podman"
+	"github.com/redhat-developer/odo/pkg/testingutil/filesystem"
+	"github.com/redhat-developer/odo/pkg/util"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog"
+)
+
+// firstHostPort is the first local port on which the ports of the Services are published
+const firstHostPort = 40001
+
+var isPortFree = util.IsPortFree
+
+// PodmanDeployClient deploys the components defined in the devfile on Podman
+type PodmanDeployClient struct {
+	podmanClient podman.Client
+	fs           filesystem.Filesystem
+}
+
+var _ Client = (*PodmanDeployClient)(nil)
+
+func NewPodmanDeployClient(podmanClient podman.Client, fs filesystem.Filesystem) *PodmanDeployClient {
+	return &PodmanDeployClient{
+		podmanClient: podmanClient,
+		fs:           fs,
+	}
+}
+
+// Deploy builds the images of the deploy command locally with Podman,
+// and runs the workloads defined by its Kubernetes components as pods on Podman
+func (o *PodmanDeployClient) Deploy(ctx context.Context) error {
+	var (
+		devfileObj    = odocontext.GetDevfileObj(ctx)
+		devfilePath   = odocontext.GetDevfilePath(ctx)
+		path          = filepath.Dir(devfilePath)
+		componentName = odocontext.GetComponentName(ctx)
+		appName       = odocontext.GetApplication(ctx)
+	)
+	handler := &podmanDeployHandler{
+		ctx:        ctx,
+		fs:         o.fs,
+		devfileObj: *devfileObj,
+		path:       path,
+		images:     map[string]bool{},
+	}
+	err := libdevfile.Deploy(*devfileObj, handler)
+	if err != nil {
+		return err
+	}
+
+	runtime := component.GetComponentRuntimeFromDevfileMetadata(devfileObj.Data.GetMetadata())
+	deployment, err := toPodmanDeployment(handler.resources, handler.images, odolabels.GetLabels(componentName, appName, runtime, odolabels.ComponentDeployMode, false))
+	if err != nil {
+		return err
+	}
+	if len(deployment.pods) == 0 {
+		return errors.New("no Deployment or Pod to run on Podman found in the Kubernetes components of the deploy command")
+	}
+
+	err = o.removeDeployedPods(componentName, appName)
+	if err != nil {
+		return err
+	}
+
+	existingVolumes, err := o.podmanClient.VolumeLs()
+	if err != nil {
+		return err
+	}
+	for i, pod := range deployment.pods {
+		var resources []unstructured.Unstructured
+		for _, resource := range deployment.resources {
+			// ConfigMaps are not created by Podman, they are only used to define the pod;
+			// other resources are created once
+			if resource.GetKind() != "ConfigMap" && i > 0 {
+				continue
+			}
+			if resource.GetKind() == "PersistentVolumeClaim" && existingVolumes[resource.GetName()] {
+				continue
+			}
+			resources = append(resources, resource)
+		}
+		log.Sectionf("Running pod on Podman: %s", pod.GetName())
+		err = o.podmanClient.PlayKube(pod, resources)
+		if err != nil {
+			return fmt.Errorf("unable to run pod %q on Podman: %w", pod.GetName(), err)
+		}
+	}
+
+	for _, port := range deployment.ports {
+		log.Infof(" - Service %q port %d is available on 127.0.0.1:%d", port.service, port.port, port.hostPort)
+	}
+	return nil
+}
+
+// removeDeployedPods stops and deletes the pods previously deployed by odo deploy for the component,
+// to replace them. The volumes are kept.
+func (o *PodmanDeployClient) removeDeployedPods(componentName string, appName string) error {
+	selector := odolabels.GetSelector(componentName, appName, odolabels.ComponentDeployMode, false)
+	pods, err := o.podmanClient.GetPodsMatchingSelector(selector)
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods.Items {
+		klog.V(3).Infof("replacing pod %q", pod.GetName())
+		err = o.podmanClient.PodStop(pod.GetName())
+		if err != nil {
+			return err
+		}
+		err = o.podmanClient.PodRm(pod.GetName())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// podmanDeployHandler collects the Kubernetes resources to deploy, and builds the images locally with Podman
+type podmanDeployHandler struct {
+	ctx        context.Context
+	fs         filesystem.Filesystem
+	devfileObj parser.DevfileObj
+	path       string
+
+	// images are the names of the images built locally
+	images map[string]bool
+	// resources are the Kubernetes resources to deploy
+	resources []unstructured.Unstructured
+}
+
+var _ libdevfile.Handler = (*podmanDeployHandler)(nil)
+
+// ApplyImage builds the OCI image with Podman, without pushing it
+func (o *podmanDeployHandler) ApplyImage(img v1alpha2.Component) error {
+	err := image.BuildSpecificImageWithPodman(o.ctx, o.fs, img)
+	if err != nil {
+		return err
+	}
+	o.images[img.Image.ImageName] = true
+	return nil
+}
+
+// ApplyKubernetes records the Kubernetes resource to deploy
+func (o *podmanDeployHandler) ApplyKubernetes(kubernetes v1alpha2.Component) error {
+	u, err := libdevfile.GetK8sComponentAsUnstructured(o.devfileObj, kubernetes.Name, o.path, devfilefs.DefaultFs{})
+	if err != nil {
+		return err
+	}
+	o.resources = append(o.resources, u)
+	return nil
+}
+
+// Execute will deploy the listed information in the `exec` section of devfile.yaml
+// We currently do NOT support this in `odo deploy`.
+func (o *podmanDeployHandler) Execute(command v1alpha2.Command) error {
+	return errors.New("exec command is not implemented for Deploy")
+}
+
+// podmanDeployment is the definition of the pods and resources to run on Podman
+type podmanDeployment struct {
+	pods []*corev1.Pod
+	// resources are the ConfigMaps, Secrets and PersistentVolumeClaims to deploy alongside the pods
+	resources []unstructured.Unstructured
+	// ports are the ports of the Services published on local ports
+	ports []publishedPort
+}
+
+type publishedPort struct {
+	service  string
+	port     int32
+	hostPort int32
+}
+
+// toPodmanDeployment converts the Kubernetes resources into pods and resources to run on Podman:
+//   - Deployments are converted into pods, with the labels of their pod template and the given labels,
+//   - the ports of the Services are published on local ports, on the containers of the pods selected by the Services,
+//   - ConfigMaps, Secrets and PersistentVolumeClaims are deployed alongside the pods,
+//   - other kinds are not supported, and ignored with a warning.
+//
+// The images built locally (in images) are not pulled.
+func toPodmanDeployment(resources []unstructured.Unstructured, images map[string]bool, odoLabels map[string]string) (podmanDeployment, error) {
+	var result podmanDeployment
+	var services []corev1.Service
+	for _, u := range resources {
+		gvk := u.GroupVersionKind()
+		switch {
+		case gvk.Group == appsv1.GroupName && gvk.Kind == "Deployment":
+			var deployment appsv1.Deployment
+			err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &deployment)
+			if err != nil {
+				return podmanDeployment{}, err
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: *deployment.Spec.Template.ObjectMeta.DeepCopy(),
+				Spec:       *deployment.Spec.Template.Spec.DeepCopy(),
+			}
+			pod.SetName(deployment.GetName())
+			result.pods = append(result.pods, pod)
+		case gvk.Group == corev1.GroupName && gvk.Kind == "Pod":
+			var pod corev1.Pod
+			err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &pod)
+			if err != nil {
+				return podmanDeployment{}, err
+			}
+			result.pods = append(result.pods, &pod)
+		case gvk.Group == corev1.GroupName && gvk.Kind == "Service":
+			var service corev1.Service
+			err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &service)
+			if err != nil {
+				return podmanDeployment{}, err
+			}
+			services = append(services, service)
+		case gvk.Group == corev1.GroupName && (gvk.Kind == "ConfigMap" || gvk.Kind == "Secret" || gvk.Kind == "PersistentVolumeClaim"):
+			result.resources = append(result.resources, u)
+		default:
+			log.Warningf("Kubernetes resource %q of kind %q is not supported on Podman and is ignored", u.GetName(), u.GetKind())
+		}
+	}
+
+	// Services are sorted to publish the same ports on the same local ports between deployments
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].GetName() < services[j].GetName()
+	})
+	usedPorts := map[int32]bool{}
+	nextPort := int32(firstHostPort)
+	for _, service := range services {
+		for _, servicePort := range service.Spec.Ports {
+			container, containerPort := findContainerPort(result.pods, service, servicePort)
+			if containerPort == nil {
+				log.Warningf("No container port found for the port %d of Service %q, the port is not published", servicePort.Port, service.GetName())
+				continue
+			}
+			if containerPort.HostPort == 0 {
+				for usedPorts[nextPort] || !isPortFree(int(nextPort)) {
+					nextPort++
+				}
+				containerPort.HostPort = nextPort
+				usedPorts[nextPort] = true
+			}
+			klog.V(4).Infof("port %d of container %q published on local port %d", containerPort.ContainerPort, container.Name, containerPort.HostPort)
+			result.ports = append(result.ports, publishedPort{
+				service:  service.GetName(),
+				port:     servicePort.Port,
+				hostPort: containerPort.HostPort,
+			})
+		}
+	}
+
+	// odo labels are added once the Services have selected the pods, as they could override the labels used by the selectors
+	for _, pod := range result.pods {
+		pod.APIVersion, pod.Kind = corev1.SchemeGroupVersion.WithKind("Pod").ToAPIVersionAndKind()
+		pod.SetNamespace("")
+		podLabels := pod.GetLabels()
+		if podLabels == nil {
+			podLabels = map[string]string{}
+		}
+		for k, v := range odoLabels {
+			podLabels[k] = v
+		}
+		pod.SetLabels(podLabels)
+		for i := range pod.Spec.Containers {
+			if images[pod.Spec.Containers[i].Image] {
+				pod.Spec.Containers[i].ImagePullPolicy = corev1.PullIfNotPresent
+			}
+		}
+	}
+	return result, nil
+}
+
+// findContainerPort returns the container and the port of the container targeted by the port of the Service,
+// in the pods selected by the Service
+func findContainerPort(pods []*corev1.Pod, service corev1.Service, servicePort corev1.ServicePort) (*corev1.Container, *corev1.ContainerPort) {
+	if len(service.Spec.Selector) == 0 {
+		return nil, nil
+	}
+	selector := labels.SelectorFromSet(service.Spec.Selector)
+	for _, pod := range pods {
+		if !selector.Matches(labels.Set(pod.GetLabels())) {
+			continue
+		}
+		for i := range pod.Spec.Containers {
+			container := &pod.Spec.Containers[i]
+			for j := range container.Ports {
+				port := &container.Ports[j]
+				targetPort := servicePort.TargetPort
+				if targetPort.Type == intstr.String {
+					if port.Name == targetPort.StrVal {
+						return container, port
+					}
+					continue
+				}
+				target := targetPort.IntVal
+				if target == 0 {
+					// the target port defaults to the port of the Service
+					target = servicePort.Port
+				}
+				if port.ContainerPort == target {
+					return container, port
+				}
+			}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,216 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/redhat-developer/odo/pkg/util"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func toUnstructured(t *testing.T, manifest string) unstructured.Unstructured {
+	var u unstructured.Unstructured
+	err := yaml.Unmarshal([]byte(manifest), &u.Object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+const deploymentManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-deployment
+  namespace: my-namespace
+spec:
+  template:
+    metadata:
+      labels:
+        app: my-app
+    spec:
+      containers:
+      - name: main
+        image: my-image
+        ports:
+        - name: http
+          containerPort: 8080
+        - containerPort: 8081
+`
+
+func Test_toPodmanDeployment(t *testing.T) {
+	odoLabels := map[string]string{
+		"app.kubernetes.io/instance": "mycmp",
+		"app":                        "app",
+	}
+	type container struct {
+		Image      string
+		PullPolicy string
+		HostPorts  []int32
+	}
+	type pod struct {
+		Name       string
+		Labels     map[string]string
+		Containers []container
+	}
+	tests := []struct {
+		name          string
+		resources     []string
+		images        map[string]bool
+		busyPorts     map[int]bool
+		wantPods      []pod
+		wantResources []string
+		wantPorts     []publishedPort
+	}{
+		{
+			name:      "Deployment is converted into a pod with odo labels",
+			resources: []string{deploymentManifest},
+			wantPods: []pod{
+				{
+					Name:   "my-deployment",
+					Labels: odoLabels,
+					Containers: []container{
+						{Image: "my-image", HostPorts: []int32{0, 0}},
+					},
+				},
+			},
+		},
+		{
+			name:      "locally built images are not pulled",
+			resources: []string{deploymentManifest},
+			images:    map[string]bool{"my-image": true},
+			wantPods: []pod{
+				{
+					Name:   "my-deployment",
+					Labels: odoLabels,
+					Containers: []container{
+						{Image: "my-image", PullPolicy: "IfNotPresent", HostPorts: []int32{0, 0}},
+					},
+				},
+			},
+		},
+		{
+			name: "ports of Services are published on free local ports",
+			resources: []string{
+				deploymentManifest,
+				`
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc2
+spec:
+  selector:
+    app: my-app
+  ports:
+  - port: 8081
+`,
+				`
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc1
+spec:
+  selector:
+    app: my-app
+  ports:
+  - port: 80
+    targetPort: http
+  - port: 9999
+`,
+			},
+			busyPorts: map[int]bool{40001: true},
+			wantPods: []pod{
+				{
+					Name:   "my-deployment",
+					Labels: odoLabels,
+					Containers: []container{
+						{Image: "my-image", HostPorts: []int32{40002, 40003}},
+					},
+				},
+			},
+			wantPorts: []publishedPort{
+				{service: "svc1", port: 80, hostPort: 40002},
+				{service: "svc2", port: 8081, hostPort: 40003},
+			},
+		},
+		{
+			name: "supported resources are kept, others are ignored",
+			resources: []string{
+				deploymentManifest,
+				"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: my-config\n",
+				"apiVersion: v1\nkind: Secret\nmetadata:\n  name: my-secret\n",
+				"apiVersion: v1\nkind: PersistentVolumeClaim\nmetadata:\n  name: my-pvc\n",
+				"apiVersion: networking.k8s.io/v1\nkind: Ingress\nmetadata:\n  name: my-ingress\n",
+			},
+			wantPods: []pod{
+				{
+					Name:   "my-deployment",
+					Labels: odoLabels,
+					Containers: []container{
+						{Image: "my-image", HostPorts: []int32{0, 0}},
+					},
+				},
+			},
+			wantResources: []string{"ConfigMap/my-config", "Secret/my-secret", "PersistentVolumeClaim/my-pvc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isPortFree = func(port int) bool {
+				return !tt.busyPorts[port]
+			}
+			defer func() { isPortFree = util.IsPortFree }()
+
+			var resources []unstructured.Unstructured
+			for _, manifest := range tt.resources {
+				resources = append(resources, toUnstructured(t, manifest))
+			}
+
+			got, err := toPodmanDeployment(resources, tt.images, odoLabels)
+			if err != nil {
+				t.Fatalf("toPodmanDeployment() unexpected error: %v", err)
+			}
+
+			var gotPods []pod
+			for _, p := range got.pods {
+				if p.GetNamespace() != "" {
+					t.Errorf("namespace of pod %q should be empty, got %q", p.GetName(), p.GetNamespace())
+				}
+				gotPod := pod{
+					Name:   p.GetName(),
+					Labels: p.GetLabels(),
+				}
+				for _, c := range p.Spec.Containers {
+					gotContainer := container{
+						Image:      c.Image,
+						PullPolicy: string(c.ImagePullPolicy),
+					}
+					for _, port := range c.Ports {
+						gotContainer.HostPorts = append(gotContainer.HostPorts, port.HostPort)
+					}
+					gotPod.Containers = append(gotPod.Containers, gotContainer)
+				}
+				gotPods = append(gotPods, gotPod)
+			}
+			if diff := cmp.Diff(tt.wantPods, gotPods); diff != "" {
+				t.Errorf("toPodmanDeployment() pods mismatch (-want +got):\n%s", diff)
+			}
+
+			var gotResources []string
+			for _, u := range got.resources {
+				gotResources = append(gotResources, u.GetKind()+"/"+u.GetName())
+			}
+			if diff := cmp.Diff(tt.wantResources, gotResources, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("toPodmanDeployment() resources mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantPorts, got.ports, cmp.AllowUnexported(publishedPort{}), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("toPodmanDeployment() ports mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,25 +2,118 @@ package common
 
 import (
 	"fmt"
+	"path"
+	"strings"
 
 	"github.com/devfile/library/pkg/devfile/generator"
 
+	"github.com/redhat-developer/odo/pkg/log"
+	"github.com/redhat-developer/odo/pkg/sync"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog"
 )
 
-// GetFirstContainerWithSourceVolume returns the first container that set mountSources: true as well
-// as the path to the source volume inside the container.
-// Because the source volume is shared across all components that need it, we only need to sync once,
-// so we only need to find one container. If no container was found, that means there's no
-// container to sync to, so return an error
-func GetFirstContainerWithSourceVolume(containers []corev1.Container) (string, string, error) {
+// ContainerWithSourceVolume is a container mounting the source volume
+type ContainerWithSourceVolume struct {
+	// Name is the name of the container
+	Name string
+	// SyncFolder is the path of the sources inside the container, depending on the sourceMapping of the container
+	SyncFolder string
+}
+
+// GetContainersWithSourceVolume returns the containers that set mountSources: true, in the order of containers,
+// along with the path to the sources inside each container.
+// As the source volume is shared across all the containers mounting it, syncing into one container makes the files
+// visible in the others: a container whose sources are at the same location of the volume as a previous container,
+// even if mounted at a different path with sourceMapping, is not returned, so the files are synced only once there.
+// If no container was found, that means there's no container to sync to, so return an error
+func GetContainersWithSourceVolume(containers []corev1.Container) ([]ContainerWithSourceVolume, error) {
+	var result []ContainerWithSourceVolume
+	locations := map[string]bool{}
 	for _, c := range containers {
 		for _, env := range c.Env {
-			if env.Name == generator.EnvProjectsSrc {
-				return c.Name, env.Value, nil
+			if env.Name != generator.EnvProjectsSrc {
+				continue
 			}
+			if location := getVolumeLocation(c, env.Value); location != "" {
+				if locations[location] {
+					klog.V(4).Infof("sources of container %q are already synced through another container", c.Name)
+					break
+				}
+				locations[location] = true
+			}
+			result = append(result, ContainerWithSourceVolume{
+				Name:       c.Name,
+				SyncFolder: env.Value,
+			})
+			break
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("in order to sync files, odo requires at least one component in a devfile to set 'mountSources: true'")
+	}
+	return result, nil
+}
+
+// getVolumeLocation returns the location of dir inside the volumes mounted by container, in the form volume:path,
+// or an empty string if dir is not inside a volume mounted by container
+func getVolumeLocation(container corev1.Container, dir string) string {
+	dir = path.Clean(dir)
+	var mount *corev1.VolumeMount
+	for i := range container.VolumeMounts {
+		mountPath := path.Clean(container.VolumeMounts[i].MountPath)
+		if dir != mountPath && !strings.HasPrefix(dir, strings.TrimSuffix(mountPath, "/")+"/") {
+			continue
+		}
+		// the deepest mount contains dir
+		if mount == nil || len(mountPath) > len(path.Clean(mount.MountPath)) {
+			mount = &container.VolumeMounts[i]
 		}
 	}
+	if mount == nil {
+		return ""
+	}
+	rel := strings.TrimPrefix(strings.TrimPrefix(dir, path.Clean(mount.MountPath)), "/")
+	return mount.Name + ":" + path.Join("/", mount.SubPath, rel)
+}
+
+// KeyboardCommandsPromptMessage are the lines of the prompt describing the keys controlling the application, on all platforms
+const KeyboardCommandsPromptMessage = `     [f] - Synchronize all the files, including the files already synchronized
+     [r] - Rebuild and restart the application, without synchronizing the files
+     [d] - Switch between the run and the debug commands
+     [l] - Show or hide the logs of the application
+     [s] - Show the state of the component and the forwarded ports
+`
 
-	return "", "", fmt.Errorf("in order to sync files, odo requires at least one component in a devfile to set 'mountSources: true'")
+// SyncBackPromptMessage is the line of the prompt describing the key copying the sync-back paths from the container
+const SyncBackPromptMessage = `     [b] - Copy the sync-back paths from the container to the local directory
+`
+
+// SyncBack copies the paths from the first container of the pod mounting the sources back into the local directory path.
+// As all the containers mounting the sources share the same volume, the files are copied from the first one only.
+func SyncBack(syncClient sync.Client, pod *corev1.Pod, componentName string, path string, paths []sync.SyncBackPath) error {
+	containers, err := GetContainersWithSourceVolume(pod.Spec.Containers)
+	if err != nil {
+		return fmt.Errorf("error while retrieving containers from pod %s with a mounted project volume: %w", pod.GetName(), err)
+	}
+
+	s := log.Spinner("Syncing files back from the container")
+	defer s.End(false)
+	files, err := syncClient.SyncBack(sync.SyncBackParameters{
+		Path: path,
+		CompInfo: sync.ComponentInfo{
+			ComponentName: componentName,
+			ContainerName: containers[0].Name,
+			PodName:       pod.GetName(),
+			SyncFolder:    containers[0].SyncFolder,
+		},
+		Paths: paths,
+	})
+	if err != nil {
+		return err
+	}
+	s.EndWithStatus(fmt.Sprintf("Syncing files back from the container (%d files)", len(files)), true)
+	return nil
 }
@@ -4,17 +4,17 @@ import (
 	"testing"
 
 	"github.com/devfile/library/pkg/devfile/generator"
+	"github.com/google/go-cmp/cmp"
 
 	corev1 "k8s.io/api/core/v1"
 )
 
-func TestGetFirstContainerWithSourceVolume(t *testing.T) {
+func TestGetContainersWithSourceVolume(t *testing.T) {
 	tests := []struct {
-		name           string
-		containers     []corev1.Container
-		want           string
-		wantSourcePath string
-		wantErr        bool
+		name       string
+		containers []corev1.Container
+		want       []ContainerWithSourceVolume
+		wantErr    bool
 	}{
 		{
 			name: "Case: One container, Project Source Env",
@@ -33,9 +33,10 @@ func TestGetFirstContainerWithSourceVolume(t *testing.T) {
 					},
 				},
 			},
-			want:           "test",
-			wantSourcePath: "/mypath",
-			wantErr:        false,
+			want: []ContainerWithSourceVolume{
+				{Name: "test", SyncFolder: "/mypath"},
+			},
+			wantErr: false,
 		},
 		{
 			name: "Case: Multiple containers, multiple Project Source Env",
@@ -67,9 +68,79 @@ func TestGetFirstContainerWithSourceVolume(t *testing.T) {
 					},
 				},
 			},
-			want:           "test1",
-			wantSourcePath: "/mypath1",
-			wantErr:        false,
+			want: []ContainerWithSourceVolume{
+				{Name: "test1", SyncFolder: "/mypath1"},
+				{Name: "test2", SyncFolder: "/mypath2"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case: Multiple containers mounting the same location of the source volume at different paths",
+			containers: []corev1.Container{
+				{
+					Name: "frontend",
+					Env: []corev1.EnvVar{
+						{
+							Name:  generator.EnvProjectsSrc,
+							Value: "/projects",
+						},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "odo-shared-data", MountPath: "/opt/odo/"},
+						{Name: "odo-projects", MountPath: "/projects"},
+					},
+				},
+				{
+					Name: "backend",
+					Env: []corev1.EnvVar{
+						{
+							Name:  generator.EnvProjectsSrc,
+							Value: "/src",
+						},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "odo-projects", MountPath: "/src"},
+					},
+				},
+			},
+			want: []ContainerWithSourceVolume{
+				{Name: "frontend", SyncFolder: "/projects"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case: Multiple containers mounting different locations of the source volume",
+			containers: []corev1.Container{
+				{
+					Name: "frontend",
+					Env: []corev1.EnvVar{
+						{
+							Name:  generator.EnvProjectsSrc,
+							Value: "/projects/frontend",
+						},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "odo-projects", MountPath: "/projects"},
+					},
+				},
+				{
+					Name: "backend",
+					Env: []corev1.EnvVar{
+						{
+							Name:  generator.EnvProjectsSrc,
+							Value: "/src/backend",
+						},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "odo-projects", MountPath: "/src/"},
+					},
+				},
+			},
+			want: []ContainerWithSourceVolume{
+				{Name: "frontend", SyncFolder: "/projects/frontend"},
+				{Name: "backend", SyncFolder: "/src/backend"},
+			},
+			wantErr: false,
 		},
 		{
 			name: "Case: Multiple containers, no Project Source Env",
@@ -98,16 +169,13 @@ func TestGetFirstContainerWithSourceVolume(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			container, syncFolder, err := GetFirstContainerWithSourceVolume(tt.containers)
-			if container != tt.want {
-				t.Errorf("expected %s, actual %s", tt.want, container)
-			}
-			if syncFolder != tt.wantSourcePath {
-				t.Errorf("expected %s, actual %s", tt.wantSourcePath, syncFolder)
-			}
+			got, err := GetContainersWithSourceVolume(tt.containers)
 			if !tt.wantErr == (err != nil) {
 				t.Errorf("expected %v, actual %v", tt.wantErr, err)
 			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetContainersWithSourceVolume() mismatch (-want +got):\n%s", diff)
+			}
 		})
 	}
 }
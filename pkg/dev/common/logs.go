@@ -0,0 +1,184 @@
+package common
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog"
+
+	"github.com/redhat-developer/odo/pkg/log"
+	"github.com/redhat-developer/odo/pkg/platform"
+)
+
+// maxPausedLines is the maximum number of lines kept while the printer is paused.
+// The oldest lines are discarded when the limit is reached
+const maxPausedLines = 1000
+
+// LogsPrinter follows the logs of the containers of the component pod, and prints them when enabled,
+// each line being prefixed with the name of the container, in a colour specific to the container.
+// The logs are followed as soon as the pod is known, so the lines emitted before the printer is enabled
+// are discarded instead of being displayed when the printer is enabled.
+// The printer can be paused, while files are synchronized or the application is built; the lines emitted
+// while the printer is paused are printed when it is resumed.
+// When the logs of a restarted container are followed again, only the lines emitted after the last line read are printed.
+type LogsPrinter struct {
+	platformClient platform.Client
+	out            io.Writer
+
+	mu      sync.Mutex
+	enabled bool
+	paused  bool
+	// pending are the lines emitted while the printer is paused
+	pending []string
+	// colours are the colours of the containers, by name
+	colours map[string]color.Attribute
+	podName string
+	// following are the names of the containers of the pod whose logs are currently followed
+	following map[string]bool
+	// lastTimestamps are the timestamps of the last lines read from the containers of the pod, by name
+	lastTimestamps map[string]time.Time
+	ctx            context.Context
+	cancel         context.CancelFunc
+}
+
+// NewLogsPrinter returns a printer of the logs of the containers into out, initially enabled if enabled is true
+func NewLogsPrinter(platformClient platform.Client, out io.Writer, enabled bool) *LogsPrinter {
+	return &LogsPrinter{
+		platformClient: platformClient,
+		out:            out,
+		enabled:        enabled,
+		colours:        map[string]color.Attribute{},
+	}
+}
+
+// Toggle enables the printer if disabled, disables it otherwise, and returns true if the printer is enabled
+func (o *LogsPrinter) Toggle() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.enabled = !o.enabled
+	if !o.enabled {
+		o.pending = nil
+	}
+	return o.enabled
+}
+
+// Pause stops printing the logs, until Resume is called
+func (o *LogsPrinter) Pause() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.paused = true
+}
+
+// Resume prints the lines emitted since the printer was paused, and prints the next lines as they are emitted
+func (o *LogsPrinter) Resume() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.paused = false
+	for _, line := range o.pending {
+		fmt.Fprint(o.out, line)
+	}
+	o.pending = nil
+}
+
+// Follow follows the logs of the containers of pod, until ctx is done.
+// It stops following the logs of the pod previously followed, if any.
+// For the pod already followed, only the logs of the containers which have been restarted are followed again,
+// from the last line read.
+func (o *LogsPrinter) Follow(ctx context.Context, pod *corev1.Pod) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if pod.GetName() != o.podName {
+		if o.cancel != nil {
+			o.cancel()
+		}
+		o.ctx, o.cancel = context.WithCancel(ctx)
+		o.podName = pod.GetName()
+		o.following = map[string]bool{}
+		o.lastTimestamps = map[string]time.Time{}
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if o.following[container.Name] {
+			continue
+		}
+		since := o.lastTimestamps[container.Name]
+		rd, err := o.platformClient.FollowPodLogsSince(pod.GetName(), container.Name, since)
+		if err != nil {
+			return fmt.Errorf("unable to get the logs of container %s: %w", container.Name, err)
+		}
+		o.following[container.Name] = true
+		if _, ok := o.colours[container.Name]; !ok {
+			o.colours[container.Name] = log.ColorPicker()
+		}
+		go o.print(o.ctx, container.Name, color.New(o.colours[container.Name]), rd, since)
+	}
+	return nil
+}
+
+// print prints the lines read from rd until rd is closed or ctx is done, prefixed with containerName
+// and coloured with colour, when the printer is enabled.
+// The lines of rd are prefixed with their timestamp; the lines emitted at or before since have already been read and are skipped.
+func (o *LogsPrinter) print(ctx context.Context, containerName string, colour *color.Color, rd io.ReadCloser, since time.Time) {
+	go func() {
+		<-ctx.Done()
+		_ = rd.Close()
+	}()
+
+	scanner := bufio.NewScanner(rd)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			break
+		}
+		timestamp, text, ok := splitTimestamp(scanner.Text())
+		if ok && !since.IsZero() && !timestamp.After(since) {
+			continue
+		}
+		line := colour.Sprintf("%s: %s", containerName, text) + "\n"
+		o.mu.Lock()
+		if ok && ctx.Err() == nil {
+			o.lastTimestamps[containerName] = timestamp
+		}
+		switch {
+		case !o.enabled:
+		case o.paused:
+			o.pending = append(o.pending, line)
+			if len(o.pending) > maxPausedLines {
+				o.pending = o.pending[len(o.pending)-maxPausedLines:]
+			}
+		default:
+			fmt.Fprint(o.out, line)
+		}
+		o.mu.Unlock()
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		klog.V(4).Infof("error reading the logs of container %s: %v", containerName, err)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if ctx.Err() == nil {
+		// the container has stopped, its logs need to be followed again when it is restarted
+		delete(o.following, containerName)
+	}
+}
+
+// splitTimestamp splits a line of logs into its RFC3339Nano timestamp prefix and its text.
+// ok is false if the line is not prefixed with a timestamp, the text being the whole line.
+func splitTimestamp(line string) (timestamp time.Time, text string, ok bool) {
+	prefix, text, found := strings.Cut(line, " ")
+	if !found {
+		prefix = line
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return time.Time{}, line, false
+	}
+	return timestamp, text, true
+}
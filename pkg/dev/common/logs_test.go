@@ -0,0 +1,201 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/redhat-developer/odo/pkg/kclient"
+)
+
+func TestLogsPrinter(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "runtime"}},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	kc := kclient.NewMockClientInterface(ctrl)
+	gomock.InOrder(
+		kc.EXPECT().FollowPodLogsSince("pod", "runtime", time.Time{}).
+			Return(io.NopCloser(strings.NewReader("2023-01-02T10:00:00.1Z before\n")), nil),
+		kc.EXPECT().FollowPodLogsSince("pod", "runtime", time.Date(2023, 1, 2, 10, 0, 0, 100000000, time.UTC)).
+			Return(io.NopCloser(strings.NewReader("2023-01-02T10:00:01Z after\n")), nil),
+	)
+
+	var out bytes.Buffer
+	printer := NewLogsPrinter(kc, &out, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the logs emitted before the printer is enabled are discarded
+	if err := printer.Follow(ctx, pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitContainerStopped(t, printer, "runtime")
+	if got := printer.Toggle(); !got {
+		t.Errorf("Toggle() = %v, want true", got)
+	}
+
+	// the logs of the restarted container are followed again
+	if err := printer.Follow(ctx, pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitContainerStopped(t, printer, "runtime")
+
+	printer.mu.Lock()
+	defer printer.mu.Unlock()
+	if got, want := out.String(), "runtime: after\n"; got != want {
+		t.Errorf("printed logs = %q, want %q", got, want)
+	}
+}
+
+func TestLogsPrinter_Pause(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "runtime"}},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	kc := kclient.NewMockClientInterface(ctrl)
+	kc.EXPECT().FollowPodLogsSince("pod", "runtime", time.Time{}).
+		Return(io.NopCloser(strings.NewReader("2023-01-02T10:00:00Z during sync\n")), nil)
+
+	var out bytes.Buffer
+	printer := NewLogsPrinter(kc, &out, true)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	printer.Pause()
+	if err := printer.Follow(ctx, pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitContainerStopped(t, printer, "runtime")
+
+	printer.mu.Lock()
+	if got := out.String(); got != "" {
+		t.Errorf("logs printed while paused = %q, want none", got)
+	}
+	printer.mu.Unlock()
+
+	// the logs emitted while the printer is paused are printed when it is resumed
+	printer.Resume()
+	if got, want := out.String(), "runtime: during sync\n"; got != want {
+		t.Errorf("printed logs = %q, want %q", got, want)
+	}
+}
+
+func TestLogsPrinter_restartedContainer(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "runtime"}},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	kc := kclient.NewMockClientInterface(ctrl)
+	gomock.InOrder(
+		kc.EXPECT().FollowPodLogsSince("pod", "runtime", time.Time{}).
+			Return(io.NopCloser(strings.NewReader(
+				"2023-01-02T10:00:00.1Z first\n"+
+					"2023-01-02T10:00:00.2Z second\n")), nil),
+		// the logs are requested from the second of the last line read, and contain the lines already printed
+		kc.EXPECT().FollowPodLogsSince("pod", "runtime", time.Date(2023, 1, 2, 10, 0, 0, 200000000, time.UTC)).
+			Return(io.NopCloser(strings.NewReader(
+				"2023-01-02T10:00:00.1Z first\n"+
+					"2023-01-02T10:00:00.2Z second\n"+
+					"2023-01-02T10:00:00.3Z after restart\n")), nil),
+		kc.EXPECT().FollowPodLogsSince("pod", "runtime", time.Date(2023, 1, 2, 10, 0, 0, 300000000, time.UTC)).
+			Return(io.NopCloser(strings.NewReader("")), nil),
+	)
+
+	var out bytes.Buffer
+	printer := NewLogsPrinter(kc, &out, true)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// each reconciliation follows again the logs of the stopped container
+	for i := 0; i < 3; i++ {
+		if err := printer.Follow(ctx, pod); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		waitContainerStopped(t, printer, "runtime")
+	}
+
+	printer.mu.Lock()
+	defer printer.mu.Unlock()
+	if got, want := out.String(), "runtime: first\nruntime: second\nruntime: after restart\n"; got != want {
+		t.Errorf("printed logs = %q, want %q", got, want)
+	}
+}
+
+func Test_splitTimestamp(t *testing.T) {
+	tests := []struct {
+		name          string
+		line          string
+		wantTimestamp time.Time
+		wantText      string
+		wantOk        bool
+	}{
+		{
+			name:          "timestamped line",
+			line:          "2023-01-02T10:00:00.123456789Z Listening on port 3000",
+			wantTimestamp: time.Date(2023, 1, 2, 10, 0, 0, 123456789, time.UTC),
+			wantText:      "Listening on port 3000",
+			wantOk:        true,
+		},
+		{
+			name:          "timestamped empty line",
+			line:          "2023-01-02T10:00:00Z",
+			wantTimestamp: time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC),
+			wantOk:        true,
+		},
+		{
+			name:          "timestamp with a time zone",
+			line:          "2023-01-02T11:00:00.5+01:00 a line",
+			wantTimestamp: time.Date(2023, 1, 2, 10, 0, 0, 500000000, time.UTC),
+			wantText:      "a line",
+			wantOk:        true,
+		},
+		{
+			name:     "line without timestamp",
+			line:     "Listening on port 3000",
+			wantText: "Listening on port 3000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTimestamp, gotText, gotOk := splitTimestamp(tt.line)
+			if !gotTimestamp.Equal(tt.wantTimestamp) || gotText != tt.wantText || gotOk != tt.wantOk {
+				t.Errorf("splitTimestamp() = %v, %q, %v, want %v, %q, %v", gotTimestamp, gotText, gotOk, tt.wantTimestamp, tt.wantText, tt.wantOk)
+			}
+		})
+	}
+}
+
+// waitContainerStopped waits for the end of the logs of the container
+func waitContainerStopped(t *testing.T, printer *LogsPrinter, containerName string) {
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		printer.mu.Lock()
+		following := printer.following[containerName]
+		printer.mu.Unlock()
+		if !following {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("the logs of container %s are still followed", containerName)
+}
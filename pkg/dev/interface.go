@@ -3,6 +3,9 @@ package dev
 import (
 	"context"
 	"io"
+	"time"
+
+	"github.com/redhat-developer/odo/pkg/sync"
 )
 
 type StartOptions struct {
@@ -20,8 +23,16 @@ type StartOptions struct {
 	RandomPorts bool
 	// if WatchFiles is set, files changes will trigger a new sync to the container
 	WatchFiles bool
+	// PollInterval is the interval at which the files are polled for changes, instead of using filesystem notifications, if not zero
+	PollInterval time.Duration
 	// Variables to override in the Devfile
 	Variables map[string]string
+	// SyncBack are the paths to copy from the container back to the local directory, on demand
+	SyncBack []sync.SyncBackPath
+	// if Logs is set, the logs of the containers are displayed from the start, instead of when requested by the user
+	Logs bool
+	// APIServerAddress is the address on which the API server of the session listens, in the form host:port, if not empty
+	APIServerAddress string
 }
 
 type Client interface {
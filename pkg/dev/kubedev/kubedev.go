@@ -9,6 +9,7 @@ import (
 	"github.com/redhat-developer/odo/pkg/binding"
 	_delete "github.com/redhat-developer/odo/pkg/component/delete"
 	"github.com/redhat-developer/odo/pkg/dev"
+	"github.com/redhat-developer/odo/pkg/dev/common"
 	"github.com/redhat-developer/odo/pkg/devfile"
 	"github.com/redhat-developer/odo/pkg/exec"
 	"github.com/redhat-developer/odo/pkg/kclient"
@@ -43,6 +44,9 @@ type DevClient struct {
 	filesystem        filesystem.Filesystem
 	execClient        exec.Client
 	deleteClient      _delete.Client
+
+	// logs prints the logs of the application, when enabled by the user
+	logs *common.LogsPrinter
 }
 
 var _ dev.Client = (*DevClient)(nil)
@@ -102,6 +106,7 @@ func (o *DevClient) Start(
 		Debug:           options.Debug,
 		DevfileBuildCmd: options.BuildCommand,
 		DevfileRunCmd:   options.RunCommand,
+		DevfileDebugCmd: options.DebugCommand,
 		RandomPorts:     options.RandomPorts,
 		ErrOut:          errOut,
 	}
@@ -114,6 +119,14 @@ func (o *DevClient) Start(
 	}
 	klog.V(4).Infoln("Successfully created inner-loop resources")
 
+	o.logs = common.NewLogsPrinter(o.kubernetesClient, out, options.Logs)
+	o.followLogs(ctx, componentName, componentStatus)
+
+	prompt := promptMessage + common.KeyboardCommandsPromptMessage
+	if len(options.SyncBack) > 0 {
+		prompt += common.SyncBackPromptMessage
+	}
+
 	watchParameters := watch.WatchParameters{
 		DevfilePath:         devfilePath,
 		Path:                path,
@@ -125,12 +138,18 @@ func (o *DevClient) Start(
 		Debug:               options.Debug,
 		DevfileBuildCmd:     options.BuildCommand,
 		DevfileRunCmd:       options.RunCommand,
+		DevfileDebugCmd:     options.DebugCommand,
 		Variables:           options.Variables,
 		RandomPorts:         options.RandomPorts,
 		WatchFiles:          options.WatchFiles,
+		PollInterval:        options.PollInterval,
 		WatchCluster:        true,
 		ErrOut:              errOut,
-		PromptMessage:       promptMessage,
+		PromptMessage:       prompt,
+		SyncBack:            options.SyncBack,
+		SyncBackHandler:     o.syncBack,
+		ToggleLogsHandler:   o.logs.Toggle,
+		APIServerAddress:    options.APIServerAddress,
 	}
 
 	return o.watchClient.WatchAndPush(out, watchParameters, ctx, componentStatus)
@@ -145,14 +164,43 @@ func (o *DevClient) regenerateAdapterAndPush(ctx context.Context, pushParams ada
 		return fmt.Errorf("unable to generate component from watch parameters: %w", err)
 	}
 
+	// The logs of the application are not mixed with the output of the synchronization and of the build
+	o.logs.Pause()
+	defer o.logs.Resume()
 	err = adapter.Push(ctx, pushParams, componentStatus)
 	if err != nil {
 		return fmt.Errorf("watch command was unable to push component: %w", err)
 	}
+	o.followLogs(ctx, watchParams.ComponentName, *componentStatus)
 
 	return nil
 }
 
+// followLogs follows the logs of the pod of the component, once the component is ready.
+// The errors are only logged, as the logs are not needed to run the application
+func (o *DevClient) followLogs(ctx context.Context, componentName string, componentStatus watch.ComponentStatus) {
+	if componentStatus.State != watch.StateReady {
+		return
+	}
+	pod, err := o.kubernetesClient.GetPodUsingComponentName(componentName)
+	if err != nil {
+		klog.V(4).Infof("unable to get pod for component %s: %v", componentName, err)
+		return
+	}
+	if err = o.logs.Follow(ctx, pod); err != nil {
+		klog.V(4).Infof("unable to follow the logs of pod %s: %v", pod.GetName(), err)
+	}
+}
+
+// syncBack copies the sync-back paths from the container of the component back to the local directory
+func (o *DevClient) syncBack(ctx context.Context, watchParams watch.WatchParameters) error {
+	pod, err := o.kubernetesClient.GetPodUsingComponentName(watchParams.ComponentName)
+	if err != nil {
+		return fmt.Errorf("unable to get pod for component %s: %w", watchParams.ComponentName, err)
+	}
+	return common.SyncBack(o.syncClient, pod, watchParams.ComponentName, watchParams.Path, watchParams.SyncBack)
+}
+
 func (o *DevClient) regenerateComponentAdapterFromWatchParams(parameters watch.WatchParameters) (component.ComponentAdapter, error) {
 	devObj, err := devfile.ParseAndValidateFromFileWithVariables(location.DevfileLocation(""), parameters.Variables)
 	if err != nil {
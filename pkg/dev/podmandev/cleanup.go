@@ -6,15 +6,42 @@ import (
 	"io"
 
 	"k8s.io/klog"
+
+	"github.com/redhat-developer/odo/pkg/component"
+	"github.com/redhat-developer/odo/pkg/libdevfile"
+	odocontext "github.com/redhat-developer/odo/pkg/odo/context"
 )
 
 func (o *DevClient) CleanupResources(ctx context.Context, out io.Writer) error {
-	fmt.Printf("Cleaning up resources\n")
+	var (
+		appName       = odocontext.GetApplication(ctx)
+		componentName = odocontext.GetComponentName(ctx)
+		devfileObj    = odocontext.GetDevfileObj(ctx)
+	)
+
+	fmt.Fprintf(out, "Cleaning up resources\n")
 
 	if o.deployedPod == nil {
 		return nil
 	}
 
+	if devfileObj != nil && libdevfile.HasPreStopEvents(*devfileObj) {
+		execHandler := component.NewExecHandler(
+			o.podmanClient,
+			o.execClient,
+			appName,
+			componentName,
+			o.deployedPod.GetName(),
+			"",
+			false,
+		)
+		err := libdevfile.ExecPreStopEvents(*devfileObj, execHandler)
+		if err != nil {
+			klog.V(4).Infof("Failed to execute %q event commands for component %q, cause: %v", libdevfile.PreStop, componentName, err)
+			fmt.Fprintln(out, "Failed to execute preStop events")
+		}
+	}
+
 	err := o.podmanClient.PodStop(o.deployedPod.GetName())
 	if err != nil {
 		return err
@@ -0,0 +1,96 @@
+package podmandev
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/devfile/library/pkg/devfile/parser"
+	"github.com/devfile/library/pkg/testingutil/filesystem"
+	"github.com/golang/mock/gomock"
+
+	"github.com/redhat-developer/odo/pkg/exec"
+	odocontext "github.com/redhat-developer/odo/pkg/odo/context"
+	"github.com/redhat-developer/odo/pkg/podman"
+	odoTestingUtil "github.com/redhat-developer/odo/pkg/testingutil"
+)
+
+func TestDevClient_CleanupResources(t *testing.T) {
+	fs := filesystem.NewFakeFs()
+	preStopCmd := []string{"/bin/sh", "-c", "cd /projects/nodejs-starter && (echo \"Hello World!\") 1>>/proc/1/fd/1 2>>/proc/1/fd/2"}
+
+	tests := []struct {
+		name       string
+		devfileObj func() parser.DevfileObj
+		// execCmd sets the expectations of the execution of the preStop commands,
+		// and returns the last expected call, if any
+		execCmd    func(client *podman.MockClient) *gomock.Call
+		wantOutput string
+	}{
+		{
+			name: "no preStop event",
+			devfileObj: func() parser.DevfileObj {
+				return odoTestingUtil.GetTestDevfileObj(fs)
+			},
+			execCmd: func(client *podman.MockClient) *gomock.Call { return nil },
+		},
+		{
+			name: "preStop events are executed before the pod is removed",
+			devfileObj: func() parser.DevfileObj {
+				return odoTestingUtil.GetTestDevfileObjWithPreStopEvents(fs, "runtime", "echo \"Hello World!\"")
+			},
+			execCmd: func(client *podman.MockClient) *gomock.Call {
+				return client.EXPECT().ExecCMDInContainer("runtime", "mycmp-app", preStopCmd, gomock.Any(), gomock.Any(), nil, false).Return(nil)
+			},
+		},
+		{
+			name: "failure of preStop events is reported and does not prevent the pod removal",
+			devfileObj: func() parser.DevfileObj {
+				return odoTestingUtil.GetTestDevfileObjWithPreStopEvents(fs, "runtime", "echo \"Hello World!\"")
+			},
+			execCmd: func(client *podman.MockClient) *gomock.Call {
+				execCall := client.EXPECT().ExecCMDInContainer("runtime", "mycmp-app", preStopCmd, gomock.Any(), gomock.Any(), nil, false).Return(errors.New("some error"))
+				// the logs of the container are retrieved when the command fails
+				client.EXPECT().GetRunningPodFromSelector(gomock.Any()).Return(basePod.DeepCopy(), nil).AnyTimes()
+				client.EXPECT().GetPodLogs("mycmp-app", gomock.Any(), gomock.Any()).Return(nil, errors.New("an error")).AnyTimes()
+				return execCall
+			},
+			wantOutput: "Failed to execute preStop events",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			podmanClient := podman.NewMockClient(ctrl)
+			execCall := tt.execCmd(podmanClient)
+			stopCall := podmanClient.EXPECT().PodStop("mycmp-app").Return(nil)
+			if execCall != nil {
+				stopCall.After(execCall)
+			}
+			rmCall := podmanClient.EXPECT().PodRm("mycmp-app").Return(nil).After(stopCall)
+			podmanClient.EXPECT().VolumeRm("odo-projects-mycmp-app").Return(nil).After(rmCall)
+			podmanClient.EXPECT().VolumeRm("odo-shared-data-mycmp-app").Return(nil).After(rmCall)
+
+			o := &DevClient{
+				podmanClient: podmanClient,
+				execClient:   exec.NewExecClient(podmanClient),
+				deployedPod:  basePod.DeepCopy(),
+			}
+			devfileObj := tt.devfileObj()
+			ctx := odocontext.WithApplication(context.Background(), appName)
+			ctx = odocontext.WithComponentName(ctx, devfileName)
+			ctx = odocontext.WithDevfileObj(ctx, &devfileObj)
+
+			var out bytes.Buffer
+			err := o.CleanupResources(ctx, &out)
+			if err != nil {
+				t.Fatalf("CleanupResources() unexpected error: %v", err)
+			}
+			if tt.wantOutput != "" && !strings.Contains(out.String(), tt.wantOutput) {
+				t.Errorf("CleanupResources() output = %q, should contain %q", out.String(), tt.wantOutput)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/redhat-developer/odo/pkg/exec"
 	"github.com/redhat-developer/odo/pkg/libdevfile"
 	"github.com/redhat-developer/odo/pkg/platform"
+	"github.com/redhat-developer/odo/pkg/remotecmd"
 )
 
 type commandHandler struct {
@@ -42,3 +43,14 @@ func (a commandHandler) Execute(devfileCmd devfilev1.Command) error {
 		a.componentName,
 	)
 }
+
+// IsRemoteProcessForCommandRunning returns true if the command is running
+func (a commandHandler) IsRemoteProcessForCommandRunning(command devfilev1.Command, podName string) (bool, error) {
+	remoteProcess, err := remotecmd.NewKubeExecProcessHandler(a.execClient).GetProcessInfoForCommand(
+		remotecmd.CommandDefinition{Id: command.Id}, podName, command.Exec.Component)
+	if err != nil {
+		return false, err
+	}
+
+	return remoteProcess.Status == remotecmd.Running, nil
+}
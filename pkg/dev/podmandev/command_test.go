@@ -0,0 +1,78 @@
+package podmandev
+
+import (
+	"testing"
+
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/golang/mock/gomock"
+
+	"github.com/redhat-developer/odo/pkg/exec"
+)
+
+func Test_commandHandler_IsRemoteProcessForCommandRunning(t *testing.T) {
+	command := devfilev1.Command{
+		Id: "run",
+		CommandUnion: devfilev1.CommandUnion{
+			Exec: &devfilev1.ExecCommand{
+				Component:   "runtime",
+				CommandLine: "npm start",
+			},
+		},
+	}
+	pidCmd := []string{"/bin/sh", "-c", "cat /opt/odo/.odo_cmd_run.pid || true"}
+	killCmd := []string{"/bin/sh", "-c", "kill -0 123; echo $?"}
+
+	tests := []struct {
+		name       string
+		execClient func(ctrl *gomock.Controller) exec.Client
+		want       bool
+		wantErr    bool
+	}{
+		{
+			name: "command never started",
+			execClient: func(ctrl *gomock.Controller) exec.Client {
+				client := exec.NewMockClient(ctrl)
+				client.EXPECT().ExecuteCommand(pidCmd, "mycmp-app", "runtime", false, nil, nil).Return(nil, nil, nil)
+				return client
+			},
+			want: false,
+		},
+		{
+			name: "command running",
+			execClient: func(ctrl *gomock.Controller) exec.Client {
+				client := exec.NewMockClient(ctrl)
+				client.EXPECT().ExecuteCommand(pidCmd, "mycmp-app", "runtime", false, nil, nil).Return([]string{"123"}, nil, nil)
+				client.EXPECT().ExecuteCommand(killCmd, "mycmp-app", "runtime", false, nil, nil).Return([]string{"0"}, nil, nil)
+				return client
+			},
+			want: true,
+		},
+		{
+			name: "command crashed",
+			execClient: func(ctrl *gomock.Controller) exec.Client {
+				client := exec.NewMockClient(ctrl)
+				client.EXPECT().ExecuteCommand(pidCmd, "mycmp-app", "runtime", false, nil, nil).Return([]string{"123", "1"}, nil, nil)
+				client.EXPECT().ExecuteCommand(killCmd, "mycmp-app", "runtime", false, nil, nil).Return([]string{"1"}, nil, nil)
+				return client
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			a := commandHandler{
+				execClient: tt.execClient(ctrl),
+				podName:    "mycmp-app",
+			}
+			got, err := a.IsRemoteProcessForCommandRunning(command, "mycmp-app")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsRemoteProcessForCommandRunning() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsRemoteProcessForCommandRunning() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,6 +16,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
+const (
+	// firstHostPort is the first local port assigned to the container ports, as done when port forwarding on a cluster
+	firstHostPort = 40001
+)
+
+var (
+	isPortFree        = util.IsPortFree
+	getRandomFreePort = util.GetRandomFreePort
+)
+
 func createPodFromComponent(
 	devfileObj parser.DevfileObj,
 	componentName string,
@@ -23,6 +33,8 @@ func createPodFromComponent(
 	buildCommand string,
 	runCommand string,
 	debugCommand string,
+	randomPorts bool,
+	previousPod *corev1.Pod,
 ) (*corev1.Pod, []api.ForwardedPort, error) {
 	containers, err := generator.GetContainers(devfileObj, common.DevfileOptions{})
 	if err != nil {
@@ -39,7 +51,10 @@ func createPodFromComponent(
 	utils.AddOdoProjectVolume(&containers)
 	utils.AddOdoMandatoryVolume(&containers)
 
-	fwPorts := addHostPorts(containers)
+	fwPorts, err := addHostPorts(containers, randomPorts, previousPod)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	volumes := []corev1.Volume{
 		{
@@ -80,7 +95,6 @@ func createPodFromComponent(
 		}
 	}
 
-	// TODO add labels (for GetRunningPodFromSelector)
 	pod := corev1.Pod{
 		Spec: corev1.PodSpec{
 			Containers: containers,
@@ -105,11 +119,45 @@ func getVolumeName(volume string, componentName string, appName string) string {
 	return volume + "-" + componentName + "-" + appName
 }
 
-func addHostPorts(containers []corev1.Container) []api.ForwardedPort {
+// addHostPorts assigns a local port to each port of the containers, and returns the list of forwarded ports.
+// The ports assigned to the same container ports in previousPod are reused, so the ports are kept when the pod is recreated.
+// Other ports are assigned starting at firstHostPort, skipping the ports already in use,
+// or randomly if randomPorts is true.
+func addHostPorts(containers []corev1.Container, randomPorts bool, previousPod *corev1.Pod) ([]api.ForwardedPort, error) {
+	previousPorts := map[string]int32{}
+	usedPorts := map[int32]bool{}
+	if previousPod != nil {
+		for _, container := range previousPod.Spec.Containers {
+			for _, port := range container.Ports {
+				if port.HostPort == 0 {
+					continue
+				}
+				previousPorts[fmt.Sprintf("%s:%d", container.Name, port.ContainerPort)] = port.HostPort
+				usedPorts[port.HostPort] = true
+			}
+		}
+	}
+
 	result := []api.ForwardedPort{}
-	hostPort := int32(39001)
+	nextPort := int32(firstHostPort)
 	for i := range containers {
 		for j := range containers[i].Ports {
+			hostPort, found := previousPorts[fmt.Sprintf("%s:%d", containers[i].Name, containers[i].Ports[j].ContainerPort)]
+			if !found {
+				var err error
+				if randomPorts {
+					hostPort, err = getRandomHostPort(usedPorts)
+					if err != nil {
+						return nil, err
+					}
+				} else {
+					for usedPorts[nextPort] || !isPortFree(int(nextPort)) {
+						nextPort++
+					}
+					hostPort = nextPort
+				}
+				usedPorts[hostPort] = true
+			}
 			result = append(result, api.ForwardedPort{
 				ContainerName: containers[i].Name,
 				LocalAddress:  "127.0.0.1",
@@ -117,10 +165,22 @@ func addHostPorts(containers []corev1.Container) []api.ForwardedPort {
 				ContainerPort: int(containers[i].Ports[j].ContainerPort),
 			})
 			containers[i].Ports[j].HostPort = hostPort
-			hostPort++
 		}
 	}
-	return result
+	return result, nil
+}
+
+// getRandomHostPort returns a free local port, randomly chosen by the system, and not part of usedPorts
+func getRandomHostPort(usedPorts map[int32]bool) (int32, error) {
+	for {
+		port, err := getRandomFreePort()
+		if err != nil {
+			return 0, fmt.Errorf("unable to find a free local port: %w", err)
+		}
+		if !usedPorts[int32(port)] {
+			return int32(port), nil
+		}
+	}
 }
 
 func addVolumeMountToContainer(containers []corev1.Container, devfileVolume storage.LocalStorage) error {
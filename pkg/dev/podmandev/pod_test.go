@@ -12,6 +12,7 @@ import (
 	"github.com/redhat-developer/odo/pkg/api"
 	"github.com/redhat-developer/odo/pkg/labels"
 	"github.com/redhat-developer/odo/pkg/libdevfile/generator"
+	"github.com/redhat-developer/odo/pkg/util"
 	"github.com/redhat-developer/odo/pkg/version"
 
 	corev1 "k8s.io/api/core/v1"
@@ -221,7 +222,7 @@ func Test_createPodFromComponent(t *testing.T) {
 					Name:          "http",
 					ContainerPort: 8080,
 					Protocol:      "TCP",
-					HostPort:      39001,
+					HostPort:      40001,
 				})
 				return pod
 			},
@@ -229,7 +230,7 @@ func Test_createPodFromComponent(t *testing.T) {
 				{
 					ContainerName: "mycomponent",
 					LocalAddress:  "127.0.0.1",
-					LocalPort:     39001,
+					LocalPort:     40001,
 					ContainerPort: 8080,
 				},
 			},
@@ -263,13 +264,13 @@ func Test_createPodFromComponent(t *testing.T) {
 					Name:          "http",
 					ContainerPort: 8080,
 					Protocol:      "TCP",
-					HostPort:      39001,
+					HostPort:      40001,
 				})
 				pod.Spec.Containers[0].Ports = append(pod.Spec.Containers[0].Ports, corev1.ContainerPort{
 					Name:          "debug",
 					ContainerPort: 5858,
 					Protocol:      "TCP",
-					HostPort:      39002,
+					HostPort:      40002,
 				})
 				return pod
 			},
@@ -277,13 +278,13 @@ func Test_createPodFromComponent(t *testing.T) {
 				{
 					ContainerName: "mycomponent",
 					LocalAddress:  "127.0.0.1",
-					LocalPort:     39001,
+					LocalPort:     40001,
 					ContainerPort: 8080,
 				},
 				{
 					ContainerName: "mycomponent",
 					LocalAddress:  "127.0.0.1",
-					LocalPort:     39002,
+					LocalPort:     40002,
 					ContainerPort: 5858,
 				},
 			},
@@ -326,12 +327,46 @@ func Test_createPodFromComponent(t *testing.T) {
 				return pod
 			},
 		},
+		{
+			name: "basic component + debug command on another container",
+			args: args{
+				devfileObj: func() parser.DevfileObj {
+					data, _ := data.NewDevfileData(string(data.APISchemaVersion200))
+					debugCommand := generator.GetExecCommand(generator.ExecCommandParams{
+						Id:          "debug",
+						Component:   "mydebugcomponent",
+						CommandLine: "./debug",
+						IsDefault:   pointer.Bool(true),
+						Kind:        v1alpha2.DebugCommandGroupKind,
+					})
+					_ = data.AddCommands([]v1alpha2.Command{command, debugCommand})
+					debugComponent := baseComponent.DeepCopy()
+					debugComponent.Name = "mydebugcomponent"
+					_ = data.AddComponents([]v1alpha2.Component{baseComponent, *debugComponent})
+					return parser.DevfileObj{
+						Data: data,
+					}
+				},
+				componentName: devfileName,
+				appName:       appName,
+				debugCommand:  "debug",
+			},
+			wantPod: func() *corev1.Pod {
+				pod := basePod.DeepCopy()
+				debugContainer := pod.Spec.Containers[0].DeepCopy()
+				debugContainer.Name = "mydebugcomponent"
+				pod.Spec.Containers = append(pod.Spec.Containers, *debugContainer)
+				return pod
+			},
+		},
 
 		// TODO: Add test cases.
 	}
+	isPortFree = func(int) bool { return true }
+	defer func() { isPortFree = util.IsPortFree }()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, gotFwPorts, err := createPodFromComponent(tt.args.devfileObj(), tt.args.componentName, tt.args.appName, tt.args.buildCommand, tt.args.runCommand, tt.args.debugCommand)
+			got, gotFwPorts, err := createPodFromComponent(tt.args.devfileObj(), tt.args.componentName, tt.args.appName, tt.args.buildCommand, tt.args.runCommand, tt.args.debugCommand, false, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("createPodFromComponent() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -346,3 +381,135 @@ func Test_createPodFromComponent(t *testing.T) {
 		})
 	}
 }
+
+func Test_addHostPorts(t *testing.T) {
+	getContainers := func() []corev1.Container {
+		return []corev1.Container{
+			{
+				Name:  "runtime",
+				Ports: []corev1.ContainerPort{{ContainerPort: 8080}, {ContainerPort: 5858}},
+			},
+			{
+				Name:  "tools",
+				Ports: []corev1.ContainerPort{{ContainerPort: 9090}},
+			},
+		}
+	}
+	fwPort := func(containerName string, localPort int, containerPort int) api.ForwardedPort {
+		return api.ForwardedPort{
+			ContainerName: containerName,
+			LocalAddress:  "127.0.0.1",
+			LocalPort:     localPort,
+			ContainerPort: containerPort,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		busyPorts   map[int]bool
+		randomPorts []int
+		previousPod func() *corev1.Pod
+		random      bool
+		want        []api.ForwardedPort
+	}{
+		{
+			name: "ports are assigned starting at 40001",
+			want: []api.ForwardedPort{
+				fwPort("runtime", 40001, 8080),
+				fwPort("runtime", 40002, 5858),
+				fwPort("tools", 40003, 9090),
+			},
+		},
+		{
+			name:      "busy ports are skipped",
+			busyPorts: map[int]bool{40001: true, 40003: true},
+			want: []api.ForwardedPort{
+				fwPort("runtime", 40002, 8080),
+				fwPort("runtime", 40004, 5858),
+				fwPort("tools", 40005, 9090),
+			},
+		},
+		{
+			name:        "random ports",
+			random:      true,
+			randomPorts: []int{51000, 51000, 52000, 53000},
+			want: []api.ForwardedPort{
+				fwPort("runtime", 51000, 8080),
+				fwPort("runtime", 52000, 5858),
+				fwPort("tools", 53000, 9090),
+			},
+		},
+		{
+			name: "ports of the previous pod are reused",
+			// ports used by the previous pod are not free
+			busyPorts: map[int]bool{40001: true, 40005: true},
+			previousPod: func() *corev1.Pod {
+				return &corev1.Pod{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "runtime",
+								Ports: []corev1.ContainerPort{{ContainerPort: 8080, HostPort: 40005}},
+							},
+							{
+								Name:  "tools",
+								Ports: []corev1.ContainerPort{{ContainerPort: 9090, HostPort: 40002}},
+							},
+						},
+					},
+				}
+			},
+			want: []api.ForwardedPort{
+				fwPort("runtime", 40005, 8080),
+				fwPort("runtime", 40003, 5858),
+				fwPort("tools", 40002, 9090),
+			},
+		},
+	}
+	defer func() {
+		isPortFree = util.IsPortFree
+		getRandomFreePort = util.GetRandomFreePort
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isPortFree = func(port int) bool {
+				return !tt.busyPorts[port]
+			}
+			randomPorts := tt.randomPorts
+			getRandomFreePort = func() (int, error) {
+				if len(randomPorts) == 0 {
+					t.Fatal("unexpected call to getRandomFreePort")
+				}
+				port := randomPorts[0]
+				randomPorts = randomPorts[1:]
+				return port, nil
+			}
+			var previousPod *corev1.Pod
+			if tt.previousPod != nil {
+				previousPod = tt.previousPod()
+			}
+
+			containers := getContainers()
+			got, err := addHostPorts(containers, tt.random, previousPod)
+			if err != nil {
+				t.Fatalf("addHostPorts() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("addHostPorts() mismatch (-want +got):\n%s", diff)
+			}
+			var gotHostPorts []int
+			for _, container := range containers {
+				for _, port := range container.Ports {
+					gotHostPorts = append(gotHostPorts, int(port.HostPort))
+				}
+			}
+			wantHostPorts := make([]int, 0, len(tt.want))
+			for _, fwPort := range tt.want {
+				wantHostPorts = append(wantHostPorts, fwPort.LocalPort)
+			}
+			if diff := cmp.Diff(wantHostPorts, gotHostPorts); diff != "" {
+				t.Errorf("addHostPorts() host ports mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
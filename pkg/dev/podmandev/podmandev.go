@@ -9,8 +9,13 @@ import (
 
 	"github.com/redhat-developer/odo/pkg/dev"
 	"github.com/redhat-developer/odo/pkg/dev/common"
+	"github.com/redhat-developer/odo/pkg/devfile"
 	"github.com/redhat-developer/odo/pkg/devfile/adapters"
+	"github.com/redhat-developer/odo/pkg/devfile/location"
 	"github.com/redhat-developer/odo/pkg/exec"
+	"github.com/redhat-developer/odo/pkg/libdevfile"
+	"github.com/redhat-developer/odo/pkg/log"
+	"github.com/redhat-developer/odo/pkg/machineoutput"
 	odocontext "github.com/redhat-developer/odo/pkg/odo/context"
 	"github.com/redhat-developer/odo/pkg/podman"
 	"github.com/redhat-developer/odo/pkg/state"
@@ -18,16 +23,21 @@ import (
 	"github.com/redhat-developer/odo/pkg/watch"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog"
 )
 
 const (
 	promptMessage = `
-[Ctrl+c] - Exit and delete resources from podman
-     [p] - Manually apply local changes to the application on podman
+[Ctrl+c] - Exit and delete resources from %[1]s
+     [p] - Manually apply local changes to the application on %[1]s
 `
 )
 
+// DevClient runs the component on Podman, or on any platform accessed through the Podman client interface
 type DevClient struct {
+	// platform is the name of the platform, podman or docker
+	platform     string
 	podmanClient podman.Client
 	syncClient   sync.Client
 	execClient   exec.Client
@@ -35,11 +45,18 @@ type DevClient struct {
 	watchClient  watch.Client
 
 	deployedPod *corev1.Pod
+	// deployedResources are the resources deployed alongside deployedPod
+	deployedResources []unstructured.Unstructured
+	// warnedResources are the Kubernetes components and kinds not supported on Podman, already warned about during the session
+	warnedResources map[string]bool
+	// logs prints the logs of the application, when enabled by the user
+	logs *common.LogsPrinter
 }
 
 var _ dev.Client = (*DevClient)(nil)
 
 func NewDevClient(
+	platform string,
 	podmanClient podman.Client,
 	syncClient sync.Client,
 	execClient exec.Client,
@@ -47,11 +64,14 @@ func NewDevClient(
 	watchClient watch.Client,
 ) *DevClient {
 	return &DevClient{
+		platform:     platform,
 		podmanClient: podmanClient,
 		syncClient:   syncClient,
 		execClient:   execClient,
 		stateClient:  stateClient,
 		watchClient:  watchClient,
+
+		warnedResources: map[string]bool{},
 	}
 }
 
@@ -71,12 +91,17 @@ func (o *DevClient) Start(
 		componentStatus = watch.ComponentStatus{}
 	)
 
-	err := o.reconcile(ctx, out, errOut, options, &componentStatus)
+	o.logs = common.NewLogsPrinter(o.podmanClient, out, options.Logs)
+	err := o.reconcile(ctx, out, errOut, options, false, &componentStatus)
 	if err != nil {
 		return err
 	}
 
-	watch.PrintInfoMessage(out, path, options.WatchFiles, promptMessage)
+	prompt := fmt.Sprintf(promptMessage, o.platform) + common.KeyboardCommandsPromptMessage
+	if len(options.SyncBack) > 0 {
+		prompt += common.SyncBackPromptMessage
+	}
+	watch.PrintInfoMessage(out, path, options.WatchFiles, prompt)
 
 	watchParameters := watch.WatchParameters{
 		DevfilePath:         devfilePath,
@@ -89,34 +114,51 @@ func (o *DevClient) Start(
 		Debug:               options.Debug,
 		DevfileBuildCmd:     options.BuildCommand,
 		DevfileRunCmd:       options.RunCommand,
+		DevfileDebugCmd:     options.DebugCommand,
 		Variables:           options.Variables,
 		RandomPorts:         options.RandomPorts,
 		WatchFiles:          options.WatchFiles,
+		PollInterval:        options.PollInterval,
 		WatchCluster:        false,
+		WatchPodman:         true,
 		Out:                 out,
 		ErrOut:              errOut,
-		PromptMessage:       promptMessage,
+		PromptMessage:       prompt,
+		SyncBack:            options.SyncBack,
+		SyncBackHandler:     o.syncBack,
+		ToggleLogsHandler:   o.logs.Toggle,
+		APIServerAddress:    options.APIServerAddress,
 	}
 
 	return o.watchClient.WatchAndPush(out, watchParameters, ctx, componentStatus)
 }
 
-// syncFiles syncs the local source files in path into the pod's source volume
+// syncFiles syncs the local source files in path into all the containers of the pod mounting the sources
 func (o *DevClient) syncFiles(ctx context.Context, options dev.StartOptions, pod *corev1.Pod, path string) (bool, error) {
 	var (
 		componentName = odocontext.GetComponentName(ctx)
+		devfileObj    = odocontext.GetDevfileObj(ctx)
 	)
 
-	containerName, syncFolder, err := common.GetFirstContainerWithSourceVolume(pod.Spec.Containers)
+	pushDevfileCommands, err := libdevfile.GetPushCommands(*devfileObj, options.BuildCommand, options.RunCommand, options.Debug, options.DebugCommand)
+	if err != nil {
+		return false, err
+	}
+
+	containers, err := common.GetContainersWithSourceVolume(pod.Spec.Containers)
 	if err != nil {
-		return false, fmt.Errorf("error while retrieving container from pod %s with a mounted project volume: %w", pod.GetName(), err)
+		return false, fmt.Errorf("error while retrieving containers from pod %s with a mounted project volume: %w", pod.GetName(), err)
 	}
 
-	compInfo := sync.ComponentInfo{
-		ComponentName: componentName,
-		ContainerName: containerName,
-		PodName:       pod.GetName(),
-		SyncFolder:    syncFolder,
+	var syncStats sync.SyncStats
+	compInfos := make([]sync.ComponentInfo, 0, len(containers))
+	for _, container := range containers {
+		compInfos = append(compInfos, sync.ComponentInfo{
+			ComponentName: componentName,
+			ContainerName: container.Name,
+			PodName:       pod.GetName(),
+			SyncFolder:    container.SyncFolder,
+		})
 	}
 
 	syncParams := sync.SyncParameters{
@@ -126,29 +168,52 @@ func (o *DevClient) syncFiles(ctx context.Context, options dev.StartOptions, pod
 		IgnoredFiles:             options.IgnorePaths,
 		DevfileScanIndexForWatch: true,
 
-		CompInfo:  compInfo,
+		CompInfos: compInfos,
 		ForcePush: true,
-		Files:     map[string]string{}, // ??? TODO
+		Files:     libdevfile.GetSyncFilesFromAttributes(pushDevfileCommands),
+		Stats:     &syncStats,
 	}
+
+	s := log.Spinner("Syncing files into the container")
+	defer s.End(false)
 	execRequired, err := o.syncClient.SyncFiles(syncParams)
 	if err != nil {
 		return false, err
 	}
+	machineoutput.NewMachineEventLoggingClient().FilesSynced(syncStats.Files, syncStats.Bytes, syncStats.TransferredBytes, syncStats.Compression,
+		syncStats.Duration, machineoutput.TimestampNow())
+	if syncStats.Files > 0 {
+		s.EndWithStatus(fmt.Sprintf("Syncing files into the container (%s)", syncStats), true)
+	} else {
+		s.End(true)
+	}
 	return execRequired, nil
 }
 
 // checkVolumesFree checks that all persistent volumes declared in pod
-// are not using an existing volume
-func (o *DevClient) checkVolumesFree(pod *corev1.Pod) error {
+// are not using an existing volume not owned by the component.
+// The volumes used by previousPod, the pod previously deployed by this session,
+// and the volumes named for the component, left by a previous session, are owned and reused
+func (o *DevClient) checkVolumesFree(pod *corev1.Pod, previousPod *corev1.Pod, componentName string, appName string) error {
 	existingVolumesSet, err := o.podmanClient.VolumeLs()
 	if err != nil {
 		return err
 	}
+	ownedVolumesSet := getVolumeNames(previousPod)
 	var problematicVolumes []string
 	for _, volume := range pod.Spec.Volumes {
-		if volume.PersistentVolumeClaim != nil && existingVolumesSet[volume.PersistentVolumeClaim.ClaimName] {
-			problematicVolumes = append(problematicVolumes, volume.PersistentVolumeClaim.ClaimName)
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		volumeName := volume.PersistentVolumeClaim.ClaimName
+		if !existingVolumesSet[volumeName] || ownedVolumesSet[volumeName] {
+			continue
 		}
+		if volumeName == getVolumeName(volume.Name, componentName, appName) {
+			klog.V(4).Infof("reusing volume %q created by a previous session", volumeName)
+			continue
+		}
+		problematicVolumes = append(problematicVolumes, volumeName)
 	}
 	if len(problematicVolumes) > 0 {
 		return fmt.Errorf("volumes already exist, please remove them before to run odo dev: %s", strings.Join(problematicVolumes, ", "))
@@ -156,15 +221,50 @@ func (o *DevClient) checkVolumesFree(pod *corev1.Pod) error {
 	return nil
 }
 
+// getVolumeNames returns the set of the names of the podman volumes used by pod
+func getVolumeNames(pod *corev1.Pod) map[string]bool {
+	result := map[string]bool{}
+	if pod == nil {
+		return result
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			result[volume.PersistentVolumeClaim.ClaimName] = true
+		}
+	}
+	return result
+}
+
 func (o *DevClient) watchHandler(ctx context.Context, pushParams adapters.PushParameters, watchParams watch.WatchParameters, componentStatus *watch.ComponentStatus) error {
 	startOptions := dev.StartOptions{
 		IgnorePaths:  watchParams.FileIgnores,
 		Debug:        watchParams.Debug,
 		BuildCommand: watchParams.DevfileBuildCmd,
 		RunCommand:   watchParams.DevfileRunCmd,
+		DebugCommand: watchParams.DevfileDebugCmd,
 		RandomPorts:  watchParams.RandomPorts,
 		WatchFiles:   watchParams.WatchFiles,
+		PollInterval: watchParams.PollInterval,
 		Variables:    watchParams.Variables,
+		SyncBack:     watchParams.SyncBack,
+	}
+
+	devObj, err := devfile.ParseAndValidateFromFileWithVariables(location.DevfileLocation(""), watchParams.Variables)
+	if err != nil {
+		return err
+	}
+	ctx = odocontext.WithDevfileObj(ctx, &devObj)
+
+	// The logs of the application are not mixed with the output of the synchronization and of the build
+	o.logs.Pause()
+	defer o.logs.Resume()
+	return o.reconcile(ctx, watchParams.Out, watchParams.ErrOut, startOptions, pushParams.RestartCommand, componentStatus)
+}
+
+// syncBack copies the sync-back paths from the container of the deployed pod back to the local directory
+func (o *DevClient) syncBack(ctx context.Context, watchParams watch.WatchParameters) error {
+	if o.deployedPod == nil {
+		return fmt.Errorf("no pod deployed for component %s", watchParams.ComponentName)
 	}
-	return o.reconcile(ctx, watchParams.Out, watchParams.ErrOut, startOptions, componentStatus)
+	return common.SyncBack(o.syncClient, o.deployedPod, watchParams.ComponentName, watchParams.Path, watchParams.SyncBack)
 }
@@ -0,0 +1,114 @@
+package podmandev
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/redhat-developer/odo/pkg/podman"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_checkVolumesFree(t *testing.T) {
+	// podWithVolume returns the base pod, with an additional volume backed by the claim claimName
+	podWithVolume := func(claimName string) func() *corev1.Pod {
+		return func() *corev1.Pod {
+			pod := basePod.DeepCopy()
+			pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+				Name: "data",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: claimName,
+					},
+				},
+			})
+			return pod
+		}
+	}
+
+	tests := []struct {
+		name            string
+		existingVolumes map[string]bool
+		pod             func() *corev1.Pod
+		previousPod     func() *corev1.Pod
+		wantErr         bool
+	}{
+		{
+			name:            "no existing volume",
+			existingVolumes: map[string]bool{},
+			pod:             func() *corev1.Pod { return basePod.DeepCopy() },
+			previousPod:     func() *corev1.Pod { return nil },
+		},
+		{
+			name: "volumes of another component exist",
+			existingVolumes: map[string]bool{
+				"odo-projects-othercmp-app": true,
+			},
+			pod:         func() *corev1.Pod { return basePod.DeepCopy() },
+			previousPod: func() *corev1.Pod { return nil },
+		},
+		{
+			name: "volumes of the component exist, created by a previous session",
+			existingVolumes: map[string]bool{
+				"odo-projects-mycmp-app": true,
+			},
+			pod:         func() *corev1.Pod { return basePod.DeepCopy() },
+			previousPod: func() *corev1.Pod { return nil },
+		},
+		{
+			name: "volumes exist, used by the previously deployed pod",
+			existingVolumes: map[string]bool{
+				"odo-projects-mycmp-app":    true,
+				"odo-shared-data-mycmp-app": true,
+			},
+			pod:         func() *corev1.Pod { return basePod.DeepCopy() },
+			previousPod: func() *corev1.Pod { return basePod.DeepCopy() },
+		},
+		{
+			name: "volume of the component exists, not used by the previously deployed pod",
+			existingVolumes: map[string]bool{
+				"odo-projects-mycmp-app":    true,
+				"odo-shared-data-mycmp-app": true,
+			},
+			pod: func() *corev1.Pod { return basePod.DeepCopy() },
+			previousPod: func() *corev1.Pod {
+				pod := basePod.DeepCopy()
+				pod.Spec.Volumes = pod.Spec.Volumes[:1]
+				return pod
+			},
+		},
+		{
+			name: "volume of another component exists, used by the pod",
+			existingVolumes: map[string]bool{
+				"odo-projects-mycmp-app": true,
+				"data-othercmp-app":      true,
+			},
+			pod:         podWithVolume("data-othercmp-app"),
+			previousPod: func() *corev1.Pod { return nil },
+			wantErr:     true,
+		},
+		{
+			name: "volume of another component does not exist yet",
+			existingVolumes: map[string]bool{
+				"odo-projects-mycmp-app": true,
+			},
+			pod:         podWithVolume("data-othercmp-app"),
+			previousPod: func() *corev1.Pod { return nil },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			podmanClient := podman.NewMockClient(ctrl)
+			podmanClient.EXPECT().VolumeLs().Return(tt.existingVolumes, nil)
+			o := &DevClient{
+				podmanClient: podmanClient,
+			}
+			err := o.checkVolumesFree(tt.pod(), tt.previousPod(), devfileName, appName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkVolumesFree() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -7,14 +7,19 @@ import (
 	"path/filepath"
 
 	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	parsercommon "github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	devfilefs "github.com/devfile/library/pkg/testingutil/filesystem"
 	"github.com/fatih/color"
 
 	"github.com/redhat-developer/odo/pkg/api"
 	"github.com/redhat-developer/odo/pkg/component"
 	"github.com/redhat-developer/odo/pkg/dev"
+	odolabels "github.com/redhat-developer/odo/pkg/labels"
 	"github.com/redhat-developer/odo/pkg/libdevfile"
 	"github.com/redhat-developer/odo/pkg/log"
+	"github.com/redhat-developer/odo/pkg/machineoutput"
 	odocontext "github.com/redhat-developer/odo/pkg/odo/context"
+	"github.com/redhat-developer/odo/pkg/util"
 	"github.com/redhat-developer/odo/pkg/watch"
 
 	corev1 "k8s.io/api/core/v1"
@@ -27,6 +32,7 @@ func (o *DevClient) reconcile(
 	out io.Writer,
 	errOut io.Writer,
 	options dev.StartOptions,
+	restartCommand bool,
 	componentStatus *watch.ComponentStatus,
 ) error {
 	var (
@@ -67,7 +73,63 @@ func (o *DevClient) reconcile(
 	}
 	componentStatus.PostStartEventsDone = true
 
-	if execRequired {
+	cmdKind := devfilev1.RunCommandGroupKind
+	cmdName := options.RunCommand
+	if options.Debug {
+		cmdKind = devfilev1.DebugCommandGroupKind
+		cmdName = options.DebugCommand
+	}
+
+	cmd, err := libdevfile.ValidateAndGetCommand(*devfileObj, cmdName, cmdKind)
+	if err != nil {
+		return err
+	}
+
+	commandType, err := parsercommon.GetCommandType(cmd)
+	if err != nil {
+		return err
+	}
+	if restartCommand {
+		err = component.StopRunAndDebugCommands(o.execClient, *devfileObj, options.RunCommand, options.DebugCommand, pod.Name)
+		if err != nil {
+			return fmt.Errorf("unable to stop the running commands: %w", err)
+		}
+	}
+
+	var running bool
+	var isComposite bool
+	cmdHandler := commandHandler{
+		execClient:     o.execClient,
+		platformClient: o.podmanClient,
+		podName:        pod.Name,
+		appName:        appName,
+		componentName:  componentName,
+	}
+
+	if commandType == devfilev1.ExecCommandType {
+		running, err = cmdHandler.IsRemoteProcessForCommandRunning(cmd, pod.Name)
+		if err != nil {
+			return err
+		}
+	} else if commandType == devfilev1.CompositeCommandType {
+		// this handler will run each command in this composite command individually,
+		// and will determine whether each command is running or not.
+		isComposite = true
+	} else {
+		return fmt.Errorf("unsupported type %q for Devfile command %s, only exec and composite are handled",
+			commandType, cmd.Id)
+	}
+
+	// The commands have been stopped and must be started again, even the hot-reload capable ones
+	cmdHandler.componentExists = (running || isComposite) && !restartCommand
+
+	klog.V(4).Infof("running=%v, execRequired=%v",
+		running, execRequired)
+
+	// The command is also executed when it is not running, so a command that has crashed is restarted
+	if isComposite || !running || execRequired {
+		// Invoke the build command once (before calling libdevfile.ExecuteCommandByNameAndKind), as, if cmd is a composite command,
+		// the handler we pass will be called for each command in that composite command.
 		doExecuteBuildCommand := func() error {
 			execHandler := component.NewExecHandler(
 				o.podmanClient,
@@ -80,25 +142,14 @@ func (o *DevClient) reconcile(
 			)
 			return libdevfile.Build(*devfileObj, options.BuildCommand, execHandler)
 		}
-		err = doExecuteBuildCommand()
-		if err != nil {
-			return err
+		// A running hot-reload capable command takes care of rebuilding the application by itself
+		if !running || cmd.Exec == nil || !util.SafeGetBool(cmd.Exec.HotReloadCapable) {
+			err = doExecuteBuildCommand()
+			if err != nil {
+				return err
+			}
 		}
 
-		cmdKind := devfilev1.RunCommandGroupKind
-		cmdName := options.RunCommand
-		if options.Debug {
-			cmdKind = devfilev1.DebugCommandGroupKind
-			cmdName = options.DebugCommand
-		}
-		cmdHandler := commandHandler{
-			execClient:      o.execClient,
-			platformClient:  o.podmanClient,
-			componentExists: true, // TODO
-			podName:         pod.Name,
-			appName:         appName,
-			componentName:   componentName,
-		}
 		err = libdevfile.ExecuteCommandByNameAndKind(*devfileObj, cmdName, cmdKind, &cmdHandler, false)
 		if err != nil {
 			return err
@@ -113,8 +164,14 @@ func (o *DevClient) reconcile(
 	if err != nil {
 		return err
 	}
+	machineoutput.NewMachineEventLoggingClient().PortsForwarded(fwPorts, machineoutput.TimestampNow())
+
+	// The logs are not needed to run the application, the errors are only logged
+	if err = o.logs.Follow(ctx, pod); err != nil {
+		klog.V(4).Infof("unable to follow the logs of pod %s: %v", pod.GetName(), err)
+	}
 
-	componentStatus.State = watch.StateReady
+	componentStatus.SetState(watch.StateReady)
 	return nil
 }
 
@@ -124,6 +181,7 @@ func (o *DevClient) deployPod(ctx context.Context, options dev.StartOptions) (*c
 		appName       = odocontext.GetApplication(ctx)
 		componentName = odocontext.GetComponentName(ctx)
 		devfileObj    = odocontext.GetDevfileObj(ctx)
+		devfilePath   = odocontext.GetDevfilePath(ctx)
 	)
 
 	spinner := log.Spinner("Deploying pod")
@@ -135,28 +193,106 @@ func (o *DevClient) deployPod(ctx context.Context, options dev.StartOptions) (*c
 		appName,
 		options.BuildCommand,
 		options.RunCommand,
-		"",
+		options.DebugCommand,
+		options.RandomPorts,
+		o.deployedPod,
 	)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	if equality.Semantic.DeepEqual(o.deployedPod, pod) {
+	resources, err := getKubernetesResources(*devfileObj, filepath.Dir(devfilePath), devfilefs.DefaultFs{}, o.warnedResources)
+	if err != nil {
+		return nil, nil, err
+	}
+	resources = wireKubernetesResources(pod, resources, componentName, appName)
+
+	previousPod := o.deployedPod
+	previousPodExists := false
+	if previousPod != nil {
+		previousPodExists, err = o.podExists(previousPod, componentName, appName)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if previousPodExists && equality.Semantic.DeepEqual(previousPod, pod) && equality.Semantic.DeepEqual(o.deployedResources, resources) {
 		klog.V(4).Info("pod is already deployed as required")
 		spinner.End(true)
 		return o.deployedPod, fwPorts, nil
 	}
 
-	err = o.checkVolumesFree(pod)
+	if previousPodExists {
+		klog.V(4).Info("pod spec has changed, recreating the pod")
+		err = o.removePreviousPod(previousPod, pod)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else if previousPod != nil {
+		klog.V(4).Info("pod has been deleted, recreating the pod")
+	}
+
+	err = o.checkVolumesFree(pod, previousPod, componentName, appName)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	err = o.podmanClient.PlayKube(pod)
+	existingVolumes, err := o.podmanClient.VolumeLs()
 	if err != nil {
 		return nil, nil, err
 	}
+	err = o.podmanClient.PlayKube(pod, withoutExistingClaims(resources, existingVolumes))
+	if err != nil {
+		return nil, nil, err
+	}
+	o.deployedResources = resources
+	machineoutput.NewMachineEventLoggingClient().KubernetesPodStatus(
+		[]machineoutput.KubernetesPodStatusEntry{machineoutput.NewKubernetesPodStatusEntry(pod)}, machineoutput.TimestampNow())
 
 	spinner.End(true)
 	return pod, fwPorts, nil
 }
+
+// podExists returns true if the pod is still deployed on Podman
+func (o *DevClient) podExists(pod *corev1.Pod, componentName string, appName string) (bool, error) {
+	selector := odolabels.GetSelector(componentName, appName, odolabels.ComponentDevMode, true)
+	pods, err := o.podmanClient.GetPodsMatchingSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range pods.Items {
+		if p.GetName() == pod.GetName() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// removePreviousPod stops and deletes the previously deployed pod, so it can be replaced by pod.
+// The volumes of the previous pod are kept to be reused by the new pod,
+// except the ones not used by the new pod anymore, which are deleted
+func (o *DevClient) removePreviousPod(previousPod *corev1.Pod, pod *corev1.Pod) error {
+	err := o.podmanClient.PodStop(previousPod.GetName())
+	if err != nil {
+		return err
+	}
+	err = o.podmanClient.PodRm(previousPod.GetName())
+	if err != nil {
+		return err
+	}
+	o.deployedPod = nil
+	o.deployedResources = nil
+
+	newVolumes := getVolumeNames(pod)
+	for volumeName := range getVolumeNames(previousPod) {
+		if newVolumes[volumeName] {
+			continue
+		}
+		klog.V(3).Infof("deleting podman volume %q, not used anymore", volumeName)
+		err = o.podmanClient.VolumeRm(volumeName)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
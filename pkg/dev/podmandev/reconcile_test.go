@@ -0,0 +1,123 @@
+package podmandev
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/redhat-developer/odo/pkg/labels"
+	"github.com/redhat-developer/odo/pkg/podman"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDevClient_removePreviousPod(t *testing.T) {
+	devfileVolume := corev1.Volume{
+		Name: "myvolume",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "myvolume-mycmp-app",
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		previousPod        func() *corev1.Pod
+		pod                func() *corev1.Pod
+		wantRemovedVolumes []string
+	}{
+		{
+			name:        "same volumes are kept",
+			previousPod: func() *corev1.Pod { return basePod.DeepCopy() },
+			pod:         func() *corev1.Pod { return basePod.DeepCopy() },
+		},
+		{
+			name: "volume removed from the devfile is deleted",
+			previousPod: func() *corev1.Pod {
+				pod := basePod.DeepCopy()
+				pod.Spec.Volumes = append(pod.Spec.Volumes, devfileVolume)
+				return pod
+			},
+			pod:                func() *corev1.Pod { return basePod.DeepCopy() },
+			wantRemovedVolumes: []string{"myvolume-mycmp-app"},
+		},
+		{
+			name:        "volume added to the devfile is not deleted",
+			previousPod: func() *corev1.Pod { return basePod.DeepCopy() },
+			pod: func() *corev1.Pod {
+				pod := basePod.DeepCopy()
+				pod.Spec.Volumes = append(pod.Spec.Volumes, devfileVolume)
+				return pod
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			podmanClient := podman.NewMockClient(ctrl)
+			previousPod := tt.previousPod()
+			podmanClient.EXPECT().PodStop(previousPod.GetName()).Return(nil)
+			podmanClient.EXPECT().PodRm(previousPod.GetName()).Return(nil)
+			for _, volume := range tt.wantRemovedVolumes {
+				podmanClient.EXPECT().VolumeRm(volume).Return(nil)
+			}
+			o := &DevClient{
+				podmanClient: podmanClient,
+				deployedPod:  previousPod,
+			}
+			err := o.removePreviousPod(previousPod, tt.pod())
+			if err != nil {
+				t.Errorf("removePreviousPod() unexpected error: %v", err)
+			}
+			if o.deployedPod != nil {
+				t.Errorf("removePreviousPod() deployedPod should be reset")
+			}
+		})
+	}
+}
+
+func TestDevClient_podExists(t *testing.T) {
+	tests := []struct {
+		name     string
+		podNames []string
+		want     bool
+	}{
+		{
+			name:     "pod is deployed",
+			podNames: []string{"mycmp-app"},
+			want:     true,
+		},
+		{
+			name: "pod has been deleted",
+		},
+		{
+			name:     "another pod is deployed",
+			podNames: []string{"mycmp-other"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			podmanClient := podman.NewMockClient(ctrl)
+			pods := corev1.PodList{}
+			for _, name := range tt.podNames {
+				pod := corev1.Pod{}
+				pod.SetName(name)
+				pods.Items = append(pods.Items, pod)
+			}
+			selector := labels.GetSelector(devfileName, appName, labels.ComponentDevMode, true)
+			podmanClient.EXPECT().GetPodsMatchingSelector(selector).Return(&pods, nil)
+			o := &DevClient{
+				podmanClient: podmanClient,
+			}
+			got, err := o.podExists(basePod, devfileName, appName)
+			if err != nil {
+				t.Fatalf("podExists() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("podExists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package podmandev
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/devfile/library/pkg/devfile/parser"
+	devfilefs "github.com/devfile/library/pkg/testingutil/filesystem"
+
+	"github.com/redhat-developer/odo/pkg/devfile"
+	"github.com/redhat-developer/odo/pkg/libdevfile"
+	"github.com/redhat-developer/odo/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog"
+)
+
+const (
+	kindConfigMap             = "ConfigMap"
+	kindSecret                = "Secret"
+	kindPersistentVolumeClaim = "PersistentVolumeClaim"
+)
+
+// getKubernetesResources returns the resources defined by the Kubernetes components of the devfile
+// to be deployed alongside the pod, the ones not referenced by any command.
+// Only ConfigMaps, Secrets and PersistentVolumeClaims are supported by Podman, a warning is displayed for other kinds.
+// The warning is displayed once per component and kind: warned contains the components and kinds already warned about, and is updated.
+func getKubernetesResources(devfileObj parser.DevfileObj, path string, fs devfilefs.Filesystem, warned map[string]bool) ([]unstructured.Unstructured, error) {
+	k8sComponents, err := devfile.GetKubernetesComponentsToPush(devfileObj, false)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to fetch Kubernetes components from devfile: %w", err)
+	}
+
+	var result []unstructured.Unstructured
+	for _, k8sComponent := range k8sComponents {
+		u, err := libdevfile.GetK8sComponentAsUnstructured(devfileObj, k8sComponent.Name, path, fs)
+		if err != nil {
+			return nil, err
+		}
+		if !isSupportedKind(u) {
+			key := k8sComponent.Name + "/" + u.GetKind()
+			if !warned[key] {
+				log.Warningf("Kubernetes component %q of kind %q is not supported on Podman and is ignored", k8sComponent.Name, u.GetKind())
+				warned[key] = true
+			}
+			continue
+		}
+		result = append(result, u)
+	}
+	// Components are returned in random order, sort the resources to keep the generated definitions stable
+	sortResources(result)
+	return result, nil
+}
+
+// isSupportedKind returns true if the kind of the resource can be played by Podman alongside a pod
+func isSupportedKind(u unstructured.Unstructured) bool {
+	gvk := u.GroupVersionKind()
+	if gvk.Group != corev1.GroupName || gvk.Version != corev1.SchemeGroupVersion.Version {
+		return false
+	}
+	switch gvk.Kind {
+	case kindConfigMap, kindSecret, kindPersistentVolumeClaim:
+		return true
+	}
+	return false
+}
+
+// sortResources sorts resources by kind then name
+func sortResources(resources []unstructured.Unstructured) {
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].GetKind() != resources[j].GetKind() {
+			return resources[i].GetKind() < resources[j].GetKind()
+		}
+		return resources[i].GetName() < resources[j].GetName()
+	})
+}
+
+// wireKubernetesResources makes the containers of the pod use the resources:
+//   - a devfile volume with the same name as a ConfigMap, a Secret or a PersistentVolumeClaim
+//     is backed by this resource, instead of a volume created by odo,
+//   - the other ConfigMaps and Secrets are exposed as environment variables to all containers, with envFrom.
+//
+// PersistentVolumeClaims are renamed the same way as the volumes created by odo, to be specific to the component,
+// and are ignored when not used by any devfile volume.
+// The returned resources are the ones to play alongside the pod.
+func wireKubernetesResources(pod *corev1.Pod, resources []unstructured.Unstructured, componentName string, appName string) []unstructured.Unstructured {
+	mounted := map[string]bool{}
+	result := make([]unstructured.Unstructured, 0, len(resources))
+	for _, resource := range resources {
+		resource = *resource.DeepCopy()
+		name := resource.GetName()
+		for i := range pod.Spec.Volumes {
+			volume := &pod.Spec.Volumes[i]
+			if volume.Name != name {
+				continue
+			}
+			switch resource.GetKind() {
+			case kindConfigMap:
+				volume.VolumeSource = corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: name},
+					},
+				}
+			case kindSecret:
+				volume.VolumeSource = corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: name},
+				}
+			case kindPersistentVolumeClaim:
+				claimName := getVolumeName(name, componentName, appName)
+				resource.SetName(claimName)
+				volume.VolumeSource = corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+				}
+			}
+			mounted[resource.GetKind()+"/"+name] = true
+		}
+		if resource.GetKind() == kindPersistentVolumeClaim && !mounted[kindPersistentVolumeClaim+"/"+name] {
+			// the volume would not be attached to the pod, and never deleted
+			klog.V(3).Infof("PersistentVolumeClaim %q is not used by any devfile volume, ignoring it", name)
+			continue
+		}
+		result = append(result, resource)
+	}
+
+	for _, resource := range resources {
+		var envFrom corev1.EnvFromSource
+		switch {
+		case mounted[resource.GetKind()+"/"+resource.GetName()]:
+			continue
+		case resource.GetKind() == kindConfigMap:
+			envFrom.ConfigMapRef = &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: resource.GetName()},
+			}
+		case resource.GetKind() == kindSecret:
+			envFrom.SecretRef = &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: resource.GetName()},
+			}
+		default:
+			continue
+		}
+		for i := range pod.Spec.Containers {
+			pod.Spec.Containers[i].EnvFrom = append(pod.Spec.Containers[i].EnvFrom, envFrom)
+		}
+	}
+	return result
+}
+
+// withoutExistingClaims returns the resources, except the PersistentVolumeClaims for which a volume already exists,
+// as the existing volumes are reused by Podman
+func withoutExistingClaims(resources []unstructured.Unstructured, existingVolumes map[string]bool) []unstructured.Unstructured {
+	result := make([]unstructured.Unstructured, 0, len(resources))
+	for _, resource := range resources {
+		if resource.GetKind() == kindPersistentVolumeClaim && existingVolumes[resource.GetName()] {
+			continue
+		}
+		result = append(result, resource)
+	}
+	return result
+}
@@ -0,0 +1,259 @@
+package podmandev
+
+import (
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser"
+	"github.com/devfile/library/pkg/devfile/parser/data"
+	devfilefs "github.com/devfile/library/pkg/testingutil/filesystem"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/redhat-developer/odo/pkg/libdevfile/generator"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func getInlinedKubernetesComponent(name string, inlined string) v1alpha2.Component {
+	return generator.GetKubernetesComponent(generator.KubernetesComponentParams{
+		Name: name,
+		Kubernetes: &v1alpha2.KubernetesComponent{
+			K8sLikeComponent: v1alpha2.K8sLikeComponent{
+				K8sLikeComponentLocation: v1alpha2.K8sLikeComponentLocation{
+					Inlined: inlined,
+				},
+			},
+		},
+	})
+}
+
+func getResource(kind string, name string) unstructured.Unstructured {
+	u := unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind(kind)
+	u.SetName(name)
+	return u
+}
+
+func Test_getKubernetesResources(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []v1alpha2.Component
+		commands   []v1alpha2.Command
+		// warned are the components and kinds already warned about
+		warned     map[string]bool
+		want       []string
+		wantWarned map[string]bool
+		wantErr    bool
+	}{
+		{
+			name:       "no Kubernetes component",
+			components: []v1alpha2.Component{baseComponent},
+		},
+		{
+			name: "supported kinds are returned sorted by kind and name",
+			components: []v1alpha2.Component{
+				baseComponent,
+				getInlinedKubernetesComponent("secret", "apiVersion: v1\nkind: Secret\nmetadata:\n  name: mysecret\n"),
+				getInlinedKubernetesComponent("config2", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: myconfig2\n"),
+				getInlinedKubernetesComponent("config1", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: myconfig1\n"),
+				getInlinedKubernetesComponent("pvc", "apiVersion: v1\nkind: PersistentVolumeClaim\nmetadata:\n  name: mypvc\n"),
+			},
+			want: []string{"ConfigMap/myconfig1", "ConfigMap/myconfig2", "PersistentVolumeClaim/mypvc", "Secret/mysecret"},
+		},
+		{
+			name: "unsupported kinds are ignored",
+			components: []v1alpha2.Component{
+				baseComponent,
+				getInlinedKubernetesComponent("config", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: myconfig\n"),
+				getInlinedKubernetesComponent("deployment", "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: mydeployment\n"),
+				getInlinedKubernetesComponent("service", "apiVersion: v1\nkind: Service\nmetadata:\n  name: myservice\n"),
+			},
+			want:       []string{"ConfigMap/myconfig"},
+			wantWarned: map[string]bool{"deployment/Deployment": true, "service/Service": true},
+		},
+		{
+			name: "unsupported kinds already warned about are ignored",
+			components: []v1alpha2.Component{
+				baseComponent,
+				getInlinedKubernetesComponent("deployment", "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: mydeployment\n"),
+			},
+			warned:     map[string]bool{"deployment/Deployment": true},
+			wantWarned: map[string]bool{"deployment/Deployment": true},
+		},
+		{
+			name: "components referenced by an apply command are ignored",
+			components: []v1alpha2.Component{
+				baseComponent,
+				getInlinedKubernetesComponent("config", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: myconfig\n"),
+			},
+			commands: []v1alpha2.Command{
+				generator.GetApplyCommand(generator.ApplyCommandParams{
+					Id:        "apply",
+					Component: "config",
+				}),
+			},
+		},
+		{
+			name: "invalid manifest",
+			components: []v1alpha2.Component{
+				baseComponent,
+				getInlinedKubernetesComponent("config", "{"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devfileData, _ := data.NewDevfileData(string(data.APISchemaVersion200))
+			_ = devfileData.AddComponents(tt.components)
+			_ = devfileData.AddCommands(tt.commands)
+			devfileObj := parser.DevfileObj{
+				Data: devfileData,
+			}
+
+			warned := map[string]bool{}
+			for key := range tt.warned {
+				warned[key] = true
+			}
+			got, err := getKubernetesResources(devfileObj, "", devfilefs.NewFakeFs(), warned)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getKubernetesResources() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var gotNames []string
+			for _, u := range got {
+				gotNames = append(gotNames, u.GetKind()+"/"+u.GetName())
+			}
+			if diff := cmp.Diff(tt.want, gotNames, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("getKubernetesResources() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantWarned, warned, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("getKubernetesResources() warned mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_wireKubernetesResources(t *testing.T) {
+	tests := []struct {
+		name          string
+		volumes       []string
+		resources     []unstructured.Unstructured
+		wantResources []unstructured.Unstructured
+		wantVolumes   []corev1.VolumeSource
+		wantEnvFrom   []corev1.EnvFromSource
+	}{
+		{
+			name: "ConfigMaps and Secrets not used as volumes are exposed as environment variables",
+			resources: []unstructured.Unstructured{
+				getResource("ConfigMap", "myconfig"),
+				getResource("Secret", "mysecret"),
+			},
+			wantResources: []unstructured.Unstructured{
+				getResource("ConfigMap", "myconfig"),
+				getResource("Secret", "mysecret"),
+			},
+			wantEnvFrom: []corev1.EnvFromSource{
+				{
+					ConfigMapRef: &corev1.ConfigMapEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "myconfig"},
+					},
+				},
+				{
+					SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "mysecret"},
+					},
+				},
+			},
+		},
+		{
+			name:    "devfile volumes are backed by the resources with the same name",
+			volumes: []string{"myconfig", "mysecret", "mypvc"},
+			resources: []unstructured.Unstructured{
+				getResource("ConfigMap", "myconfig"),
+				getResource("PersistentVolumeClaim", "mypvc"),
+				getResource("Secret", "mysecret"),
+			},
+			wantResources: []unstructured.Unstructured{
+				getResource("ConfigMap", "myconfig"),
+				getResource("PersistentVolumeClaim", "mypvc-mycmp-app"),
+				getResource("Secret", "mysecret"),
+			},
+			wantVolumes: []corev1.VolumeSource{
+				{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "myconfig"},
+					},
+				},
+				{
+					Secret: &corev1.SecretVolumeSource{SecretName: "mysecret"},
+				},
+				{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "mypvc-mycmp-app"},
+				},
+			},
+		},
+		{
+			name:    "PersistentVolumeClaims not used by any volume are ignored",
+			volumes: []string{"myvolume"},
+			resources: []unstructured.Unstructured{
+				getResource("PersistentVolumeClaim", "mypvc"),
+			},
+			wantVolumes: []corev1.VolumeSource{
+				{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "myvolume-mycmp-app"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := basePod.DeepCopy()
+			pod.Spec.Volumes = nil
+			for _, volume := range tt.volumes {
+				pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+					Name: volume,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: getVolumeName(volume, devfileName, appName),
+						},
+					},
+				})
+			}
+
+			got := wireKubernetesResources(pod, tt.resources, devfileName, appName)
+			if diff := cmp.Diff(tt.wantResources, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("wireKubernetesResources() resources mismatch (-want +got):\n%s", diff)
+			}
+			var gotVolumes []corev1.VolumeSource
+			for _, volume := range pod.Spec.Volumes {
+				gotVolumes = append(gotVolumes, volume.VolumeSource)
+			}
+			if diff := cmp.Diff(tt.wantVolumes, gotVolumes, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("wireKubernetesResources() volumes mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantEnvFrom, pod.Spec.Containers[0].EnvFrom, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("wireKubernetesResources() envFrom mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_withoutExistingClaims(t *testing.T) {
+	resources := []unstructured.Unstructured{
+		getResource("ConfigMap", "myconfig"),
+		getResource("PersistentVolumeClaim", "existing-mycmp-app"),
+		getResource("PersistentVolumeClaim", "new-mycmp-app"),
+	}
+	want := []unstructured.Unstructured{
+		getResource("ConfigMap", "myconfig"),
+		getResource("PersistentVolumeClaim", "new-mycmp-app"),
+	}
+	got := withoutExistingClaims(resources, map[string]bool{"existing-mycmp-app": true, "myconfig": true})
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("withoutExistingClaims() mismatch (-want +got):\n%s", diff)
+	}
+}
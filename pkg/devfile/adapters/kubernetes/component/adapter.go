@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"path/filepath"
 	"reflect"
 	"strings"
 	sync2 "sync"
@@ -170,14 +169,14 @@ func (a Adapter) Push(ctx context.Context, parameters adapters.PushParameters, c
 
 	if updated {
 		klog.V(4).Infof("Deployment has been updated to generation %d. Waiting new event...\n", deployment.GetGeneration())
-		componentStatus.State = watch.StateWaitDeployment
+		componentStatus.SetState(watch.StateWaitDeployment)
 		return nil
 	}
 
 	numberReplicas := deployment.Status.ReadyReplicas
 	if numberReplicas != 1 {
 		klog.V(4).Infof("Deployment has %d ready replicas. Waiting new event...\n", numberReplicas)
-		componentStatus.State = watch.StateWaitDeployment
+		componentStatus.SetState(watch.StateWaitDeployment)
 		return nil
 	}
 
@@ -209,29 +208,37 @@ func (a Adapter) Push(ctx context.Context, parameters adapters.PushParameters, c
 		return fmt.Errorf("unable to get pod for component %s: %w", a.ComponentName, err)
 	}
 
-	// Find at least one pod with the source volume mounted, error out if none can be found
-	containerName, syncFolder, err := common.GetFirstContainerWithSourceVolume(pod.Spec.Containers)
+	// Find the containers with the source volume mounted, error out if none can be found
+	containers, err := common.GetContainersWithSourceVolume(pod.Spec.Containers)
 	if err != nil {
-		return fmt.Errorf("error while retrieving container from pod %s with a mounted project volume: %w", pod.GetName(), err)
+		return fmt.Errorf("error while retrieving containers from pod %s with a mounted project volume: %w", pod.GetName(), err)
 	}
 
 	s := log.Spinner("Syncing files into the container")
 	defer s.End(false)
 
-	// Get commands
-	pushDevfileCommands, err := a.getPushDevfileCommands(parameters)
+	// Get the files to sync defined by the commands
+	syncFiles, err := a.getSyncFiles(parameters)
 	if err != nil {
 		return fmt.Errorf("failed to validate devfile build and run commands: %w", err)
 	}
 
 	podChanged := componentStatus.State == watch.StateWaitDeployment
+	if componentStatus.State != watch.StateReady && componentStatus.State != watch.StateSyncOutdated {
+		// the pod is deployed for the first time, or has been recreated
+		a.logger.KubernetesPodStatus([]machineoutput.KubernetesPodStatusEntry{machineoutput.NewKubernetesPodStatusEntry(pod)}, machineoutput.TimestampNow())
+	}
 
 	// Get a sync adapter. Check if project files have changed and sync accordingly
-	compInfo := sync.ComponentInfo{
-		ComponentName: a.ComponentName,
-		ContainerName: containerName,
-		PodName:       pod.GetName(),
-		SyncFolder:    syncFolder,
+	var syncStats sync.SyncStats
+	compInfos := make([]sync.ComponentInfo, 0, len(containers))
+	for _, container := range containers {
+		compInfos = append(compInfos, sync.ComponentInfo{
+			ComponentName: a.ComponentName,
+			ContainerName: container.Name,
+			PodName:       pod.GetName(),
+			SyncFolder:    container.SyncFolder,
+		})
 	}
 
 	syncParams := sync.SyncParameters{
@@ -241,17 +248,23 @@ func (a Adapter) Push(ctx context.Context, parameters adapters.PushParameters, c
 		IgnoredFiles:             parameters.IgnoredFiles,
 		DevfileScanIndexForWatch: parameters.DevfileScanIndexForWatch,
 
-		CompInfo:  compInfo,
-		ForcePush: !deploymentExists || podChanged,
-		Files:     getSyncFilesFromAttributes(pushDevfileCommands),
+		CompInfos: compInfos,
+		ForcePush: !deploymentExists || podChanged || parameters.ForcePush,
+		Files:     syncFiles,
+		Stats:     &syncStats,
 	}
 
 	execRequired, err := a.syncClient.SyncFiles(syncParams)
 	if err != nil {
-		componentStatus.State = watch.StateReady
+		componentStatus.SetState(watch.StateReady)
 		return fmt.Errorf("failed to sync to component with name %s: %w", a.ComponentName, err)
 	}
-	s.End(true)
+	a.logger.FilesSynced(syncStats.Files, syncStats.Bytes, syncStats.TransferredBytes, syncStats.Compression, syncStats.Duration, machineoutput.TimestampNow())
+	if syncStats.Files > 0 {
+		s.EndWithStatus(fmt.Sprintf("Syncing files into the container (%s)", syncStats), true)
+	} else {
+		s.End(true)
+	}
 
 	// PostStart events from the devfile will only be executed when the component
 	// didn't previously exist
@@ -280,6 +293,13 @@ func (a Adapter) Push(ctx context.Context, parameters adapters.PushParameters, c
 	if err != nil {
 		return err
 	}
+	if parameters.RestartCommand {
+		err = component.StopRunAndDebugCommands(a.execClient, a.Devfile, parameters.DevfileRunCmd, parameters.DevfileDebugCmd, pod.GetName())
+		if err != nil {
+			return fmt.Errorf("unable to stop the running commands: %w", err)
+		}
+	}
+
 	var running bool
 	var isComposite bool
 	cmdHandler := runHandler{
@@ -308,7 +328,8 @@ func (a Adapter) Push(ctx context.Context, parameters adapters.PushParameters, c
 			commandType, cmd.Id)
 	}
 
-	cmdHandler.componentExists = running || isComposite
+	// The commands have been stopped and must be started again, even the hot-reload capable ones
+	cmdHandler.componentExists = (running || isComposite) && !parameters.RestartCommand
 
 	klog.V(4).Infof("running=%v, execRequired=%v",
 		running, execRequired)
@@ -348,7 +369,7 @@ func (a Adapter) Push(ctx context.Context, parameters adapters.PushParameters, c
 	}
 	componentStatus.EndpointsForwarded = a.portForwardClient.GetForwardedPorts()
 
-	componentStatus.State = watch.StateReady
+	componentStatus.SetState(watch.StateReady)
 	return nil
 }
 
@@ -754,21 +775,3 @@ func (a Adapter) deleteServiceBindingSecrets(serviceBindingSecretsToRemove []uns
 	}
 	return nil
 }
-
-// PushCommandsMap stores the commands to be executed as per their types.
-type PushCommandsMap map[devfilev1.CommandGroupKind]devfilev1.Command
-
-// getSyncFilesFromAttributes gets the target files and folders along with their respective remote destination from the devfile
-// it uses the "dev.odo.push.path" attribute in the run command
-func getSyncFilesFromAttributes(commandsMap PushCommandsMap) map[string]string {
-	syncMap := make(map[string]string)
-	if value, ok := commandsMap[devfilev1.RunCommandGroupKind]; ok {
-		for key, value := range value.Attributes.Strings(nil) {
-			if strings.HasPrefix(key, "dev.odo.push.path:") {
-				localValue := strings.ReplaceAll(key, "dev.odo.push.path:", "")
-				syncMap[filepath.Clean(localValue)] = filepath.ToSlash(filepath.Clean(value))
-			}
-		}
-	}
-	return syncMap
-}
@@ -8,11 +8,13 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 
+	"github.com/redhat-developer/odo/pkg/devfile/adapters"
 	"github.com/redhat-developer/odo/pkg/libdevfile"
 	"github.com/redhat-developer/odo/pkg/preference"
 	"github.com/redhat-developer/odo/pkg/util"
 
 	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
 	devfileParser "github.com/devfile/library/pkg/devfile/parser"
 	"github.com/devfile/library/pkg/testingutil"
 
@@ -244,3 +246,81 @@ func TestAdapter_generateDeploymentObjectMeta(t *testing.T) {
 		})
 	}
 }
+
+func TestAdapter_getSyncFiles(t *testing.T) {
+	command := func(id string, kind devfilev1.CommandGroupKind, pushPaths map[string]string) devfilev1.Command {
+		cmd := devfilev1.Command{
+			Id: id,
+			CommandUnion: devfilev1.CommandUnion{
+				Exec: &devfilev1.ExecCommand{
+					LabeledCommand: devfilev1.LabeledCommand{
+						BaseCommand: devfilev1.BaseCommand{
+							Group: &devfilev1.CommandGroup{Kind: kind, IsDefault: util.GetBoolPtr(true)},
+						},
+					},
+					CommandLine: "ls -la",
+					Component:   "runtime",
+				},
+			},
+		}
+		cmd.Attributes = attributes.Attributes{}
+		for local, remote := range pushPaths {
+			cmd.Attributes = cmd.Attributes.PutString("dev.odo.push.path:"+local, remote)
+		}
+		return cmd
+	}
+	devfileData, err := data.NewDevfileData(string(data.APISchemaVersion200))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = devfileData.AddCommands([]devfilev1.Command{
+		command("build", devfilev1.BuildCommandGroupKind, map[string]string{"package.json": "package.json", "server.js": "build/server.js"}),
+		command("run", devfilev1.RunCommandGroupKind, map[string]string{"server.js": "run/server.js"}),
+		command("debug", devfilev1.DebugCommandGroupKind, map[string]string{"server.js": "debug/server.js"}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = devfileData.AddComponents([]devfilev1.Component{testingutil.GetFakeContainerComponent("runtime")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		parameters adapters.PushParameters
+		want       map[string]string
+	}{
+		{
+			name: "attributes of the build and run commands",
+			want: map[string]string{
+				"package.json": "package.json",
+				"server.js":    "run/server.js",
+			},
+		},
+		{
+			name:       "attributes of the build, run and debug commands when debugging",
+			parameters: adapters.PushParameters{Debug: true},
+			want: map[string]string{
+				"package.json": "package.json",
+				"server.js":    "debug/server.js",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Adapter{
+				AdapterContext: AdapterContext{
+					Devfile: devfileParser.DevfileObj{Data: devfileData},
+				},
+			}
+			got, err := a.getSyncFiles(tt.parameters)
+			if err != nil {
+				t.Fatalf("getSyncFiles() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("getSyncFiles() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
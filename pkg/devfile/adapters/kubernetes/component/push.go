@@ -66,21 +66,14 @@ func (a *Adapter) pushDevfileKubernetesComponents(
 	return k8sComponents, nil
 }
 
-func (a *Adapter) getPushDevfileCommands(parameters adapters.PushParameters) (map[devfilev1.CommandGroupKind]devfilev1.Command, error) {
-	pushDevfileCommands, err := libdevfile.ValidateAndGetPushCommands(a.Devfile, parameters.DevfileBuildCmd, parameters.DevfileRunCmd)
+// getSyncFiles returns the files to sync with their destination, defined by the dev.odo.push.path attributes
+// of the build and run commands selected by the parameters, and of the debug command when debugging
+func (a *Adapter) getSyncFiles(parameters adapters.PushParameters) (map[string]string, error) {
+	pushDevfileCommands, err := libdevfile.GetPushCommands(a.Devfile, parameters.DevfileBuildCmd, parameters.DevfileRunCmd, parameters.Debug, parameters.DevfileDebugCmd)
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate devfile build and run commands: %w", err)
-	}
-
-	if parameters.Debug {
-		pushDevfileDebugCommands, e := libdevfile.ValidateAndGetCommand(a.Devfile, parameters.DevfileDebugCmd, devfilev1.DebugCommandGroupKind)
-		if e != nil {
-			return nil, fmt.Errorf("debug command is not valid: %w", e)
-		}
-		pushDevfileCommands[devfilev1.DebugCommandGroupKind] = pushDevfileDebugCommands
+		return nil, err
 	}
-
-	return pushDevfileCommands, nil
+	return libdevfile.GetSyncFilesFromAttributes(pushDevfileCommands), nil
 }
 
 func (a *Adapter) updatePVCsOwnerReferences(ownerReference metav1.OwnerReference) error {
@@ -15,6 +15,8 @@ type PushParameters struct {
 	DevfileRunCmd            string    // DevfileRunCmd takes the run command through the command line and overwrites devfile run command
 	DevfileDebugCmd          string    // DevfileDebugCmd takes the debug command through the command line and overwrites the devfile debug command
 	DevfileScanIndexForWatch bool      // DevfileScanIndexForWatch is true if watch's push should regenerate the index file during SyncFiles, false otherwise. See 'pkg/sync/adapter.go' for details
+	ForcePush                bool      // ForcePush is true to synchronize all the files, regardless of the files already synchronized
+	RestartCommand           bool      // RestartCommand is true to stop the run and debug commands, then build and start the command again, even if no file has changed
 	Debug                    bool      // Runs the component in debug mode
 	RandomPorts              bool      // True to forward containers ports on local random ports
 	ErrOut                   io.Writer // Writer to output forwarded port information
@@ -4,6 +4,7 @@ package image
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os/exec"
 	"path/filepath"
 
@@ -77,6 +78,20 @@ func BuildPushSpecificImage(ctx context.Context, fs filesystem.Filesystem, compo
 	return buildPushImage(backend, fs, component.Image, path, push)
 }
 
+// BuildSpecificImageWithPodman builds an image defined in the devfile present in devfilePath with Podman,
+// so the image is available locally to the pods run by Podman. The image is not pushed.
+func BuildSpecificImageWithPodman(ctx context.Context, fs filesystem.Filesystem, component devfile.Component) error {
+	var (
+		devfilePath = odocontext.GetDevfilePath(ctx)
+		path        = filepath.Dir(devfilePath)
+	)
+	podmanCmd := envcontext.GetEnvConfig(ctx).PodmanCmd
+	if _, err := lookPathCmd(podmanCmd); err != nil {
+		return fmt.Errorf("podman is required to build the images used on Podman: %w", err)
+	}
+	return buildPushImage(NewDockerCompatibleBackend(podmanCmd), fs, component.Image, path, false)
+}
+
 // buildPushImage build an image using the provided backend
 // If push is true, also push the image to its registry
 func buildPushImage(backend Backend, fs filesystem.Filesystem, image *devfile.ImageComponent, devfilePath string, push bool) error {
@@ -0,0 +1,198 @@
+// Package docker implements the operations needed to run components on a Docker engine.
+// Docker has no notion of pod: a pod is run as an infra container, holding the network namespace
+// and the published ports of the pod, and one container per container of the pod, joining the network namespace
+// of the infra container.
+package docker
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/redhat-developer/odo/pkg/podman"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog"
+)
+
+const (
+	// podLabel is the label set on all the containers of a pod, with the name of the pod as value
+	podLabel = "odo.dev/docker-pod"
+	// infraLabel is the label set on the infra container of a pod
+	infraLabel = "odo.dev/docker-infra"
+	// containerLabel is the label set on the containers of a pod, with the name of the container in the pod as value
+	containerLabel = "odo.dev/docker-container"
+
+	// infraImage is the image of the infra containers, doing nothing but holding the network namespace of the pods
+	infraImage = "registry.k8s.io/pause:3.9"
+)
+
+// DockerCli is an implementation of podman.Client executing the docker binary
+type DockerCli struct{}
+
+var _ podman.Client = (*DockerCli)(nil)
+
+func NewDockerCli() *DockerCli {
+	return &DockerCli{}
+}
+
+// run executes docker with the given arguments, and returns its standard output.
+// It is a variable so it can be replaced in tests
+var run = func(args ...string) ([]byte, error) {
+	klog.V(4).Infof("executing docker %v", redactArgs(args))
+	out, err := exec.Command("docker", args...).Output()
+	if err != nil {
+		if exiterr, ok := err.(*exec.ExitError); ok {
+			err = fmt.Errorf("%s: %s", err, string(exiterr.Stderr))
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlayKube runs the pod as containers, after creating the volumes defined by the PersistentVolumeClaims in resources.
+// The ConfigMaps and Secrets in resources are used to define the environment variables of the containers.
+func (o *DockerCli) PlayKube(pod *corev1.Pod, resources []unstructured.Unstructured) error {
+	volumes := getVolumes(pod)
+	envFileContents := make([]string, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		env, err := getEnv(container, resources)
+		if err != nil {
+			return fmt.Errorf("unable to define container %q: %w", container.Name, err)
+		}
+		content, err := getEnvFileContent(env)
+		if err != nil {
+			return fmt.Errorf("unable to define container %q: %w", container.Name, err)
+		}
+		envFileContents = append(envFileContents, content)
+	}
+
+	for _, resource := range resources {
+		if resource.GetKind() != "PersistentVolumeClaim" {
+			continue
+		}
+		_, err := run("volume", "create", resource.GetName())
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir == nil {
+			continue
+		}
+		// the volume is labelled with the name of the pod, to be deleted with the pod
+		_, err := run("volume", "create", "--label", podLabel+"="+pod.GetName(), volumes[volume.Name])
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := run(getInfraArgs(pod)...)
+	for i := 0; err == nil && i < len(pod.Spec.Containers); i++ {
+		err = runContainer(pod, pod.Spec.Containers[i], volumes, envFileContents[i])
+	}
+	if err != nil {
+		// the containers already created are removed, so the pod can be created again
+		if rmErr := o.PodRm(pod.GetName()); rmErr != nil {
+			klog.V(4).Infof("unable to remove the containers of pod %q: %v", pod.GetName(), rmErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// runContainer creates the container of the pod. The environment variables of the container, defined by envFileContent,
+// are passed in a temporary file readable only by the user, deleted once the container is started
+func runContainer(pod *corev1.Pod, container corev1.Container, volumes map[string]string, envFileContent string) error {
+	envFile := ""
+	if envFileContent != "" {
+		var err error
+		envFile, err = writeEnvFile(envFileContent)
+		if err != nil {
+			return fmt.Errorf("unable to write the environment variables of container %q: %w", container.Name, err)
+		}
+		defer func() {
+			if err := os.Remove(envFile); err != nil {
+				klog.V(4).Infof("unable to remove file %s: %v", envFile, err)
+			}
+		}()
+	}
+	_, err := run(getContainerArgs(pod, container, volumes, envFile)...)
+	return err
+}
+
+// PodStop stops all the containers of the pod
+func (o *DockerCli) PodStop(podname string) error {
+	ids, err := getPodContainerIDs(podname)
+	if err != nil {
+		return err
+	}
+	out, err := run(append([]string{"stop"}, ids...)...)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("Stopped containers %s", strings.TrimSpace(string(out)))
+	return nil
+}
+
+// PodRm deletes all the containers of the pod, and the volumes backing its emptyDir volumes.
+// The volumes backing its PersistentVolumeClaims are kept
+func (o *DockerCli) PodRm(podname string) error {
+	ids, err := getPodContainerIDs(podname)
+	if err != nil {
+		return err
+	}
+	out, err := run(append([]string{"rm", "--force", "--volumes"}, ids...)...)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("Deleted containers %s", strings.TrimSpace(string(out)))
+
+	out, err = run("volume", "ls", "--quiet", "--filter", "label="+podLabel+"="+podname)
+	if err != nil {
+		return err
+	}
+	volumes := strings.Fields(string(out))
+	if len(volumes) == 0 {
+		return nil
+	}
+	out, err = run(append([]string{"volume", "rm"}, volumes...)...)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("Deleted volumes %s", strings.TrimSpace(string(out)))
+	return nil
+}
+
+func (o *DockerCli) VolumeRm(volumeName string) error {
+	out, err := run("volume", "rm", volumeName)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("Deleted volume %s", string(out))
+	return nil
+}
+
+func (o *DockerCli) VolumeLs() (map[string]bool, error) {
+	out, err := run("volume", "ls", "--format", "{{.Name}}")
+	if err != nil {
+		return nil, err
+	}
+	return podman.SplitLinesAsSet(string(out)), nil
+}
+
+// getPodContainerIDs returns the IDs of the containers of the pod, including its infra container
+func getPodContainerIDs(podname string) ([]string, error) {
+	out, err := run("ps", "--all", "--quiet", "--no-trunc", "--filter", "label="+podLabel+"="+podname)
+	if err != nil {
+		return nil, err
+	}
+	ids := strings.Fields(string(out))
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no pod with name %q", podname)
+	}
+	return ids, nil
+}
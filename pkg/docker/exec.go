@@ -0,0 +1,31 @@
+package docker
+
+import (
+	"io"
+	"os/exec"
+
+	"k8s.io/klog"
+)
+
+func (o *DockerCli) ExecCMDInContainer(containerName, podName string, cmd []string, stdout io.Writer, stderr io.Writer, stdin io.Reader, tty bool) error {
+	options := []string{}
+	if tty {
+		options = append(options, "--tty")
+	}
+
+	args := []string{"exec", "--interactive"}
+	args = append(args, options...)
+	args = append(args, getContainerName(podName, containerName))
+	args = append(args, cmd...)
+
+	command := exec.Command("docker", args...)
+	command.Stdin = stdin
+
+	klog.V(4).Infof("exec docker %v\n", args)
+	out, err := command.Output()
+	if err != nil {
+		return err
+	}
+	_, err = stdout.Write(out)
+	return err
+}
@@ -0,0 +1,115 @@
+package docker
+
+import (
+	"io"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// GetPodLogs returns the logs of the specified pod container.
+// All logs for all containers part of the pod are returned if an empty string is provided as container name.
+func (o *DockerCli) GetPodLogs(podName, containerName string, followLog bool) (io.ReadCloser, error) {
+	containerNames := []string{containerName}
+	if containerName == "" {
+		pod, err := o.KubeGenerate(podName)
+		if err != nil {
+			return nil, err
+		}
+		containerNames = nil
+		for _, container := range pod.Spec.Containers {
+			containerNames = append(containerNames, container.Name)
+		}
+	}
+
+	// docker logs writes the container stdout and stderr on its own stdout and stderr,
+	// both are merged into the returned stream, along with the logs of the other containers
+	pr, pw := io.Pipe()
+	reader := &logsReader{
+		PipeReader: pr,
+	}
+	var wg sync.WaitGroup
+	for _, name := range containerNames {
+		args := getLogsArgs(podName, name, followLog)
+		cmd := exec.Command("docker", args...)
+		klog.V(4).Infof("executing docker %v", redactArgs(args))
+		cmd.Stdout = pw
+		cmd.Stderr = pw
+		if err := cmd.Start(); err != nil {
+			_ = reader.Close()
+			return nil, err
+		}
+		reader.cmds = append(reader.cmds, cmd)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = cmd.Wait()
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		_ = pw.Close()
+	}()
+
+	return reader, nil
+}
+
+// FollowPodLogsSince follows the logs of the pod container emitted since the given time, or all its logs if since is zero,
+// each line being prefixed with its timestamp
+func (o *DockerCli) FollowPodLogsSince(podName, containerName string, since time.Time) (io.ReadCloser, error) {
+	args := getFollowLogsSinceArgs(podName, containerName, since)
+	cmd := exec.Command("docker", args...)
+	klog.V(4).Infof("executing docker %v", redactArgs(args))
+
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	go func() {
+		_ = pw.CloseWithError(cmd.Wait())
+	}()
+	return &logsReader{
+		PipeReader: pr,
+		cmds:       []*exec.Cmd{cmd},
+	}, nil
+}
+
+// getLogsArgs returns the arguments to pass to docker to get the logs of the pod container
+func getLogsArgs(podName, containerName string, followLog bool) []string {
+	args := []string{"logs"}
+	if followLog {
+		args = append(args, "--follow")
+	}
+	return append(args, getContainerName(podName, containerName))
+}
+
+// getFollowLogsSinceArgs returns the arguments to pass to docker to follow the timestamped logs of the pod container,
+// emitted since the given time if not zero
+func getFollowLogsSinceArgs(podName, containerName string, since time.Time) []string {
+	args := []string{"logs", "--follow", "--timestamps"}
+	if !since.IsZero() {
+		args = append(args, "--since", strconv.FormatInt(since.Unix(), 10))
+	}
+	return append(args, getContainerName(podName, containerName))
+}
+
+// logsReader reads the output of `docker logs` commands.
+// Closing it stops the commands, if still running.
+type logsReader struct {
+	*io.PipeReader
+	cmds []*exec.Cmd
+}
+
+func (o *logsReader) Close() error {
+	for _, cmd := range o.cmds {
+		// Kill returns an error if the process already exited, which can be ignored
+		_ = cmd.Process.Kill()
+	}
+	return o.PipeReader.Close()
+}
@@ -0,0 +1,246 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/redhat-developer/odo/pkg/podman"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+)
+
+// ContainerInspect is the subset of the `docker inspect` output used by odo
+type ContainerInspect struct {
+	ID           string `json:"Id"`
+	Name         string
+	RestartCount int32
+	State        podman.ContainerInspectState
+	Config       ContainerInspectConfig
+	Mounts       []ContainerInspectMount
+}
+
+// ContainerInspectConfig is the configuration of a container, part of the `docker inspect` output
+type ContainerInspectConfig struct {
+	Labels map[string]string
+}
+
+// ContainerInspectMount is a mount of a container, part of the `docker inspect` output
+type ContainerInspectMount struct {
+	Type        string
+	Name        string
+	Destination string
+}
+
+// GetPodsMatchingSelector returns all pods matching the given label selector.
+func (o *DockerCli) GetPodsMatchingSelector(selector string) (*corev1.PodList, error) {
+	inspects, err := inspectContainers("label=" + podLabel)
+	if err != nil {
+		return nil, err
+	}
+	return toPodList(inspects, selector)
+}
+
+// GetAllResourcesFromSelector returns all resources of any kind matching the given label selector.
+// Only pods are returned, as pods are the only resources labelled by odo on Docker.
+func (o *DockerCli) GetAllResourcesFromSelector(selector string, ns string) ([]unstructured.Unstructured, error) {
+	pods, err := o.GetPodsMatchingSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]unstructured.Unstructured, 0, len(pods.Items))
+	for i := range pods.Items {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&pods.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, unstructured.Unstructured{Object: content})
+	}
+	return result, nil
+}
+
+// GetAllPodsInNamespaceMatchingSelector returns all pods matching the given label selector and in the specified namespace.
+// Docker has no notion of namespace, so the namespace is ignored.
+func (o *DockerCli) GetAllPodsInNamespaceMatchingSelector(selector string, ns string) (*corev1.PodList, error) {
+	return o.GetPodsMatchingSelector(selector)
+}
+
+// GetRunningPodFromSelector returns the only running pod matching the given label selector.
+// A PodNotFoundError is returned if no pod is running, and an error if several pods are running
+func (o *DockerCli) GetRunningPodFromSelector(selector string) (*corev1.Pod, error) {
+	pods, err := o.GetPodsMatchingSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return podman.GetRunningPod(pods, selector)
+}
+
+// KubeGenerate returns the definition of the pod with the given name, including its volumes.
+// Only the named volumes mounted by the containers are part of the returned volumes.
+func (o *DockerCli) KubeGenerate(name string) (*corev1.Pod, error) {
+	inspects, err := inspectContainers("label=" + podLabel + "=" + name)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := toPodList(inspects, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, fmt.Errorf("no pod with name %q", name)
+	}
+	pod := pods.Items[0]
+	pod.Spec.Volumes = getNamedVolumes(inspects)
+	return &pod, nil
+}
+
+// inspectContainers returns the description of all the containers matching the docker filter
+func inspectContainers(filter string) ([]ContainerInspect, error) {
+	out, err := run("ps", "--all", "--quiet", "--no-trunc", "--filter", filter)
+	if err != nil {
+		return nil, err
+	}
+	ids := strings.Fields(string(out))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	out, err = run(append([]string{"inspect", "--type", "container"}, ids...)...)
+	if err != nil {
+		// containers can be deleted between the list and the inspection
+		klog.V(4).Infof("unable to inspect containers: %v", err)
+		return nil, nil
+	}
+	var result []ContainerInspect
+	err = json.Unmarshal(out, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse output of docker inspect: %w", err)
+	}
+	klog.V(4).Infof("%d containers found in docker", len(result))
+	return result, nil
+}
+
+// toPodList returns the pods run by the containers, and matching selector, as Kubernetes pods.
+// A pod is described by its infra container, the labels of the pod being the labels of the infra container.
+// The infra container is not part of the returned containers.
+func toPodList(inspects []ContainerInspect, selector string) (*corev1.PodList, error) {
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var pods []*corev1.Pod
+	containers := map[string][]ContainerInspect{}
+	for _, inspect := range inspects {
+		podName := inspect.Config.Labels[podLabel]
+		if inspect.Config.Labels[infraLabel] == "" {
+			containers[podName] = append(containers[podName], inspect)
+			continue
+		}
+		podLabels := map[string]string{}
+		for key, value := range inspect.Config.Labels {
+			if key == podLabel || key == infraLabel {
+				continue
+			}
+			podLabels[key] = value
+		}
+		if !sel.Matches(labels.Set(podLabels)) {
+			continue
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   podName,
+				UID:    types.UID(inspect.ID),
+				Labels: podLabels,
+			},
+			Status: corev1.PodStatus{
+				Phase: toPodPhase(inspect.State.Status),
+			},
+		}
+		pod.APIVersion, pod.Kind = corev1.SchemeGroupVersion.WithKind("Pod").ToAPIVersionAndKind()
+		pods = append(pods, pod)
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].GetName() < pods[j].GetName()
+	})
+	result := corev1.PodList{}
+	for _, pod := range pods {
+		podContainers := containers[pod.GetName()]
+		sort.Slice(podContainers, func(i, j int) bool {
+			return podContainers[i].Config.Labels[containerLabel] < podContainers[j].Config.Labels[containerLabel]
+		})
+		for _, container := range podContainers {
+			name := container.Config.Labels[containerLabel]
+			pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+				Name: name,
+			})
+			status := corev1.ContainerStatus{
+				Name:        name,
+				ContainerID: container.ID,
+			}
+			podman.SetContainerState(&status, podman.ContainerInspect{
+				ID:           container.ID,
+				RestartCount: container.RestartCount,
+				State:        container.State,
+			})
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, status)
+		}
+		result.Items = append(result.Items, *pod)
+	}
+	return &result, nil
+}
+
+// toPodPhase converts the status of an infra container into a Kubernetes pod phase
+func toPodPhase(status string) corev1.PodPhase {
+	switch strings.ToLower(status) {
+	case "running", "restarting":
+		return corev1.PodRunning
+	case "created", "paused":
+		return corev1.PodPending
+	case "exited":
+		return corev1.PodSucceeded
+	case "dead", "removing":
+		return corev1.PodFailed
+	default:
+		return corev1.PodUnknown
+	}
+}
+
+// anonymousVolumeName matches the names generated by Docker for anonymous volumes
+var anonymousVolumeName = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// getNamedVolumes returns the named volumes mounted by the containers, as PersistentVolumeClaims with the name of the volume.
+// Anonymous volumes are deleted with the containers, and are not returned.
+func getNamedVolumes(inspects []ContainerInspect) []corev1.Volume {
+	names := map[string]bool{}
+	for _, inspect := range inspects {
+		for _, mount := range inspect.Mounts {
+			if mount.Type == "volume" && mount.Name != "" && !anonymousVolumeName.MatchString(mount.Name) {
+				names[mount.Name] = true
+			}
+		}
+	}
+	result := make([]corev1.Volume, 0, len(names))
+	for name := range names {
+		result = append(result, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: name,
+				},
+			},
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
@@ -0,0 +1,223 @@
+package docker
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/redhat-developer/odo/pkg/kclient"
+	"github.com/redhat-developer/odo/pkg/podman"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_toPodList(t *testing.T) {
+	inspects := []ContainerInspect{
+		{
+			ID:    "infra1",
+			State: podman.ContainerInspectState{Status: "running"},
+			Config: ContainerInspectConfig{Labels: map[string]string{
+				podLabel:    "mycmp-app",
+				infraLabel:  "true",
+				"component": "mycmp",
+			}},
+		},
+		{
+			ID:           "tools1",
+			RestartCount: 1,
+			State:        podman.ContainerInspectState{Status: "exited", ExitCode: 137, OOMKilled: true},
+			Config: ContainerInspectConfig{Labels: map[string]string{
+				podLabel:       "mycmp-app",
+				containerLabel: "tools",
+			}},
+		},
+		{
+			ID:    "runtime1",
+			State: podman.ContainerInspectState{Status: "running"},
+			Config: ContainerInspectConfig{Labels: map[string]string{
+				podLabel:       "mycmp-app",
+				containerLabel: "runtime",
+			}},
+		},
+		{
+			ID:    "infra2",
+			State: podman.ContainerInspectState{Status: "created"},
+			Config: ContainerInspectConfig{Labels: map[string]string{
+				podLabel:    "other-app",
+				infraLabel:  "true",
+				"component": "other",
+			}},
+		},
+	}
+
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "mycmp-app",
+			UID:    "infra1",
+			Labels: map[string]string{"component": "mycmp"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "runtime"}, {Name: "tools"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:        "runtime",
+					ContainerID: "runtime1",
+					Ready:       true,
+					State:       corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				},
+				{
+					Name:         "tools",
+					ContainerID:  "tools1",
+					RestartCount: 1,
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 137,
+						Reason:   "OOMKilled",
+					}},
+				},
+			},
+		},
+	}
+	pod.APIVersion, pod.Kind = corev1.SchemeGroupVersion.WithKind("Pod").ToAPIVersionAndKind()
+
+	got, err := toPodList(inspects, "component=mycmp")
+	if err != nil {
+		t.Fatalf("toPodList() unexpected error: %v", err)
+	}
+	want := &corev1.PodList{Items: []corev1.Pod{pod}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("toPodList() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_getNamedVolumes(t *testing.T) {
+	inspects := []ContainerInspect{
+		{
+			Mounts: []ContainerInspectMount{
+				{Type: "volume", Name: "odo-projects-mycmp-app", Destination: "/projects"},
+				{Type: "volume", Name: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", Destination: "/data"},
+				{Type: "bind", Destination: "/etc/hosts"},
+			},
+		},
+		{
+			Mounts: []ContainerInspectMount{
+				{Type: "volume", Name: "odo-projects-mycmp-app", Destination: "/projects"},
+				{Type: "volume", Name: "cache-mycmp-app", Destination: "/cache"},
+			},
+		},
+	}
+	want := []corev1.Volume{
+		{
+			Name: "cache-mycmp-app",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "cache-mycmp-app"},
+			},
+		},
+		{
+			Name: "odo-projects-mycmp-app",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "odo-projects-mycmp-app"},
+			},
+		},
+	}
+	got := getNamedVolumes(inspects)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("getNamedVolumes() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// fakeRun replaces the execution of docker by handler during the test
+func fakeRun(t *testing.T, handler func(args []string) ([]byte, error)) {
+	saved := run
+	t.Cleanup(func() {
+		run = saved
+	})
+	run = func(args ...string) ([]byte, error) {
+		return handler(args)
+	}
+}
+
+// fakeInspect fakes the docker commands listing and inspecting the containers, returning inspects
+func fakeInspect(t *testing.T, inspects []ContainerInspect) {
+	fakeRun(t, func(args []string) ([]byte, error) {
+		switch args[0] {
+		case "ps":
+			var ids []byte
+			for _, inspect := range inspects {
+				ids = append(ids, inspect.ID+"\n"...)
+			}
+			return ids, nil
+		case "inspect":
+			return json.Marshal(inspects)
+		}
+		return nil, errors.New("unexpected docker command")
+	})
+}
+
+func TestDockerCli_GetRunningPodFromSelector(t *testing.T) {
+	infra := func(id string, podName string, status string) ContainerInspect {
+		return ContainerInspect{
+			ID:    id,
+			State: podman.ContainerInspectState{Status: status},
+			Config: ContainerInspectConfig{Labels: map[string]string{
+				podLabel:    podName,
+				infraLabel:  "true",
+				"component": "mycmp",
+			}},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		inspects        []ContainerInspect
+		wantName        string
+		wantNotFoundErr bool
+		wantErr         bool
+	}{
+		{
+			name:     "one running pod and a stopped pod",
+			inspects: []ContainerInspect{infra("infra1", "old-app", "exited"), infra("infra2", "mycmp-app", "running")},
+			wantName: "mycmp-app",
+		},
+		{
+			name:            "no pod",
+			wantNotFoundErr: true,
+			wantErr:         true,
+		},
+		{
+			name:            "no running pod",
+			inspects:        []ContainerInspect{infra("infra1", "mycmp-app", "created")},
+			wantNotFoundErr: true,
+			wantErr:         true,
+		},
+		{
+			name:     "several running pods",
+			inspects: []ContainerInspect{infra("infra1", "mycmp-app", "running"), infra("infra2", "other-app", "running")},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeInspect(t, tt.inspects)
+			got, err := NewDockerCli().GetRunningPodFromSelector("component=mycmp")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRunningPodFromSelector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var notFoundErr *kclient.PodNotFoundError
+			if errors.As(err, &notFoundErr) != tt.wantNotFoundErr {
+				t.Errorf("GetRunningPodFromSelector() error = %v, want PodNotFoundError %v", err, tt.wantNotFoundErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.GetName() != tt.wantName {
+				t.Errorf("GetRunningPodFromSelector() = %q, want %q", got.GetName(), tt.wantName)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package docker
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-developer/odo/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// getContainerName returns the name of the Docker container running the container of the pod,
+// as named by `podman play kube`
+func getContainerName(podName string, containerName string) string {
+	return fmt.Sprintf("%s-%s", podName, containerName)
+}
+
+// getVolumes returns the names of the Docker volumes backing the volumes of the pod, indexed by the names of the pod volumes.
+// PersistentVolumeClaims are backed by the volume with the name of the claim, emptyDir volumes by a volume dedicated to the pod,
+// deleted with the pod.
+// Other volume types are not supported, and are not mounted.
+func getVolumes(pod *corev1.Pod) map[string]string {
+	result := map[string]string{}
+	for _, volume := range pod.Spec.Volumes {
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			result[volume.Name] = volume.PersistentVolumeClaim.ClaimName
+		case volume.EmptyDir != nil:
+			result[volume.Name] = pod.GetName() + "-" + volume.Name
+		default:
+			log.Warningf("Volume %q of pod %q is not supported on Docker and is not mounted", volume.Name, pod.GetName())
+		}
+	}
+	return result
+}
+
+// getInfraArgs returns the arguments of `docker run` creating the infra container of the pod,
+// which holds the labels of the pod, and publishes the host ports of its containers on the local interface
+func getInfraArgs(pod *corev1.Pod) []string {
+	args := []string{
+		"run", "--detach",
+		"--name", pod.GetName(),
+		"--label", podLabel + "=" + pod.GetName(),
+		"--label", infraLabel + "=true",
+	}
+	podLabels := pod.GetLabels()
+	keys := make([]string, 0, len(podLabels))
+	for key := range podLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, "--label", key+"="+podLabels[key])
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.HostPort == 0 {
+				continue
+			}
+			hostIP := port.HostIP
+			if hostIP == "" {
+				hostIP = "127.0.0.1"
+			}
+			protocol := "tcp"
+			if port.Protocol != "" {
+				protocol = strings.ToLower(string(port.Protocol))
+			}
+			args = append(args, "--publish", fmt.Sprintf("%s:%d:%d/%s", hostIP, port.HostPort, port.ContainerPort, protocol))
+		}
+	}
+	return append(args, infraImage)
+}
+
+// getContainerArgs returns the arguments of `docker run` creating the container of the pod.
+// volumes are the Docker volumes backing the volumes of the pod, as returned by getVolumes.
+// envFile is the file defining the environment variables of the container, if not empty.
+func getContainerArgs(pod *corev1.Pod, container corev1.Container, volumes map[string]string, envFile string) []string {
+	args := []string{
+		"run", "--detach",
+		"--name", getContainerName(pod.GetName(), container.Name),
+		"--label", podLabel + "=" + pod.GetName(),
+		"--label", containerLabel + "=" + container.Name,
+		"--network", "container:" + pod.GetName(),
+		"--restart", getRestartPolicy(pod.Spec.RestartPolicy),
+	}
+	if container.WorkingDir != "" {
+		args = append(args, "--workdir", container.WorkingDir)
+	}
+	if container.TTY {
+		args = append(args, "--tty")
+	}
+	if container.Stdin {
+		args = append(args, "--interactive")
+	}
+	if memory, found := container.Resources.Limits[corev1.ResourceMemory]; found {
+		args = append(args, "--memory", strconv.FormatInt(memory.Value(), 10))
+	}
+	if cpu, found := container.Resources.Limits[corev1.ResourceCPU]; found {
+		args = append(args, "--cpus", strconv.FormatFloat(float64(cpu.MilliValue())/1000, 'f', -1, 64))
+	}
+
+	if envFile != "" {
+		// the values of the variables, which can come from Secrets, are not passed in the command line
+		args = append(args, "--env-file", envFile)
+	}
+
+	for _, mount := range container.VolumeMounts {
+		volume, found := volumes[mount.Name]
+		if !found {
+			continue
+		}
+		if mount.SubPath != "" {
+			log.Warningf("Sub path %q of volume %q is not supported on Docker, the whole volume is mounted", mount.SubPath, mount.Name)
+		}
+		spec := volume + ":" + mount.MountPath
+		if mount.ReadOnly {
+			spec += ":ro"
+		}
+		args = append(args, "--volume", spec)
+	}
+
+	if len(container.Command) > 0 {
+		args = append(args, "--entrypoint", container.Command[0], container.Image)
+		args = append(args, container.Command[1:]...)
+	} else {
+		args = append(args, container.Image)
+	}
+	return append(args, container.Args...)
+}
+
+// getRestartPolicy returns the Docker restart policy equivalent to the restart policy of a pod
+func getRestartPolicy(policy corev1.RestartPolicy) string {
+	switch policy {
+	case corev1.RestartPolicyNever:
+		return "no"
+	case corev1.RestartPolicyOnFailure:
+		return "on-failure"
+	default:
+		return "always"
+	}
+}
+
+// getEnv returns the environment variables of the container, as NAME=value.
+// The variables defined with envFrom are returned first, so they can be overridden by the variables defined with env.
+func getEnv(container corev1.Container, resources []unstructured.Unstructured) ([]string, error) {
+	var result []string
+	for _, envFrom := range container.EnvFrom {
+		var (
+			kind     string
+			name     string
+			optional *bool
+		)
+		switch {
+		case envFrom.ConfigMapRef != nil:
+			kind, name, optional = "ConfigMap", envFrom.ConfigMapRef.Name, envFrom.ConfigMapRef.Optional
+		case envFrom.SecretRef != nil:
+			kind, name, optional = "Secret", envFrom.SecretRef.Name, envFrom.SecretRef.Optional
+		default:
+			continue
+		}
+		data, found, err := getResourceData(resources, kind, name)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			if optional != nil && *optional {
+				continue
+			}
+			return nil, fmt.Errorf("%s %q not found", kind, name)
+		}
+		keys := make([]string, 0, len(data))
+		for key := range data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			result = append(result, envFrom.Prefix+key+"="+data[key])
+		}
+	}
+
+	for _, env := range container.Env {
+		if env.ValueFrom == nil {
+			result = append(result, env.Name+"="+env.Value)
+			continue
+		}
+		var (
+			kind     string
+			name     string
+			key      string
+			optional *bool
+		)
+		switch {
+		case env.ValueFrom.ConfigMapKeyRef != nil:
+			ref := env.ValueFrom.ConfigMapKeyRef
+			kind, name, key, optional = "ConfigMap", ref.Name, ref.Key, ref.Optional
+		case env.ValueFrom.SecretKeyRef != nil:
+			ref := env.ValueFrom.SecretKeyRef
+			kind, name, key, optional = "Secret", ref.Name, ref.Key, ref.Optional
+		default:
+			log.Warningf("Value of environment variable %q is not supported on Docker, the variable is not defined", env.Name)
+			continue
+		}
+		data, _, err := getResourceData(resources, kind, name)
+		if err != nil {
+			return nil, err
+		}
+		value, found := data[key]
+		if !found {
+			if optional != nil && *optional {
+				continue
+			}
+			return nil, fmt.Errorf("key %q of %s %q not found for environment variable %q", key, kind, name, env.Name)
+		}
+		result = append(result, env.Name+"="+value)
+	}
+	return result, nil
+}
+
+// getEnvFileContent returns the content of the file passed to `docker run --env-file` to define the environment variables env,
+// as returned by getEnv. The values of the variables cannot contain new lines in such a file
+func getEnvFileContent(env []string) (string, error) {
+	var content strings.Builder
+	for _, e := range env {
+		if strings.ContainsAny(e, "\r\n") {
+			name := strings.SplitN(e, "=", 2)[0]
+			return "", fmt.Errorf("value of environment variable %q contains a new line, which is not supported on Docker", name)
+		}
+		content.WriteString(e)
+		content.WriteString("\n")
+	}
+	return content.String(), nil
+}
+
+// writeEnvFile writes the content of an env file into a temporary file readable only by the user, and returns its path
+func writeEnvFile(content string) (string, error) {
+	f, err := os.CreateTemp("", "odo-docker-env-")
+	if err != nil {
+		return "", err
+	}
+	_, err = f.WriteString(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// redactArgs returns the arguments of a docker command, the values of the environment variables being redacted,
+// so the arguments can be logged
+func redactArgs(args []string) []string {
+	result := make([]string, len(args))
+	copy(result, args)
+	for i := 1; i < len(result); i++ {
+		if result[i-1] != "--env" && result[i-1] != "-e" {
+			continue
+		}
+		if name, _, found := strings.Cut(result[i], "="); found {
+			result[i] = name + "=<redacted>"
+		}
+	}
+	return result
+}
+
+// getResourceData returns the data of the ConfigMap or Secret with the given kind and name, from resources,
+// and false if no such resource is found. The values of the Secrets are decoded.
+func getResourceData(resources []unstructured.Unstructured, kind string, name string) (map[string]string, bool, error) {
+	for _, resource := range resources {
+		if resource.GetKind() != kind || resource.GetName() != name {
+			continue
+		}
+		data, _, err := unstructured.NestedStringMap(resource.Object, "data")
+		if err != nil {
+			return nil, false, err
+		}
+		if kind != "Secret" {
+			return data, true, nil
+		}
+		result := make(map[string]string, len(data))
+		for key, value := range data {
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return nil, false, fmt.Errorf("unable to decode key %q of Secret %q: %w", key, name, err)
+			}
+			result[key] = string(decoded)
+		}
+		stringData, _, err := unstructured.NestedStringMap(resource.Object, "stringData")
+		if err != nil {
+			return nil, false, err
+		}
+		for key, value := range stringData {
+			result[key] = value
+		}
+		return result, true, nil
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,400 @@
+package docker
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func getResource(kind string, name string, data map[string]interface{}) unstructured.Unstructured {
+	u := unstructured.Unstructured{Object: map[string]interface{}{
+		"data": data,
+	}}
+	u.SetAPIVersion("v1")
+	u.SetKind(kind)
+	u.SetName(name)
+	return u
+}
+
+func getPod() *corev1.Pod {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "runtime",
+					Image: "registry.access.redhat.com/ubi8/nodejs-16",
+					Ports: []corev1.ContainerPort{
+						{ContainerPort: 3000, HostPort: 40001},
+						{ContainerPort: 5858},
+					},
+				},
+				{
+					Name:  "tools",
+					Image: "quay.io/tools",
+					Ports: []corev1.ContainerPort{
+						{ContainerPort: 53, HostPort: 40002, Protocol: corev1.ProtocolUDP},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "odo-projects",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "odo-projects-mycmp-app"},
+					},
+				},
+				{
+					Name:         "cache",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				},
+				{
+					Name: "config",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "myconfig"}},
+					},
+				},
+			},
+		},
+	}
+	pod.SetName("mycmp-app")
+	pod.SetLabels(map[string]string{
+		"component":                  "mycmp",
+		"app.kubernetes.io/instance": "mycmp",
+	})
+	return pod
+}
+
+func Test_getVolumes(t *testing.T) {
+	want := map[string]string{
+		"odo-projects": "odo-projects-mycmp-app",
+		"cache":        "mycmp-app-cache",
+	}
+	got := getVolumes(getPod())
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("getVolumes() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_getInfraArgs(t *testing.T) {
+	want := []string{
+		"run", "--detach",
+		"--name", "mycmp-app",
+		"--label", "odo.dev/docker-pod=mycmp-app",
+		"--label", "odo.dev/docker-infra=true",
+		"--label", "app.kubernetes.io/instance=mycmp",
+		"--label", "component=mycmp",
+		"--publish", "127.0.0.1:40001:3000/tcp",
+		"--publish", "127.0.0.1:40002:53/udp",
+		infraImage,
+	}
+	got := getInfraArgs(getPod())
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("getInfraArgs() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_getContainerArgs(t *testing.T) {
+	prefix := []string{
+		"run", "--detach",
+		"--name", "mycmp-app-runtime",
+		"--label", "odo.dev/docker-pod=mycmp-app",
+		"--label", "odo.dev/docker-container=runtime",
+		"--network", "container:mycmp-app",
+	}
+	tests := []struct {
+		name      string
+		container func(*corev1.Container)
+		pod       func(*corev1.Pod)
+		envFile   string
+		want      []string
+	}{
+		{
+			name: "container with image only",
+			want: append(prefix, "--restart", "always", "registry.access.redhat.com/ubi8/nodejs-16"),
+		},
+		{
+			name: "container with command, args, working directory and limits",
+			container: func(container *corev1.Container) {
+				container.Command = []string{"tail", "-f"}
+				container.Args = []string{"/dev/null"}
+				container.WorkingDir = "/projects"
+				container.Resources.Limits = corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+				}
+			},
+			pod: func(pod *corev1.Pod) {
+				pod.Spec.RestartPolicy = corev1.RestartPolicyNever
+			},
+			want: append(prefix,
+				"--restart", "no",
+				"--workdir", "/projects",
+				"--memory", "1073741824",
+				"--cpus", "0.5",
+				"--entrypoint", "tail", "registry.access.redhat.com/ubi8/nodejs-16", "-f", "/dev/null",
+			),
+		},
+		{
+			name: "container with volumes and environment variables",
+			container: func(container *corev1.Container) {
+				container.VolumeMounts = []corev1.VolumeMount{
+					{Name: "odo-projects", MountPath: "/projects"},
+					{Name: "cache", MountPath: "/cache", ReadOnly: true},
+					{Name: "config", MountPath: "/config"},
+				}
+			},
+			envFile: "/tmp/odo-docker-env-1",
+			want: append(prefix,
+				"--restart", "always",
+				"--env-file", "/tmp/odo-docker-env-1",
+				"--volume", "odo-projects-mycmp-app:/projects",
+				"--volume", "mycmp-app-cache:/cache:ro",
+				"registry.access.redhat.com/ubi8/nodejs-16",
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := getPod()
+			if tt.pod != nil {
+				tt.pod(pod)
+			}
+			container := pod.Spec.Containers[0]
+			if tt.container != nil {
+				tt.container(&container)
+			}
+			got := getContainerArgs(pod, container, getVolumes(pod), tt.envFile)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("getContainerArgs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_getEnv(t *testing.T) {
+	optional := true
+	resources := []unstructured.Unstructured{
+		getResource("ConfigMap", "myconfig", map[string]interface{}{
+			"LOG_LEVEL": "debug",
+			"COLOR":     "blue",
+		}),
+		getResource("Secret", "mysecret", map[string]interface{}{
+			// "secret" encoded in base64
+			"PASSWORD": "c2VjcmV0",
+		}),
+	}
+	tests := []struct {
+		name      string
+		container corev1.Container
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name: "variables from ConfigMaps and Secrets, overridden by env",
+			container: corev1.Container{
+				EnvFrom: []corev1.EnvFromSource{
+					{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "myconfig"}}},
+					{Prefix: "DB_", SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "mysecret"}}},
+				},
+				Env: []corev1.EnvVar{
+					{Name: "LOG_LEVEL", Value: "info"},
+				},
+			},
+			want: []string{"COLOR=blue", "LOG_LEVEL=debug", "DB_PASSWORD=secret", "LOG_LEVEL=info"},
+		},
+		{
+			name: "variables referencing keys of ConfigMaps and Secrets",
+			container: corev1.Container{
+				Env: []corev1.EnvVar{
+					{Name: "COLOR", ValueFrom: &corev1.EnvVarSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "myconfig"}, Key: "COLOR"},
+					}},
+					{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "mysecret"}, Key: "PASSWORD"},
+					}},
+					{Name: "OPTIONAL", ValueFrom: &corev1.EnvVarSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "other"}, Key: "KEY", Optional: &optional},
+					}},
+				},
+			},
+			want: []string{"COLOR=blue", "PASSWORD=secret"},
+		},
+		{
+			name: "missing ConfigMap",
+			container: corev1.Container{
+				EnvFrom: []corev1.EnvFromSource{
+					{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "other"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing optional Secret",
+			container: corev1.Container{
+				EnvFrom: []corev1.EnvFromSource{
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "other"}, Optional: &optional}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getEnv(tt.container, resources)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getEnv() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("getEnv() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_getEnvFileContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "no variable",
+		},
+		{
+			name: "variables",
+			env:  []string{"COLOR=blue", "PASSWORD=a=b c"},
+			want: "COLOR=blue\nPASSWORD=a=b c\n",
+		},
+		{
+			name:    "value with a new line",
+			env:     []string{"CERT=line1\nline2"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getEnvFileContent(tt.env)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getEnvFileContent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getEnvFileContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_redactArgs(t *testing.T) {
+	args := []string{"run", "--env", "PASSWORD=secret", "-e", "TOKEN=abc", "--env", "HOME", "--name", "a=b"}
+	want := []string{"run", "--env", "PASSWORD=<redacted>", "-e", "TOKEN=<redacted>", "--env", "HOME", "--name", "a=b"}
+	if diff := cmp.Diff(want, redactArgs(args)); diff != "" {
+		t.Errorf("redactArgs() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDockerCli_PlayKube_env(t *testing.T) {
+	pod := getPod()
+	pod.Spec.Containers[0].Env = []corev1.EnvVar{
+		{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "mysecret"}, Key: "PASSWORD"},
+		}},
+	}
+	resources := []unstructured.Unstructured{
+		getResource("Secret", "mysecret", map[string]interface{}{
+			// "secret" encoded in base64
+			"PASSWORD": "c2VjcmV0",
+		}),
+	}
+
+	// contents of the env files, indexed by the names of the containers
+	envFiles := map[string]string{}
+	var envFilePaths []string
+	fakeRun(t, func(args []string) ([]byte, error) {
+		if strings.Contains(strings.Join(args, " "), "secret") {
+			t.Errorf("the value of a Secret is passed in the command line: %v", args)
+		}
+		for i := 1; i < len(args); i++ {
+			if args[i-1] != "--env-file" {
+				continue
+			}
+			info, err := os.Stat(args[i])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if perm := info.Mode().Perm(); perm != 0600 {
+				t.Errorf("permissions of the env file = %v, want %v", perm, os.FileMode(0600))
+			}
+			content, err := os.ReadFile(args[i])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			envFiles[args[3]] = string(content)
+			envFilePaths = append(envFilePaths, args[i])
+		}
+		return nil, nil
+	})
+
+	if err := NewDockerCli().PlayKube(pod, resources); err != nil {
+		t.Fatalf("PlayKube() unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"mycmp-app-runtime": "PASSWORD=secret\n",
+	}
+	if diff := cmp.Diff(want, envFiles); diff != "" {
+		t.Errorf("env files mismatch (-want +got):\n%s", diff)
+	}
+	for _, path := range envFilePaths {
+		if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("env file %s is not removed, stat error: %v", path, err)
+		}
+	}
+}
+
+func TestDockerCli_emptyDirVolumes(t *testing.T) {
+	pod := getPod()
+	pod.Spec.Containers = pod.Spec.Containers[:1]
+
+	var commands []string
+	fakeRun(t, func(args []string) ([]byte, error) {
+		command := strings.Join(args, " ")
+		commands = append(commands, command)
+		switch {
+		case args[0] == "ps":
+			return []byte("infra1\nruntime1\n"), nil
+		case strings.HasPrefix(command, "volume ls"):
+			return []byte("mycmp-app-cache\n"), nil
+		}
+		return nil, nil
+	})
+
+	o := NewDockerCli()
+	if err := o.PlayKube(pod, nil); err != nil {
+		t.Fatalf("PlayKube() unexpected error: %v", err)
+	}
+	if err := o.PodRm(pod.GetName()); err != nil {
+		t.Fatalf("PodRm() unexpected error: %v", err)
+	}
+
+	want := []string{
+		"volume create --label odo.dev/docker-pod=mycmp-app mycmp-app-cache",
+		strings.Join(getInfraArgs(pod), " "),
+		strings.Join(getContainerArgs(pod, pod.Spec.Containers[0], getVolumes(pod), ""), " "),
+		"ps --all --quiet --no-trunc --filter label=odo.dev/docker-pod=mycmp-app",
+		"rm --force --volumes infra1 runtime1",
+		"volume ls --quiet --filter label=odo.dev/docker-pod=mycmp-app",
+		"volume rm mycmp-app-cache",
+	}
+	if diff := cmp.Diff(want, commands); diff != "" {
+		t.Errorf("docker commands mismatch (-want +got):\n%s", diff)
+	}
+}
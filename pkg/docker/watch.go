@@ -0,0 +1,59 @@
+package docker
+
+import (
+	"context"
+	"io"
+	"os/exec"
+
+	"github.com/redhat-developer/odo/pkg/podman"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// DockerEvents are the events reported by Docker on containers which can change the state of a pod.
+// Other events (exec, attach, etc) are ignored
+var DockerEvents = map[string]bool{
+	"create":  true,
+	"start":   true,
+	"restart": true,
+	"die":     true,
+	"oom":     true,
+	"kill":    true,
+	"stop":    true,
+	"pause":   true,
+	"unpause": true,
+	"destroy": true,
+}
+
+func (o *DockerCli) PodWatcher(ctx context.Context, selector string) (watch.Interface, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	cmd := exec.CommandContext(ctx, "docker", "events", "--format", "{{json .}}", "--filter", "type=container", "--filter", "label="+podLabel)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if err = cmd.Start(); err != nil {
+		cancel()
+		return nil, err
+	}
+	events := &cmdReadCloser{
+		ReadCloser: stdout,
+		cmd:        cmd,
+	}
+	return podman.WatchPods(ctx, cancel, events, DockerEvents, func() (*corev1.PodList, error) {
+		return o.GetPodsMatchingSelector(selector)
+	}), nil
+}
+
+// cmdReadCloser reads the output of a command, and waits for the command to terminate when closed
+type cmdReadCloser struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+func (o *cmdReadCloser) Close() error {
+	_ = o.ReadCloser.Close()
+	return o.cmd.Wait()
+}
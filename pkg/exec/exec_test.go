@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
@@ -27,6 +28,10 @@ func (o fakePlatform) GetPodLogs(podName, containerName string, followLog bool)
 	panic("not implemented yet")
 }
 
+func (o fakePlatform) FollowPodLogsSince(podName, containerName string, since time.Time) (io.ReadCloser, error) {
+	panic("not implemented yet")
+}
+
 func (o fakePlatform) GetPodsMatchingSelector(selector string) (*corev1.PodList, error) {
 	panic("not implemented yet")
 }
@@ -114,6 +114,7 @@ type ClientInterface interface {
 	GetPodUsingComponentName(componentName string) (*corev1.Pod, error)
 	GetRunningPodFromSelector(selector string) (*corev1.Pod, error)
 	GetPodLogs(podName, containerName string, followLog bool) (io.ReadCloser, error)
+	FollowPodLogsSince(podName, containerName string, since time.Time) (io.ReadCloser, error)
 	GetAllPodsInNamespaceMatchingSelector(selector string, ns string) (*corev1.PodList, error)
 	GetPodsMatchingSelector(selector string) (*corev1.PodList, error)
 	PodWatcher(ctx context.Context, selector string) (watch.Interface, error)
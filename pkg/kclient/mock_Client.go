@@ -756,6 +756,21 @@ func (mr *MockClientInterfaceMockRecorder) GetPVCFromName(pvcName interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVCFromName", reflect.TypeOf((*MockClientInterface)(nil).GetPVCFromName), pvcName)
 }
 
+// FollowPodLogsSince mocks base method.
+func (m *MockClientInterface) FollowPodLogsSince(podName, containerName string, since time.Time) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowPodLogsSince", podName, containerName, since)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FollowPodLogsSince indicates an expected call of FollowPodLogsSince.
+func (mr *MockClientInterfaceMockRecorder) FollowPodLogsSince(podName, containerName, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowPodLogsSince", reflect.TypeOf((*MockClientInterface)(nil).FollowPodLogsSince), podName, containerName, since)
+}
+
 // GetPodLogs mocks base method.
 func (m *MockClientInterface) GetPodLogs(podName, containerName string, followLog bool) (io.ReadCloser, error) {
 	m.ctrl.T.Helper()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	// api resource types
 
@@ -122,6 +123,27 @@ func (c *Client) GetPodLogs(podName, containerName string, followLog bool) (io.R
 	return rd, err
 }
 
+// FollowPodLogsSince follows the logs of the pod container emitted since the given time, or all its logs if since is zero,
+// each line being prefixed with its timestamp
+func (c *Client) FollowPodLogsSince(podName, containerName string, since time.Time) (io.ReadCloser, error) {
+	podLogOptions := corev1.PodLogOptions{
+		Follow:     true,
+		Container:  containerName,
+		Timestamps: true,
+	}
+	if !since.IsZero() {
+		podLogOptions.SinceTime = &metav1.Time{Time: since}
+	}
+
+	return c.KubeClient.CoreV1().RESTClient().Get().
+		Namespace(c.Namespace).
+		Name(podName).
+		Resource("pods").
+		SubResource("log").
+		VersionedParams(&podLogOptions, scheme.ParameterCodec).
+		Stream(context.TODO())
+}
+
 func (c *Client) GetAllPodsInNamespaceMatchingSelector(selector string, ns string) (*corev1.PodList, error) {
 	podList, err := c.KubeClient.CoreV1().Pods(c.Namespace).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
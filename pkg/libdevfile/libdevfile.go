@@ -2,6 +2,7 @@ package libdevfile
 
 import (
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
@@ -204,6 +205,60 @@ func ValidateAndGetPushCommands(
 	return commandMap, nil
 }
 
+// GetPushCommands validates and returns the build and run commands, as ValidateAndGetPushCommands does,
+// and the debug command if debug is true. The debug command is defined by debugCommand if not empty, or is the default one.
+func GetPushCommands(
+	devfileObj parser.DevfileObj,
+	buildCommand string,
+	runCommand string,
+	debug bool,
+	debugCommand string,
+) (map[v1alpha2.CommandGroupKind]v1alpha2.Command, error) {
+	pushDevfileCommands, err := ValidateAndGetPushCommands(devfileObj, buildCommand, runCommand)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate devfile build and run commands: %w", err)
+	}
+
+	if debug {
+		debugCmd, err := ValidateAndGetCommand(devfileObj, debugCommand, v1alpha2.DebugCommandGroupKind)
+		if err != nil {
+			return nil, fmt.Errorf("debug command is not valid: %w", err)
+		}
+		pushDevfileCommands[v1alpha2.DebugCommandGroupKind] = debugCmd
+	}
+
+	return pushDevfileCommands, nil
+}
+
+// pushPathAttributePrefix is the prefix of the command attributes defining the destination of local files and directories
+// in the container, as "dev.odo.push.path:<local path>": "<container path>"
+const pushPathAttributePrefix = "dev.odo.push.path:"
+
+// GetSyncFilesFromAttributes gets the target files and folders along with their respective remote destination,
+// from the "dev.odo.push.path" attributes of the build, run and debug commands in commands.
+// If the same local path is defined by several commands, the destination defined by the run command takes precedence
+// over the one of the build command, and the one defined by the debug command over both.
+func GetSyncFilesFromAttributes(commands map[v1alpha2.CommandGroupKind]v1alpha2.Command) map[string]string {
+	syncMap := make(map[string]string)
+	for _, kind := range []v1alpha2.CommandGroupKind{
+		v1alpha2.BuildCommandGroupKind,
+		v1alpha2.RunCommandGroupKind,
+		v1alpha2.DebugCommandGroupKind,
+	} {
+		command, ok := commands[kind]
+		if !ok {
+			continue
+		}
+		for key, value := range command.Attributes.Strings(nil) {
+			if strings.HasPrefix(key, pushPathAttributePrefix) {
+				localValue := strings.TrimPrefix(key, pushPathAttributePrefix)
+				syncMap[filepath.Clean(localValue)] = filepath.ToSlash(filepath.Clean(value))
+			}
+		}
+	}
+	return syncMap
+}
+
 func HasPostStartEvents(devfileObj parser.DevfileObj) bool {
 	postStartEvents := devfileObj.Data.GetEvents().PostStart
 	return len(postStartEvents) > 0
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
 	devfilepkg "github.com/devfile/api/v2/pkg/devfile"
 	"github.com/devfile/library/pkg/devfile/parser"
 	"github.com/devfile/library/pkg/devfile/parser/data"
@@ -1786,6 +1787,74 @@ func TestGetContainerComponentsForCommand(t *testing.T) {
 	}
 }
 
+func TestGetSyncFilesFromAttributes(t *testing.T) {
+	withPushPaths := func(cmd v1alpha2.Command, paths map[string]string) v1alpha2.Command {
+		cmd.Attributes = attributes.Attributes{}
+		for local, remote := range paths {
+			cmd.Attributes = cmd.Attributes.PutString("dev.odo.push.path:"+local, remote)
+		}
+		return cmd
+	}
+	tests := []struct {
+		name     string
+		commands map[v1alpha2.CommandGroupKind]v1alpha2.Command
+		want     map[string]string
+	}{
+		{
+			name: "no attributes",
+			commands: map[v1alpha2.CommandGroupKind]v1alpha2.Command{
+				runGroup: getExecCommand("run", runGroup),
+			},
+			want: map[string]string{},
+		},
+		{
+			name: "attributes of the run command",
+			commands: map[v1alpha2.CommandGroupKind]v1alpha2.Command{
+				runGroup: withPushPaths(getExecCommand("run", runGroup), map[string]string{
+					"server.js":       "bin/server.js",
+					"./test/../other": "/apps/other/",
+				}),
+				v1alpha2.DeployCommandGroupKind: withPushPaths(getExecCommand("deploy", v1alpha2.DeployCommandGroupKind), map[string]string{
+					"ignored": "ignored",
+				}),
+			},
+			want: map[string]string{
+				"server.js": "bin/server.js",
+				"other":     "/apps/other",
+			},
+		},
+		{
+			name: "attributes of the build, run and debug commands",
+			commands: map[v1alpha2.CommandGroupKind]v1alpha2.Command{
+				buildGroup: withPushPaths(getExecCommand("build", buildGroup), map[string]string{
+					"package.json": "package.json",
+					"server.js":    "build/server.js",
+				}),
+				runGroup: withPushPaths(getExecCommand("run", runGroup), map[string]string{
+					"server.js": "run/server.js",
+					"debug.js":  "run/debug.js",
+				}),
+				v1alpha2.DebugCommandGroupKind: withPushPaths(getExecCommand("debug", v1alpha2.DebugCommandGroupKind), map[string]string{
+					"debug.js": "debug/debug.js",
+				}),
+			},
+			want: map[string]string{
+				"package.json": "package.json",
+				"server.js":    "run/server.js",
+				"debug.js":     "debug/debug.js",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetSyncFilesFromAttributes(tt.commands)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetSyncFilesFromAttributes() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func getExecCommand(id string, group v1alpha2.CommandGroupKind) v1alpha2.Command {
 	if len(id) == 0 {
 		id = fmt.Sprintf("%s-%s", "cmd", dfutil.GenerateRandomString(10))
@@ -1811,3 +1880,97 @@ func getExecCommand(id string, group v1alpha2.CommandGroupKind) v1alpha2.Command
 	}
 
 }
+
+func TestGetPushCommands(t *testing.T) {
+	component := "alias1"
+	command := func(id string, kind v1alpha2.CommandGroupKind) v1alpha2.Command {
+		return v1alpha2.Command{
+			Id: id,
+			CommandUnion: v1alpha2.CommandUnion{
+				Exec: &v1alpha2.ExecCommand{
+					LabeledCommand: v1alpha2.LabeledCommand{
+						BaseCommand: v1alpha2.BaseCommand{
+							Group: &v1alpha2.CommandGroup{Kind: kind, IsDefault: util.GetBoolPtr(true)},
+						},
+					},
+					CommandLine: "ls -la",
+					Component:   component,
+				},
+			},
+		}
+	}
+	devfileData, err := data.NewDevfileData(string(data.APISchemaVersion200))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = devfileData.AddCommands([]v1alpha2.Command{
+		command("build", buildGroup),
+		command("run", runGroup),
+		command("debug", v1alpha2.DebugCommandGroupKind),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = devfileData.AddComponents([]v1alpha2.Component{testingutil.GetFakeContainerComponent(component)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	devObj := parser.DevfileObj{Data: devfileData}
+
+	tests := []struct {
+		name         string
+		debug        bool
+		debugCommand string
+		wantIds      map[v1alpha2.CommandGroupKind]string
+		wantErr      bool
+	}{
+		{
+			name: "build and run commands",
+			wantIds: map[v1alpha2.CommandGroupKind]string{
+				buildGroup: "build",
+				runGroup:   "run",
+			},
+		},
+		{
+			name:  "build, run and debug commands",
+			debug: true,
+			wantIds: map[v1alpha2.CommandGroupKind]string{
+				buildGroup:                     "build",
+				runGroup:                       "run",
+				v1alpha2.DebugCommandGroupKind: "debug",
+			},
+		},
+		{
+			name:         "debug command not found",
+			debug:        true,
+			debugCommand: "other",
+			wantErr:      true,
+		},
+		{
+			name:         "debug command ignored when not debugging",
+			debugCommand: "other",
+			wantIds: map[v1alpha2.CommandGroupKind]string{
+				buildGroup: "build",
+				runGroup:   "run",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetPushCommands(devObj, "", "", tt.debug, tt.debugCommand)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPushCommands() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			gotIds := map[v1alpha2.CommandGroupKind]string{}
+			for kind, cmd := range got {
+				gotIds[kind] = cmd.Id
+			}
+			if diff := cmp.Diff(tt.wantIds, gotIds); diff != "" {
+				t.Errorf("GetPushCommands() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -79,6 +79,7 @@ func (o *LogsClient) getLogsForMode(
 					containerLogs, err := o.platformClient.GetPodLogs(pod.Name, container.Name, follow)
 					if err != nil {
 						events.Err <- fmt.Errorf("failed to get logs for container %s; error: %v", container.Name, err)
+						continue
 					}
 					events.Logs <- ContainerLogs{container.Name, containerLogs}
 				}
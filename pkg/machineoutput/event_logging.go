@@ -7,8 +7,10 @@ import (
 	"io"
 	"time"
 
+	"github.com/redhat-developer/odo/pkg/api"
 	"github.com/redhat-developer/odo/pkg/log"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog"
 )
 
@@ -85,6 +87,16 @@ func (c *NoOpMachineEventLoggingClient) KubernetesPodStatus(pods []KubernetesPod
 
 }
 
+// FilesSynced ignores the provided event.
+func (c *NoOpMachineEventLoggingClient) FilesSynced(files int, bytes int64, transferredBytes int64, compression string, duration time.Duration, timestamp string) {
+}
+
+// PortsForwarded ignores the provided event.
+func (c *NoOpMachineEventLoggingClient) PortsForwarded(ports []api.ForwardedPort, timestamp string) {}
+
+// Cleanup ignores the provided event.
+func (c *NoOpMachineEventLoggingClient) Cleanup(errorVal error, timestamp string) {}
+
 // NewConsoleMachineEventLoggingClient creates a new instance of ConsoleMachineEventLoggingClient,
 // which will output events as JSON to the console.
 func NewConsoleMachineEventLoggingClient() *ConsoleMachineEventLoggingClient {
@@ -127,7 +139,8 @@ func (c *ConsoleMachineEventLoggingClient) DevFileCommandExecutionComplete(comma
 				GroupKind:        groupKind,
 				AbstractLogEvent: AbstractLogEvent{Timestamp: timestamp},
 			},
-			Error: errorStr,
+			ExitCode: getExitCode(errorVal),
+			Error:    errorStr,
 		},
 	}
 
@@ -203,6 +216,50 @@ func (c *ConsoleMachineEventLoggingClient) KubernetesPodStatus(pods []Kubernetes
 	c.outputJSON(json)
 }
 
+// FilesSynced outputs the provided event as JSON to the console.
+func (c *ConsoleMachineEventLoggingClient) FilesSynced(files int, bytes int64, transferredBytes int64, compression string, duration time.Duration, timestamp string) {
+	json := MachineEventWrapper{
+		FilesSynced: &FilesSynced{
+			Files:            files,
+			Bytes:            bytes,
+			TransferredBytes: transferredBytes,
+			Compression:      compression,
+			DurationMillis:   duration.Milliseconds(),
+			AbstractLogEvent: AbstractLogEvent{Timestamp: timestamp},
+		},
+	}
+	c.outputJSON(json)
+}
+
+// PortsForwarded outputs the provided event as JSON to the console.
+func (c *ConsoleMachineEventLoggingClient) PortsForwarded(ports []api.ForwardedPort, timestamp string) {
+	if ports == nil {
+		ports = []api.ForwardedPort{}
+	}
+	json := MachineEventWrapper{
+		PortsForwarded: &PortsForwarded{
+			Ports:            ports,
+			AbstractLogEvent: AbstractLogEvent{Timestamp: timestamp},
+		},
+	}
+	c.outputJSON(json)
+}
+
+// Cleanup outputs the provided event as JSON to the console.
+func (c *ConsoleMachineEventLoggingClient) Cleanup(errorVal error, timestamp string) {
+	errorStr := ""
+	if errorVal != nil {
+		errorStr = errorVal.Error()
+	}
+	json := MachineEventWrapper{
+		Cleanup: &Cleanup{
+			Error:            errorStr,
+			AbstractLogEvent: AbstractLogEvent{Timestamp: timestamp},
+		},
+	}
+	c.outputJSON(json)
+}
+
 func (c *ConsoleMachineEventLoggingClient) outputJSON(machineOutput MachineEventWrapper) {
 
 	if c.logFunc != nil {
@@ -244,6 +301,18 @@ func (w MachineEventWrapper) GetEntry() (MachineEventLogEntry, error) {
 		return w.URLReachable, nil
 	}
 
+	if w.FilesSynced != nil {
+		return w.FilesSynced, nil
+	}
+
+	if w.PortsForwarded != nil {
+		return w.PortsForwarded, nil
+	}
+
+	if w.Cleanup != nil {
+		return w.Cleanup, nil
+	}
+
 	return nil, errors.New("unexpected machine event log entry")
 }
 
@@ -275,6 +344,48 @@ func (c URLReachable) GetType() MachineEventLogEntryType { return TypeURLReachab
 // GetType returns the event type for this event.
 func (c KubernetesPodStatus) GetType() MachineEventLogEntryType { return TypeKubernetesPodStatus }
 
+// GetType returns the event type for this event.
+func (c FilesSynced) GetType() MachineEventLogEntryType { return TypeFilesSynced }
+
+// GetType returns the event type for this event.
+func (c PortsForwarded) GetType() MachineEventLogEntryType { return TypePortsForwarded }
+
+// GetType returns the event type for this event.
+func (c Cleanup) GetType() MachineEventLogEntryType { return TypeCleanup }
+
+// NewKubernetesPodStatusEntry returns the status entry of pod
+func NewKubernetesPodStatusEntry(pod *corev1.Pod) KubernetesPodStatusEntry {
+	entry := KubernetesPodStatusEntry{
+		Name:           pod.GetName(),
+		UID:            string(pod.GetUID()),
+		Phase:          string(pod.Status.Phase),
+		Labels:         pod.GetLabels(),
+		Containers:     pod.Status.ContainerStatuses,
+		InitContainers: pod.Status.InitContainerStatuses,
+	}
+	if pod.Status.StartTime != nil {
+		entry.StartTime = FormatTime(pod.Status.StartTime.Time)
+	}
+	return entry
+}
+
+// getExitCode returns the exit status of the command which returned err, 0 if err is nil,
+// or -1 if the exit status cannot be determined from err
+func getExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitStatusErr interface{ ExitStatus() int }
+	if errors.As(err, &exitStatusErr) {
+		return exitStatusErr.ExitStatus()
+	}
+	var exitCodeErr interface{ ExitCode() int }
+	if errors.As(err, &exitCodeErr) {
+		return exitCodeErr.ExitCode()
+	}
+	return -1
+}
+
 // MachineEventLogEntryType indicates the machine-readable event type from an ODO operation
 type MachineEventLogEntryType int
 
@@ -293,6 +404,12 @@ const (
 	TypeURLReachable MachineEventLogEntryType = 6
 	// TypeKubernetesPodStatus is the entry type for that event.
 	TypeKubernetesPodStatus MachineEventLogEntryType = 7
+	// TypeFilesSynced is the entry type for that event.
+	TypeFilesSynced MachineEventLogEntryType = 8
+	// TypePortsForwarded is the entry type for that event.
+	TypePortsForwarded MachineEventLogEntryType = 9
+	// TypeCleanup is the entry type for that event.
+	TypeCleanup MachineEventLogEntryType = 10
 )
 
 // createWriterAndChannel is similar to the exec.CreateConsoleOutputWriterAndChannel(); see that function's comment for details.
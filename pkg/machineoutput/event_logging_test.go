@@ -0,0 +1,149 @@
+package machineoutput
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/redhat-developer/odo/pkg/api"
+)
+
+// exitError is an error returned by a command exiting with a non-zero status
+type exitError int
+
+func (o exitError) Error() string {
+	return fmt.Sprintf("command terminated with exit code %d", int(o))
+}
+
+func (o exitError) ExitStatus() int {
+	return int(o)
+}
+
+func TestConsoleMachineEventLoggingClient(t *testing.T) {
+	fwPort := api.ForwardedPort{
+		ContainerName: "runtime",
+		LocalAddress:  "127.0.0.1",
+		LocalPort:     40001,
+		ContainerPort: 3000,
+	}
+
+	tests := []struct {
+		name     string
+		log      func(c MachineEventLoggingClient)
+		wantJSON string
+	}{
+		{
+			name: "command completed successfully",
+			log: func(c MachineEventLoggingClient) {
+				c.DevFileCommandExecutionComplete("build", "runtime", "npm install", "build", "1.0", nil)
+			},
+			wantJSON: `{"devFileCommandExecutionComplete":{"commandId":"build","componentName":"runtime","commandLine":"npm install",` +
+				`"groupKind":"build","timestamp":"1.0","exitCode":0}}`,
+		},
+		{
+			name: "command failed with an exit status",
+			log: func(c MachineEventLoggingClient) {
+				c.DevFileCommandExecutionComplete("build", "runtime", "npm install", "build", "1.0", fmt.Errorf("error executing command: %w", exitError(2)))
+			},
+			wantJSON: `{"devFileCommandExecutionComplete":{"commandId":"build","componentName":"runtime","commandLine":"npm install",` +
+				`"groupKind":"build","timestamp":"1.0","exitCode":2,"error":"error executing command: command terminated with exit code 2"}}`,
+		},
+		{
+			name: "command failed with an unknown status",
+			log: func(c MachineEventLoggingClient) {
+				c.DevFileCommandExecutionComplete("build", "runtime", "npm install", "build", "1.0", errors.New("pod not found"))
+			},
+			wantJSON: `{"devFileCommandExecutionComplete":{"commandId":"build","componentName":"runtime","commandLine":"npm install",` +
+				`"groupKind":"build","timestamp":"1.0","exitCode":-1,"error":"pod not found"}}`,
+		},
+		{
+			name: "files synced",
+			log: func(c MachineEventLoggingClient) {
+				c.FilesSynced(3, 2048, 512, "zstd", 1500*time.Millisecond, "1.0")
+			},
+			wantJSON: `{"filesSynced":{"files":3,"bytes":2048,"transferredBytes":512,"compression":"zstd","durationMillis":1500,"timestamp":"1.0"}}`,
+		},
+		{
+			name: "ports forwarded",
+			log: func(c MachineEventLoggingClient) {
+				c.PortsForwarded([]api.ForwardedPort{fwPort}, "1.0")
+			},
+			wantJSON: `{"portsForwarded":{"ports":[{"containerName":"runtime","localAddress":"127.0.0.1","localPort":40001,"containerPort":3000}],"timestamp":"1.0"}}`,
+		},
+		{
+			name: "no port forwarded",
+			log: func(c MachineEventLoggingClient) {
+				c.PortsForwarded(nil, "1.0")
+			},
+			wantJSON: `{"portsForwarded":{"ports":[],"timestamp":"1.0"}}`,
+		},
+		{
+			name: "cleanup",
+			log: func(c MachineEventLoggingClient) {
+				c.Cleanup(nil, "1.0")
+			},
+			wantJSON: `{"cleanup":{"timestamp":"1.0"}}`,
+		},
+		{
+			name: "cleanup failed",
+			log: func(c MachineEventLoggingClient) {
+				c.Cleanup(errors.New("unauthorized"), "1.0")
+			},
+			wantJSON: `{"cleanup":{"error":"unauthorized","timestamp":"1.0"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			c := &ConsoleMachineEventLoggingClient{
+				logFunc: func(machineOutput MachineEventWrapper) {
+					if _, err := machineOutput.GetEntry(); err != nil {
+						t.Errorf("unexpected error: %v", err)
+					}
+					jsonContent, err := json.Marshal(machineOutput)
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					got = append(got, string(jsonContent))
+				},
+			}
+			tt.log(c)
+			if diff := cmp.Diff([]string{tt.wantJSON}, got); diff != "" {
+				t.Errorf("output mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewKubernetesPodStatusEntry(t *testing.T) {
+	startTime := metav1.NewTime(time.Unix(1600000000, 0))
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "mycomponent-app",
+			UID:    "uid",
+			Labels: map[string]string{"component": "mycomponent"},
+		},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			StartTime:         &startTime,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "runtime", Ready: true}},
+		},
+	}
+	want := KubernetesPodStatusEntry{
+		Name:       "mycomponent-app",
+		UID:        "uid",
+		Phase:      "Running",
+		Labels:     map[string]string{"component": "mycomponent"},
+		StartTime:  "1600000000.000000",
+		Containers: []corev1.ContainerStatus{{Name: "runtime", Ready: true}},
+	}
+	if diff := cmp.Diff(want, NewKubernetesPodStatusEntry(pod)); diff != "" {
+		t.Errorf("NewKubernetesPodStatusEntry() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -2,8 +2,11 @@ package machineoutput
 
 import (
 	"io"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/redhat-developer/odo/pkg/api"
 )
 
 // MachineEventLoggingClient is an interface which is used by consuming code to output machine-readable
@@ -22,6 +25,12 @@ type MachineEventLoggingClient interface {
 
 	KubernetesPodStatus(pods []KubernetesPodStatusEntry, timestamp string)
 
+	FilesSynced(files int, bytes int64, transferredBytes int64, compression string, duration time.Duration, timestamp string)
+
+	PortsForwarded(ports []api.ForwardedPort, timestamp string)
+
+	Cleanup(errorVal error, timestamp string)
+
 	// CreateContainerOutputWriter is used to capture output from container processes, and synchronously write it to the screen as LogText. See implementation comments for details.
 	CreateContainerOutputWriter() (*io.PipeWriter, chan interface{}, *io.PipeWriter, chan interface{})
 }
@@ -36,6 +45,9 @@ type MachineEventWrapper struct {
 	ContainerStatus                 *ContainerStatus                 `json:"containerStatus,omitempty"`
 	URLReachable                    *URLReachable                    `json:"urlReachable,omitempty"`
 	KubernetesPodStatus             *KubernetesPodStatus             `json:"kubernetesPodStatus,omitempty"`
+	FilesSynced                     *FilesSynced                     `json:"filesSynced,omitempty"`
+	PortsForwarded                  *PortsForwarded                  `json:"portsForwarded,omitempty"`
+	Cleanup                         *Cleanup                         `json:"cleanup,omitempty"`
 }
 
 // DevFileCommandExecutionBegin is the JSON event that is emitted when a dev file command begins execution.
@@ -50,7 +62,9 @@ type DevFileCommandExecutionBegin struct {
 // DevFileCommandExecutionComplete is the JSON event that is emitted when a dev file command completes execution.
 type DevFileCommandExecutionComplete struct {
 	DevFileCommandExecutionBegin
-	Error string `json:"error,omitempty"`
+	// ExitCode is the exit status of the command, 0 if the command succeeded, -1 if the command failed with an unknown status
+	ExitCode int    `json:"exitCode"`
+	Error    string `json:"error,omitempty"`
 }
 
 // ReportError is the JSON event that is emitted when an error occurs during push command
@@ -110,6 +124,32 @@ type KubernetesPodStatusEntry struct {
 	// vast majority are useful.
 }
 
+// FilesSynced is the JSON event that is emitted when the local files have been synchronized into the containers of the component
+type FilesSynced struct {
+	// Files is the number of files and directories transferred
+	Files int `json:"files"`
+	// Bytes is the size of the files transferred, before compression
+	Bytes int64 `json:"bytes"`
+	// TransferredBytes is the size of the data sent to the containers, after compression
+	TransferredBytes int64 `json:"transferredBytes"`
+	// Compression is the name of the compression format used, or empty if the files are not compressed
+	Compression    string `json:"compression,omitempty"`
+	DurationMillis int64  `json:"durationMillis"`
+	AbstractLogEvent
+}
+
+// PortsForwarded is the JSON event that is emitted when the ports of the containers have been forwarded to local ports
+type PortsForwarded struct {
+	Ports []api.ForwardedPort `json:"ports"`
+	AbstractLogEvent
+}
+
+// Cleanup is the JSON event that is emitted when the resources of the component have been deleted, at the end of odo dev
+type Cleanup struct {
+	Error string `json:"error,omitempty"`
+	AbstractLogEvent
+}
+
 // AbstractLogEvent is the base struct for all events; all events must at a minimum contain a timestamp.
 type AbstractLogEvent struct {
 	Timestamp string `json:"timestamp"`
@@ -123,6 +163,9 @@ var _ MachineEventLogEntry = &ReportError{}
 var _ MachineEventLogEntry = &ContainerStatus{}
 var _ MachineEventLogEntry = &URLReachable{}
 var _ MachineEventLogEntry = &KubernetesPodStatus{}
+var _ MachineEventLogEntry = &FilesSynced{}
+var _ MachineEventLogEntry = &PortsForwarded{}
+var _ MachineEventLogEntry = &Cleanup{}
 
 // MachineEventLogEntry contains the expected methods for every event that is emitted.
 // (This is mainly used for test purposes.)
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog"
 	ktemplates "k8s.io/kubectl/pkg/util/templates"
@@ -19,6 +20,8 @@ import (
 	"github.com/redhat-developer/odo/pkg/odo/cli/files"
 	"github.com/redhat-developer/odo/pkg/odo/cli/ui"
 	"github.com/redhat-developer/odo/pkg/odo/cmdline"
+	"github.com/redhat-developer/odo/pkg/odo/commonflags"
+	fcontext "github.com/redhat-developer/odo/pkg/odo/commonflags/context"
 	odocontext "github.com/redhat-developer/odo/pkg/odo/context"
 	"github.com/redhat-developer/odo/pkg/odo/genericclioptions"
 	"github.com/redhat-developer/odo/pkg/odo/genericclioptions/clientset"
@@ -37,6 +40,12 @@ var deleteExample = ktemplates.Examples(`
 
 # Delete the component named 'frontend' in the 'myproject' namespace from the cluster
 %[1]s --name frontend --namespace myproject
+
+# Delete the component named 'frontend' from Podman (experimental)
+%[1]s --name frontend --run-on podman
+
+# Delete the component named 'frontend' from Docker (experimental)
+%[1]s --name frontend --run-on docker
 `)
 
 type ComponentOptions struct {
@@ -55,6 +64,9 @@ type ComponentOptions struct {
 	// waitFlag waits for deletion of all resources
 	waitFlag bool
 
+	// runOn is the platform on which to find the component to delete
+	runOn string
+
 	// Clients
 	clientset *clientset.Clientset
 }
@@ -71,6 +83,11 @@ func (o *ComponentOptions) SetClientset(clientset *clientset.Clientset) {
 }
 
 func (o *ComponentOptions) Complete(ctx context.Context, cmdline cmdline.Cmdline, args []string) (err error) {
+	o.runOn = fcontext.GetRunOn(ctx)
+	if o.runOn == commonflags.RunOnCluster && o.clientset.KubernetesClient == nil {
+		return errors.New("no connection to cluster defined")
+	}
+
 	// 1. Name is not passed, and odo has access to devfile.yaml; Name is not passed so we assume that odo has access to the devfile.yaml
 	if o.name == "" {
 		devfileObj := odocontext.GetDevfileObj(ctx)
@@ -80,6 +97,9 @@ func (o *ComponentOptions) Complete(ctx context.Context, cmdline cmdline.Cmdline
 		return nil
 	}
 	// 2. Name is passed, and odo does not have access to devfile.yaml; if Name is passed, then we assume that odo does not have access to the devfile.yaml
+	if o.runOn == commonflags.RunOnPodman || o.runOn == commonflags.RunOnDocker {
+		return nil
+	}
 	if o.namespace != "" {
 		o.clientset.KubernetesClient.SetNamespace(o.namespace)
 	} else {
@@ -92,10 +112,19 @@ func (o *ComponentOptions) Validate(ctx context.Context) error {
 	if o.withFilesFlag && o.name != "" {
 		return errors.New("'--files' cannot be used with '--name'; '--files' must be used from a directory containing a Devfile")
 	}
+	if (o.runOn == commonflags.RunOnPodman || o.runOn == commonflags.RunOnDocker) && o.namespace != "" {
+		return fmt.Errorf("'--namespace' cannot be used with '--run-on %s'", o.runOn)
+	}
 	return nil
 }
 
 func (o *ComponentOptions) Run(ctx context.Context) error {
+	if o.runOn == commonflags.RunOnPodman || o.runOn == commonflags.RunOnDocker {
+		if o.name != "" {
+			return o.deleteNamedPodmanComponent(ctx)
+		}
+		return o.deleteDevfilePodmanComponent(ctx)
+	}
 	if o.name != "" {
 		return o.deleteNamedComponent(ctx)
 	}
@@ -200,18 +229,7 @@ func (o *ComponentOptions) deleteDevfileComponent(ctx context.Context) error {
 		}
 
 		if o.withFilesFlag {
-			//Delete files
-			remainingFiles := o.deleteFilesCreatedByOdo(o.clientset.FS, filesToDelete)
-			var listOfFiles []string
-			for f, e := range remainingFiles {
-				log.Warningf("Failed to delete file or directory: %s: %v\n", f, e)
-				listOfFiles = append(listOfFiles, "\t- "+f)
-			}
-			if len(remainingFiles) != 0 {
-				log.Printf("There are still files or directories that could not be deleted.")
-				fmt.Println(strings.Join(listOfFiles, "\n"))
-				log.Info("You need to manually delete those.")
-			}
+			o.deleteFiles(filesToDelete)
 		}
 
 		return nil
@@ -222,6 +240,95 @@ func (o *ComponentOptions) deleteDevfileComponent(ctx context.Context) error {
 	return nil
 }
 
+// deleteNamedPodmanComponent deletes a component running on Podman given its name
+func (o *ComponentOptions) deleteNamedPodmanComponent(ctx context.Context) error {
+	log.Info("Searching resources to delete, please wait...")
+	pods, err := o.clientset.DeleteClient.ListPodmanResourcesToDelete(odocontext.GetApplication(ctx), o.name)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		log.Infof("No resource found for component %q on %s\n", o.name, o.runOn)
+		return nil
+	}
+	printPodmanResources(o.runOn, o.name, pods)
+	if o.forceFlag || ui.Proceed("Are you sure you want to delete these resources?") {
+		o.deletePodmanResources(pods)
+		log.Infof("The component %q is successfully deleted from %s", o.name, o.runOn)
+		return nil
+	}
+
+	log.Error("Aborting deletion of component")
+	return nil
+}
+
+// deleteDevfilePodmanComponent deletes the component defined by the devfile in the current directory and running on Podman
+// devfileObj in context must not be nil when this method is called
+func (o *ComponentOptions) deleteDevfilePodmanComponent(ctx context.Context) error {
+	var (
+		devfileObj    = odocontext.GetDevfileObj(ctx)
+		componentName = odocontext.GetComponentName(ctx)
+		appName       = odocontext.GetApplication(ctx)
+	)
+
+	log.Info("Searching resources to delete, please wait...")
+	pods, err := o.clientset.DeleteClient.ListPodmanResourcesToDelete(appName, componentName)
+	if err != nil {
+		return err
+	}
+	hasPodmanResources := len(pods) != 0
+	if hasPodmanResources {
+		printPodmanResources(o.runOn, componentName, pods)
+	} else {
+		log.Infof("No resource found for component %q on %s\n", componentName, o.runOn)
+		if !o.withFilesFlag {
+			return nil
+		}
+	}
+
+	var filesToDelete []string
+	if o.withFilesFlag {
+		filesToDelete, err = getFilesCreatedByOdo(o.clientset.FS, ctx)
+		if err != nil {
+			return err
+		}
+		printFileCreatedByOdo(filesToDelete, hasPodmanResources)
+	}
+	hasFilesToDelete := len(filesToDelete) != 0
+
+	if !(hasPodmanResources || hasFilesToDelete) {
+		klog.V(2).Info("no podman resources and no files to delete")
+		return nil
+	}
+
+	if o.forceFlag || ui.Proceed(fmt.Sprintf("Are you sure you want to delete %q and all its resources?", componentName)) {
+		if hasPodmanResources {
+			err = o.clientset.DeleteClient.ExecutePodmanPreStopEvents(*devfileObj, appName, componentName)
+			if err != nil {
+				log.Errorf("Failed to execute preStop events: %v", err)
+			}
+			o.deletePodmanResources(pods)
+			log.Infof("The component %q is successfully deleted from %s\n", componentName, o.runOn)
+		}
+
+		if o.withFilesFlag {
+			o.deleteFiles(filesToDelete)
+		}
+		return nil
+	}
+
+	log.Error("Aborting deletion of component")
+	return nil
+}
+
+// deletePodmanResources deletes the pods and their volumes, and warns about the resources that failed to be deleted
+func (o *ComponentOptions) deletePodmanResources(pods []corev1.Pod) {
+	failed := o.clientset.DeleteClient.DeletePodmanResources(pods)
+	for _, fail := range failed {
+		log.Warningf("Failed to delete the resource: %s\n", fail)
+	}
+}
+
 // listResourcesMissingFromDevfilePresentOnCluster returns a list of resources belonging to a component name that are present on cluster, but missing from devfile
 func listResourcesMissingFromDevfilePresentOnCluster(componentName string, devfileResources, clusterResources []unstructured.Unstructured) []unstructured.Unstructured {
 	var remainingResources []unstructured.Unstructured
@@ -242,6 +349,21 @@ func listResourcesMissingFromDevfilePresentOnCluster(componentName string, devfi
 	return remainingResources
 }
 
+// deleteFiles deletes the files created by odo, and lists the files that could not be deleted
+func (o *ComponentOptions) deleteFiles(filesToDelete []string) {
+	remainingFiles := o.deleteFilesCreatedByOdo(o.clientset.FS, filesToDelete)
+	var listOfFiles []string
+	for f, e := range remainingFiles {
+		log.Warningf("Failed to delete file or directory: %s: %v\n", f, e)
+		listOfFiles = append(listOfFiles, "\t- "+f)
+	}
+	if len(remainingFiles) != 0 {
+		log.Printf("There are still files or directories that could not be deleted.")
+		fmt.Println(strings.Join(listOfFiles, "\n"))
+		log.Info("You need to manually delete those.")
+	}
+}
+
 // printDevfileResources prints the devfile components for ComponentOptions.deleteDevfileComponent
 func printDevfileComponents(componentName, namespace string, k8sResources []unstructured.Unstructured) {
 	log.Infof("This will delete %q from the namespace %q.", componentName, namespace)
@@ -255,6 +377,23 @@ func printDevfileComponents(componentName, namespace string, k8sResources []unst
 	fmt.Println()
 }
 
+// printPodmanResources prints the pods and volumes of a component running on Podman, or on the given platform
+func printPodmanResources(platform string, componentName string, pods []corev1.Pod) {
+	log.Infof("This will delete %q from %s.", componentName, platform)
+
+	log.Printf("The component contains the following resources that will get deleted:")
+	for _, pod := range pods {
+		fmt.Printf("\t- Pod: %s\n", pod.GetName())
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim == nil {
+				continue
+			}
+			fmt.Printf("\t- Volume: %s\n", volume.PersistentVolumeClaim.ClaimName)
+		}
+	}
+	fmt.Println()
+}
+
 // getFilesCreatedByOdo gets the list of all files that were initially created by odo.
 func getFilesCreatedByOdo(filesys filesystem.Filesystem, ctx context.Context) ([]string, error) {
 	workingDir := odocontext.GetWorkingDirectory(ctx)
@@ -325,7 +464,8 @@ func NewCmdComponent(name, fullName string) *cobra.Command {
 	componentCmd.Flags().BoolVarP(&o.withFilesFlag, "files", "", false, "Delete all files and directories generated by odo. Use with caution.")
 	componentCmd.Flags().BoolVarP(&o.forceFlag, "force", "f", false, "Delete component without prompting")
 	componentCmd.Flags().BoolVarP(&o.waitFlag, "wait", "w", false, "Wait for deletion of all dependent resources")
-	clientset.Add(componentCmd, clientset.DELETE_COMPONENT, clientset.KUBERNETES, clientset.FILESYSTEM)
+	clientset.Add(componentCmd, clientset.DELETE_COMPONENT, clientset.KUBERNETES_NULLABLE, clientset.FILESYSTEM)
+	commonflags.UseRunOnFlag(componentCmd)
 
 	return componentCmd
 }
@@ -2,6 +2,7 @@ package deploy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/redhat-developer/odo/pkg/component"
@@ -9,6 +10,7 @@ import (
 	"github.com/redhat-developer/odo/pkg/odo/cli/messages"
 	"github.com/redhat-developer/odo/pkg/odo/cmdline"
 	"github.com/redhat-developer/odo/pkg/odo/commonflags"
+	fcontext "github.com/redhat-developer/odo/pkg/odo/commonflags/context"
 	odocontext "github.com/redhat-developer/odo/pkg/odo/context"
 	"github.com/redhat-developer/odo/pkg/odo/genericclioptions"
 	"github.com/redhat-developer/odo/pkg/odo/genericclioptions/clientset"
@@ -34,6 +36,9 @@ var _ genericclioptions.Runnable = (*DeployOptions)(nil)
 var deployExample = templates.Examples(`
   # Deploy components defined in the devfile
   %[1]s
+
+  # Deploy components defined in the devfile on Podman (experimental)
+  %[1]s --run-on podman
 `)
 
 // NewDeployOptions creates a new DeployOptions instance
@@ -60,6 +65,14 @@ func (o *DeployOptions) Validate(ctx context.Context) error {
 	if devfileObj == nil {
 		return genericclioptions.NewNoDevfileError(odocontext.GetWorkingDirectory(ctx))
 	}
+
+	platform := fcontext.GetRunOn(ctx)
+	switch platform {
+	case commonflags.RunOnCluster:
+		if o.clientset.KubernetesClient == nil {
+			return errors.New("no connection to cluster defined")
+		}
+	}
 	return nil
 }
 
@@ -68,16 +81,26 @@ func (o *DeployOptions) Run(ctx context.Context) error {
 	var (
 		devfileObj  = odocontext.GetDevfileObj(ctx)
 		devfileName = odocontext.GetComponentName(ctx)
-		namespace   = odocontext.GetNamespace(ctx)
+		platform    = fcontext.GetRunOn(ctx)
 	)
 
+	var dest string
+	switch platform {
+	case commonflags.RunOnPodman:
+		dest = "Platform: podman"
+	case commonflags.RunOnCluster:
+		dest = "Namespace: " + odocontext.GetNamespace(ctx)
+	default:
+		panic(fmt.Errorf("platform %s is not implemented", platform))
+	}
+
 	scontext.SetComponentType(ctx, component.GetComponentTypeFromDevfileMetadata(devfileObj.Data.GetMetadata()))
 	scontext.SetLanguage(ctx, devfileObj.Data.GetMetadata().Language)
 	scontext.SetProjectType(ctx, devfileObj.Data.GetMetadata().ProjectType)
 	scontext.SetDevfileName(ctx, devfileName)
 	// Output what the command is doing / information
 	log.Title("Deploying the application using "+devfileName+" Devfile",
-		"Namespace: "+namespace,
+		dest,
 		"odo version: "+version.VERSION)
 
 	// Run actual deploy command to be used
@@ -103,11 +126,12 @@ func NewCmdDeploy(name, fullName string) *cobra.Command {
 			return genericclioptions.GenericRun(o, cmd, args)
 		},
 	}
-	clientset.Add(deployCmd, clientset.INIT, clientset.DEPLOY, clientset.FILESYSTEM)
+	clientset.Add(deployCmd, clientset.INIT, clientset.DEPLOY, clientset.FILESYSTEM, clientset.KUBERNETES_NULLABLE, clientset.PODMAN_NULLABLE)
 
 	// Add a defined annotation in order to appear in the help menu
 	deployCmd.Annotations["command"] = "main"
 	deployCmd.SetUsageTemplate(odoutil.CmdUsageTemplate)
 	commonflags.UseVariablesFlags(deployCmd)
+	commonflags.UseRunOnFlag(deployCmd)
 	return deployCmd
 }
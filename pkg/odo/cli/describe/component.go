@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
@@ -17,6 +18,7 @@ import (
 	"github.com/redhat-developer/odo/pkg/log"
 	"github.com/redhat-developer/odo/pkg/odo/cmdline"
 	"github.com/redhat-developer/odo/pkg/odo/commonflags"
+	fcontext "github.com/redhat-developer/odo/pkg/odo/commonflags/context"
 	odocontext "github.com/redhat-developer/odo/pkg/odo/context"
 	"github.com/redhat-developer/odo/pkg/odo/genericclioptions"
 	"github.com/redhat-developer/odo/pkg/odo/genericclioptions/clientset"
@@ -40,6 +42,10 @@ type ComponentOptions struct {
 	// namespaceFlag on which to find the component to describe, optional, defaults to current namespaceFlag
 	namespaceFlag string
 
+	// runOn is the platform selected by the user, with the --run-on flag or the preferences,
+	// or an empty string if no platform has been selected
+	runOn string
+
 	// Clients
 	clientset *clientset.Clientset
 }
@@ -57,6 +63,11 @@ func (o *ComponentOptions) SetClientset(clientset *clientset.Clientset) {
 }
 
 func (o *ComponentOptions) Complete(ctx context.Context, cmdline cmdline.Cmdline, args []string) (err error) {
+	o.runOn = fcontext.GetRunOnIfSet(ctx)
+	if o.runOn == commonflags.RunOnDocker {
+		return errors.New("describing components is not supported with --run-on docker")
+	}
+
 	// 1. Name is not passed, and odo has access to devfile.yaml; Name is not passed so we assume that odo has access to the devfile.yaml
 	if o.nameFlag == "" {
 		if len(o.namespaceFlag) > 0 {
@@ -70,6 +81,12 @@ func (o *ComponentOptions) Complete(ctx context.Context, cmdline cmdline.Cmdline
 	}
 
 	// 2. Name is passed, and odo does not have access to devfile.yaml; if Name is passed, then we assume that odo does not have access to the devfile.yaml
+	if o.runOn == commonflags.RunOnPodman {
+		if len(o.namespaceFlag) > 0 {
+			return errors.New("--namespace cannot be used with --run-on podman")
+		}
+		return nil
+	}
 	if o.clientset.KubernetesClient != nil {
 		if o.namespaceFlag != "" {
 			o.clientset.KubernetesClient.SetNamespace(o.namespaceFlag)
@@ -81,7 +98,7 @@ func (o *ComponentOptions) Complete(ctx context.Context, cmdline cmdline.Cmdline
 }
 
 func (o *ComponentOptions) Validate(ctx context.Context) (err error) {
-	if o.clientset.KubernetesClient == nil {
+	if o.runOn != commonflags.RunOnPodman && o.clientset.KubernetesClient == nil {
 		log.Warning("No connection to cluster defined")
 	}
 	return nil
@@ -110,19 +127,20 @@ func (o *ComponentOptions) run(ctx context.Context) (result api.Component, devfi
 
 // describeNamedComponent describes a component given its name
 func (o *ComponentOptions) describeNamedComponent(ctx context.Context, name string) (result api.Component, devfileObj *parser.DevfileObj, err error) {
-	if o.clientset.KubernetesClient == nil {
+	kubeClient, podmanClient := clientset.GetPlatformClients(ctx, o.clientset, o.runOn)
+	if kubeClient == nil && podmanClient == nil {
 		return api.Component{}, nil, errors.New("cluster is non accessible")
 	}
 
-	runningIn, err := component.GetRunningModes(ctx, o.clientset.KubernetesClient, name)
+	runningOn, err := component.GetRunningModesOnPlatforms(ctx, kubeClient, podmanClient, name)
 	if err != nil {
 		return api.Component{}, nil, err
 	}
-	devfile, err := component.GetDevfileInfoFromCluster(ctx, o.clientset.KubernetesClient, name)
+	devfile, err := component.GetDevfileInfo(ctx, kubeClient, podmanClient, name)
 	if err != nil {
 		return api.Component{}, nil, err
 	}
-	ingresses, routes, err := component.ListRoutesAndIngresses(o.clientset.KubernetesClient, name, odocontext.GetApplication(ctx))
+	ingresses, routes, err := component.ListRoutesAndIngresses(kubeClient, name, odocontext.GetApplication(ctx))
 	if err != nil {
 		return api.Component{}, nil, fmt.Errorf("failed to get ingresses/routes: %w", err)
 	}
@@ -131,7 +149,8 @@ func (o *ComponentOptions) describeNamedComponent(ctx context.Context, name stri
 		DevfileData: &api.DevfileData{
 			Devfile: devfile.Data,
 		},
-		RunningIn: runningIn,
+		RunningIn: component.MergeRunningModes(runningOn),
+		RunningOn: runningOn,
 		ManagedBy: "odo",
 		Ingresses: ingresses,
 		Routes:    routes,
@@ -151,16 +170,17 @@ func (o *ComponentOptions) describeDevfileComponent(ctx context.Context) (result
 		return api.Component{}, nil, err
 	}
 
-	runningIn, err := component.GetRunningModes(ctx, o.clientset.KubernetesClient, componentName)
+	kubeClient, podmanClient := clientset.GetPlatformClients(ctx, o.clientset, o.runOn)
+	runningOn, err := component.GetRunningModesOnPlatforms(ctx, kubeClient, podmanClient, componentName)
 	if err != nil {
 		if !errors.As(err, &component.NoComponentFoundError{}) {
 			return api.Component{}, nil, err
 		} else {
 			// it is ok if the component is not deployed
-			runningIn = nil
+			runningOn = nil
 		}
 	}
-	ingresses, routes, err := component.ListRoutesAndIngresses(o.clientset.KubernetesClient, componentName, odocontext.GetApplication(ctx))
+	ingresses, routes, err := component.ListRoutesAndIngresses(kubeClient, componentName, odocontext.GetApplication(ctx))
 	if err != nil {
 		return api.Component{}, nil, fmt.Errorf("failed to get ingresses/routes: %w", err)
 	}
@@ -169,7 +189,8 @@ func (o *ComponentOptions) describeDevfileComponent(ctx context.Context) (result
 		DevfilePath:       devfilePath,
 		DevfileData:       api.GetDevfileData(*devfileObj),
 		DevForwardedPorts: forwardedPorts,
-		RunningIn:         runningIn,
+		RunningIn:         component.MergeRunningModes(runningOn),
+		RunningOn:         runningOn,
 		ManagedBy:         "odo",
 		Ingresses:         ingresses,
 		Routes:            routes,
@@ -189,6 +210,14 @@ func printHumanReadableOutput(cmp api.Component, devfileObj *parser.DevfileObj)
 	}
 
 	log.Describef("Running in: ", cmp.RunningIn.String())
+	if len(cmp.RunningOn) != 0 {
+		platforms := make([]string, 0, len(cmp.RunningOn))
+		for platform := range cmp.RunningOn {
+			platforms = append(platforms, platform)
+		}
+		sort.Strings(platforms)
+		log.Describef("Running on: ", strings.Join(platforms, ", "))
+	}
 	fmt.Println()
 
 	if len(cmp.DevForwardedPorts) > 0 {
@@ -292,8 +321,9 @@ func NewCmdComponent(name, fullName string) *cobra.Command {
 	}
 	componentCmd.Flags().StringVar(&o.nameFlag, "name", "", "Name of the component to describe, optional. By default, the component in the local devfile is described")
 	componentCmd.Flags().StringVar(&o.namespaceFlag, "namespace", "", "Namespace in which to find the component to describe, optional. By default, the current namespace defined in kubeconfig is used")
-	clientset.Add(componentCmd, clientset.KUBERNETES_NULLABLE, clientset.STATE)
+	clientset.Add(componentCmd, clientset.KUBERNETES_NULLABLE, clientset.PODMAN, clientset.STATE)
 	commonflags.UseOutputFlag(componentCmd)
+	commonflags.UseRunOnFlag(componentCmd)
 
 	return componentCmd
 }
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	ktemplates "k8s.io/kubectl/pkg/util/templates"
@@ -14,6 +16,7 @@ import (
 	"github.com/redhat-developer/odo/pkg/dev"
 	"github.com/redhat-developer/odo/pkg/libdevfile"
 	"github.com/redhat-developer/odo/pkg/log"
+	"github.com/redhat-developer/odo/pkg/machineoutput"
 	clierrors "github.com/redhat-developer/odo/pkg/odo/cli/errors"
 	"github.com/redhat-developer/odo/pkg/odo/cli/messages"
 	"github.com/redhat-developer/odo/pkg/odo/cmdline"
@@ -24,6 +27,7 @@ import (
 	"github.com/redhat-developer/odo/pkg/odo/genericclioptions/clientset"
 	odoutil "github.com/redhat-developer/odo/pkg/odo/util"
 	scontext "github.com/redhat-developer/odo/pkg/segment/context"
+	"github.com/redhat-developer/odo/pkg/sync"
 	"github.com/redhat-developer/odo/pkg/util"
 	"github.com/redhat-developer/odo/pkg/version"
 )
@@ -41,6 +45,8 @@ type DevOptions struct {
 	ignorePaths []string
 	out         io.Writer
 	errOut      io.Writer
+	// syncBack are the paths to copy from the container back to the local directory, parsed from --sync-back
+	syncBack []sync.SyncBackPath
 
 	// ctx is used to communicate with WatchAndPush to stop watching and start cleaning up
 	ctx context.Context
@@ -49,11 +55,17 @@ type DevOptions struct {
 	cancel context.CancelFunc
 
 	// Flags
-	noWatchFlag      bool
-	randomPortsFlag  bool
-	debugFlag        bool
-	buildCommandFlag string
-	runCommandFlag   string
+	noWatchFlag       bool
+	randomPortsFlag   bool
+	debugFlag         bool
+	buildCommandFlag  string
+	runCommandFlag    string
+	debugCommandFlag  string
+	syncBackFlag      []string
+	pollIntervalFlag  time.Duration
+	logsFlag          bool
+	apiServerFlag     bool
+	apiServerPortFlag int
 }
 
 var _ genericclioptions.Runnable = (*DevOptions)(nil)
@@ -73,8 +85,26 @@ var devExample = ktemplates.Examples(`
 	# Deploy component to the development cluster, using the specified run command
 	%[1]s --run-command <my-command>
 
+	# Deploy component to the development cluster in debug mode, using the specified debug command
+	%[1]s --debug --debug-command <my-command>
+
 	# Deploy component to the development cluster without automatically syncing the code upon any file changes
 	%[1]s --no-watch
+
+	# Deploy component to the development cluster, and copy the generated directory back to the local directory when pressing [b]
+	%[1]s --sync-back target/generated:generated
+
+	# Deploy component to the development cluster, polling the files for changes every 2 seconds (for network or virtualized filesystems)
+	%[1]s --poll-interval 2s
+
+	# Deploy component to the development cluster, and display the logs of its containers
+	%[1]s --logs
+
+	# Deploy component to the development cluster, and start a local API to control the session
+	%[1]s --api-server
+
+	# Deploy component to the development cluster, and output the events of the session as newline-delimited JSON objects
+	%[1]s -o json
 `)
 
 func (o *DevOptions) SetClientset(clientset *clientset.Clientset) {
@@ -89,6 +119,12 @@ func (o *DevOptions) Complete(ctx context.Context, cmdline cmdline.Cmdline, args
 	// Define this first so that if user hits Ctrl+c very soon after running odo dev, odo doesn't panic
 	o.ctx, o.cancel = context.WithCancel(ctx)
 
+	if log.IsJSON() {
+		// Only the events are output, as newline-delimited JSON objects
+		o.out = io.Discard
+		o.errOut = io.Discard
+	}
+
 	return nil
 }
 
@@ -100,6 +136,26 @@ func (o *DevOptions) Validate(ctx context.Context) error {
 	if o.debugFlag && !libdevfile.HasDebugCommand(devfileObj.Data) {
 		return clierrors.NewNoCommandInDevfileError("debug")
 	}
+	if !o.debugFlag && o.debugCommandFlag != "" {
+		return errors.New("--debug-command can only be used with --debug")
+	}
+	if o.pollIntervalFlag < 0 {
+		return errors.New("--poll-interval must be a positive duration")
+	}
+	if o.noWatchFlag && o.pollIntervalFlag != 0 {
+		return errors.New("--poll-interval cannot be used with --no-watch")
+	}
+	if !o.apiServerFlag && o.apiServerPortFlag != 0 {
+		return errors.New("--api-server-port can only be used with --api-server")
+	}
+	if o.apiServerPortFlag < 0 || o.apiServerPortFlag > 65535 {
+		return fmt.Errorf("invalid value %d for --api-server-port, expected a port between 0 and 65535", o.apiServerPortFlag)
+	}
+	var err error
+	o.syncBack, err = parseSyncBackFlag(o.syncBackFlag)
+	if err != nil {
+		return err
+	}
 
 	platform := fcontext.GetRunOn(ctx)
 	switch platform {
@@ -124,9 +180,9 @@ func (o *DevOptions) Run(ctx context.Context) (err error) {
 	var dest string
 	var deployingTo string
 	switch platform {
-	case commonflags.RunOnPodman:
-		dest = "Platform: podman"
-		deployingTo = "podman"
+	case commonflags.RunOnPodman, commonflags.RunOnDocker:
+		dest = "Platform: " + platform
+		deployingTo = platform
 	case commonflags.RunOnCluster:
 		dest = "Namespace: " + odocontext.GetNamespace(ctx)
 		deployingTo = "the cluster"
@@ -154,7 +210,7 @@ func (o *DevOptions) Run(ctx context.Context) (err error) {
 	}
 
 	var ignores []string
-	err = genericclioptions.ApplyIgnore(&ignores, "")
+	err = genericclioptions.ApplyIgnore(&ignores, path)
 	if err != nil {
 		return err
 	}
@@ -166,6 +222,12 @@ func (o *DevOptions) Run(ctx context.Context) (err error) {
 	scontext.SetProjectType(ctx, devFileObj.Data.GetMetadata().ProjectType)
 	scontext.SetDevfileName(ctx, componentName)
 
+	var apiServerAddress string
+	if o.apiServerFlag {
+		// The API server is only reachable from the local host
+		apiServerAddress = fmt.Sprintf("127.0.0.1:%d", o.apiServerPortFlag)
+	}
+
 	log.Sectionf("Deploying to %s in developer mode", deployingTo)
 
 	return o.clientset.DevClient.Start(
@@ -173,17 +235,47 @@ func (o *DevOptions) Run(ctx context.Context) (err error) {
 		o.out,
 		o.errOut,
 		dev.StartOptions{
-			IgnorePaths:  o.ignorePaths,
-			Debug:        o.debugFlag,
-			BuildCommand: o.buildCommandFlag,
-			RunCommand:   o.runCommandFlag,
-			RandomPorts:  o.randomPortsFlag,
-			WatchFiles:   !o.noWatchFlag,
-			Variables:    variables,
+			IgnorePaths:      o.ignorePaths,
+			Debug:            o.debugFlag,
+			BuildCommand:     o.buildCommandFlag,
+			RunCommand:       o.runCommandFlag,
+			DebugCommand:     o.debugCommandFlag,
+			RandomPorts:      o.randomPortsFlag,
+			WatchFiles:       !o.noWatchFlag,
+			PollInterval:     o.pollIntervalFlag,
+			Variables:        variables,
+			SyncBack:         o.syncBack,
+			Logs:             o.logsFlag,
+			APIServerAddress: apiServerAddress,
 		},
 	)
 }
 
+// parseSyncBackFlag parses the values of the --sync-back flag, in the form containerPath:localPath.
+// The local path must be relative to the component directory, and inside it.
+func parseSyncBackFlag(values []string) ([]sync.SyncBackPath, error) {
+	var result []sync.SyncBackPath
+	for _, value := range values {
+		i := strings.LastIndex(value, ":")
+		if i <= 0 || i == len(value)-1 {
+			return nil, fmt.Errorf("invalid value %q for --sync-back, expected containerPath:localPath", value)
+		}
+		containerPath, localPath := value[:i], value[i+1:]
+		if filepath.IsAbs(localPath) {
+			return nil, fmt.Errorf("invalid value %q for --sync-back, the local path must be relative to the component directory", value)
+		}
+		localPath = filepath.Clean(localPath)
+		if localPath == ".." || strings.HasPrefix(localPath, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("invalid value %q for --sync-back, the local path must be inside the component directory", value)
+		}
+		result = append(result, sync.SyncBackPath{
+			ContainerPath: containerPath,
+			LocalPath:     filepath.ToSlash(localPath),
+		})
+	}
+	return result, nil
+}
+
 func (o *DevOptions) HandleSignal() error {
 	o.cancel()
 	// At this point, `ctx.Done()` will be raised, and the cleanup will be done
@@ -193,7 +285,10 @@ func (o *DevOptions) HandleSignal() error {
 
 func (o *DevOptions) Cleanup(ctx context.Context, commandError error) {
 	if commandError != nil {
-		_ = o.clientset.DevClient.CleanupResources(ctx, log.GetStdout())
+		logger := machineoutput.NewMachineEventLoggingClient()
+		logger.ReportError(commandError, machineoutput.TimestampNow())
+		err := o.clientset.DevClient.CleanupResources(ctx, o.out)
+		logger.Cleanup(err, machineoutput.TimestampNow())
 	}
 	_ = o.clientset.StateClient.SaveExit()
 }
@@ -219,6 +314,20 @@ It forwards endpoints with any exposure values ('public', 'internal' or 'none')
 		"Alternative build command. The default one will be used if this flag is not set.")
 	devCmd.Flags().StringVar(&o.runCommandFlag, "run-command", "",
 		"Alternative run command to execute. The default one will be used if this flag is not set.")
+	devCmd.Flags().StringVar(&o.debugCommandFlag, "debug-command", "",
+		"Alternative debug command to execute, when --debug is set. The default one will be used if this flag is not set.")
+	devCmd.Flags().StringArrayVar(&o.syncBackFlag, "sync-back", nil,
+		"Path to copy from the container back to the local directory when pressing [b], in the form containerPath:localPath. "+
+			"A relative container path is relative to the sources directory in the container. Can be repeated.")
+	devCmd.Flags().DurationVar(&o.pollIntervalFlag, "poll-interval", 0,
+		"Poll the files for changes at this interval (e.g. 2s) instead of using filesystem notifications, "+
+			"for filesystems not delivering notifications (NFS, SSHFS, shared folders of virtual machines).")
+	devCmd.Flags().BoolVar(&o.logsFlag, "logs", false,
+		"Display the logs of the containers of the component. The logs can also be shown or hidden by pressing [l].")
+	devCmd.Flags().BoolVar(&o.apiServerFlag, "api-server", false,
+		"Start a local HTTP API to get the status of the session and to control it. Its address and the token to access it are written into the .odo/devstate.json file.")
+	devCmd.Flags().IntVar(&o.apiServerPortFlag, "api-server-port", 0,
+		"Local port of the API server, when --api-server is set. A random port is used if this flag is not set.")
 	clientset.Add(devCmd,
 		clientset.BINDING,
 		clientset.DEV,
@@ -226,7 +335,7 @@ It forwards endpoints with any exposure values ('public', 'internal' or 'none')
 		clientset.FILESYSTEM,
 		clientset.INIT,
 		clientset.KUBERNETES_NULLABLE,
-		clientset.PODMAN,
+		clientset.PODMAN_NULLABLE,
 		clientset.PORT_FORWARD,
 		clientset.PREFERENCE,
 		clientset.STATE,
@@ -238,5 +347,6 @@ It forwards endpoints with any exposure values ('public', 'internal' or 'none')
 	devCmd.SetUsageTemplate(odoutil.CmdUsageTemplate)
 	commonflags.UseVariablesFlags(devCmd)
 	commonflags.UseRunOnFlag(devCmd)
+	commonflags.UseOutputFlag(devCmd)
 	return devCmd
 }
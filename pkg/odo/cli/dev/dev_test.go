@@ -0,0 +1,62 @@
+package dev
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/redhat-developer/odo/pkg/sync"
+)
+
+func Test_parseSyncBackFlag(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []sync.SyncBackPath
+		wantErr bool
+	}{
+		{
+			name: "no value",
+		},
+		{
+			name:   "relative and absolute container paths",
+			values: []string{"target/generated:generated", "/projects/package-lock.json:./package-lock.json"},
+			want: []sync.SyncBackPath{
+				{ContainerPath: "target/generated", LocalPath: "generated"},
+				{ContainerPath: "/projects/package-lock.json", LocalPath: "package-lock.json"},
+			},
+		},
+		{
+			name:    "missing local path",
+			values:  []string{"target:"},
+			wantErr: true,
+		},
+		{
+			name:    "missing separator",
+			values:  []string{"target"},
+			wantErr: true,
+		},
+		{
+			name:    "absolute local path",
+			values:  []string{"target:/tmp/target"},
+			wantErr: true,
+		},
+		{
+			name:    "local path outside of the component directory",
+			values:  []string{"target:sub/../../target"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSyncBackFlag(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSyncBackFlag() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseSyncBackFlag() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/redhat-developer/odo/pkg/api"
 	"github.com/redhat-developer/odo/pkg/odo/cli/ui"
 	"github.com/redhat-developer/odo/pkg/odo/commonflags"
+	fcontext "github.com/redhat-developer/odo/pkg/odo/commonflags/context"
 
 	"github.com/redhat-developer/odo/pkg/component"
 
@@ -38,6 +39,9 @@ type ListOptions struct {
 
 	// Local variables
 	namespaceFilter string
+	// runOn is the platform selected by the user, with the --run-on flag or the preferences,
+	// or an empty string if no platform has been selected
+	runOn string
 
 	// Flags
 	namespaceFlag string
@@ -57,6 +61,14 @@ func (o *ListOptions) SetClientset(clientset *clientset.Clientset) {
 
 // Complete ...
 func (lo *ListOptions) Complete(ctx context.Context, cmdline cmdline.Cmdline, args []string) (err error) {
+	lo.runOn = fcontext.GetRunOnIfSet(ctx)
+	if lo.runOn == commonflags.RunOnDocker {
+		return errors.New("listing components is not supported with --run-on docker")
+	}
+	if lo.runOn == commonflags.RunOnPodman {
+		return nil
+	}
+
 	// If the namespace flag has been passed, we will search there.
 	// if it hasn't, we will search from the default project / namespace.
 	if lo.namespaceFlag != "" {
@@ -73,6 +85,12 @@ func (lo *ListOptions) Complete(ctx context.Context, cmdline cmdline.Cmdline, ar
 
 // Validate ...
 func (lo *ListOptions) Validate(ctx context.Context) (err error) {
+	if lo.runOn == commonflags.RunOnPodman {
+		if lo.namespaceFlag != "" {
+			return errors.New("--namespace cannot be used with --run-on podman")
+		}
+		return nil
+	}
 	if lo.clientset.KubernetesClient == nil {
 		log.Warning("No connection to cluster defined")
 	}
@@ -81,7 +99,12 @@ func (lo *ListOptions) Validate(ctx context.Context) (err error) {
 
 // Run has the logic to perform the required actions as part of command
 func (lo *ListOptions) Run(ctx context.Context) error {
-	listSpinner := log.Spinnerf("Listing components from namespace '%s'", lo.namespaceFilter)
+	var listSpinner *log.Status
+	if lo.runOn == commonflags.RunOnPodman {
+		listSpinner = log.Spinner("Listing components from podman")
+	} else {
+		listSpinner = log.Spinnerf("Listing components from namespace '%s'", lo.namespaceFilter)
+	}
 	defer listSpinner.End(false)
 
 	list, err := lo.run(ctx)
@@ -105,8 +128,9 @@ func (lo *ListOptions) run(ctx context.Context) (api.ResourcesList, error) {
 		devfileObj    = odocontext.GetDevfileObj(ctx)
 		componentName = odocontext.GetComponentName(ctx)
 	)
+	kubeClient, podmanClient := clientset.GetPlatformClients(ctx, lo.clientset, lo.runOn)
 	devfileComponents, componentInDevfile, err := component.ListAllComponents(
-		lo.clientset.KubernetesClient, lo.namespaceFilter, devfileObj, componentName)
+		kubeClient, podmanClient, lo.namespaceFilter, devfileObj, componentName)
 	if err != nil {
 		return api.ResourcesList{}, err
 	}
@@ -132,11 +156,12 @@ func NewCmdComponentList(name, fullName string) *cobra.Command {
 		},
 		Aliases: []string{"components"},
 	}
-	clientset.Add(listCmd, clientset.KUBERNETES_NULLABLE, clientset.FILESYSTEM)
+	clientset.Add(listCmd, clientset.KUBERNETES_NULLABLE, clientset.PODMAN, clientset.FILESYSTEM)
 
 	listCmd.Flags().StringVar(&o.namespaceFlag, "namespace", "", "Namespace for odo to scan for components")
 
 	commonflags.UseOutputFlag(listCmd)
+	commonflags.UseRunOnFlag(listCmd)
 
 	return listCmd
 }
@@ -151,7 +176,7 @@ func HumanReadableOutput(list api.ResourcesList) {
 	t := ui.NewTable()
 
 	// Create the header and then sort accordingly
-	t.AppendHeader(table.Row{"NAME", "PROJECT TYPE", "RUNNING IN", "MANAGED"})
+	t.AppendHeader(table.Row{"NAME", "PROJECT TYPE", "RUNNING IN", "MANAGED", "PLATFORM"})
 	t.SortBy([]table.SortBy{
 		{Name: "MANAGED", Mode: table.Asc},
 		{Name: "NAME", Mode: table.Dsc},
@@ -190,7 +215,13 @@ func HumanReadableOutput(list api.ResourcesList) {
 			managedBy = text.Colors{text.FgBlue}.Sprintf(managedBy)
 		}
 
-		t.AppendRow(table.Row{name, componentType, mode, managedBy})
+		// Get the platform on which the component is running
+		platform := comp.Platform
+		if platform == "" {
+			platform = api.TypeNone
+		}
+
+		t.AppendRow(table.Row{name, componentType, mode, managedBy, platform})
 	}
 	t.Render()
 
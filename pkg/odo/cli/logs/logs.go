@@ -24,6 +24,8 @@ import (
 	ktemplates "k8s.io/kubectl/pkg/util/templates"
 
 	"github.com/redhat-developer/odo/pkg/odo/cmdline"
+	"github.com/redhat-developer/odo/pkg/odo/commonflags"
+	fcontext "github.com/redhat-developer/odo/pkg/odo/commonflags/context"
 	odocontext "github.com/redhat-developer/odo/pkg/odo/context"
 	"github.com/redhat-developer/odo/pkg/odo/genericclioptions"
 	"github.com/redhat-developer/odo/pkg/odo/genericclioptions/clientset"
@@ -90,6 +92,14 @@ func (o *LogsOptions) Validate(ctx context.Context) error {
 	if o.devMode && o.deployMode {
 		return errors.New("pass only one of --dev or --deploy flags; pass no flag to see logs for both modes")
 	}
+
+	platform := fcontext.GetRunOn(ctx)
+	switch platform {
+	case commonflags.RunOnCluster:
+		if o.clientset.KubernetesClient == nil {
+			return errors.New("no connection to cluster defined")
+		}
+	}
 	return nil
 }
 
@@ -245,5 +255,6 @@ By default it shows logs of all containers running in both Dev and Deploy mode.
 	clientset.Add(logsCmd, clientset.LOGS, clientset.FILESYSTEM)
 	logsCmd.Annotations["command"] = "main"
 	logsCmd.SetUsageTemplate(odoutil.CmdUsageTemplate)
+	commonflags.UseRunOnFlag(logsCmd)
 	return logsCmd
 }
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"strings"
 
+	envcontext "github.com/redhat-developer/odo/pkg/config/context"
 	"github.com/redhat-developer/odo/pkg/log"
 	"github.com/redhat-developer/odo/pkg/odo/cmdline"
 	"github.com/redhat-developer/odo/pkg/odo/util"
 
+	"github.com/redhat-developer/odo/pkg/odo/cli/feature"
 	"github.com/redhat-developer/odo/pkg/odo/cli/ui"
 	"github.com/redhat-developer/odo/pkg/odo/genericclioptions/clientset"
 	"github.com/redhat-developer/odo/pkg/preference"
@@ -83,6 +85,18 @@ func (o *SetOptions) Run(ctx context.Context) (err error) {
 	}
 
 	log.Successf("Value of '%s' preference was set to '%s'", o.paramName, o.paramValue)
+
+	if o.paramName == strings.ToLower(preference.PlatformSetting) {
+		envConfig := envcontext.GetEnvConfig(ctx)
+		if !envConfig.OdoExperimentalMode {
+			log.Warningf("The %s preference is used only when the experimental mode is enabled. Set %s=%s to enable it",
+				preference.PlatformSetting, feature.OdoExperimentalModeEnvVar, feature.OdoExperimentalModeTrue)
+		}
+		platform := strings.ToLower(o.paramValue)
+		if err = genericclioptions.CheckPlatform(envConfig, platform); err != nil {
+			log.Warningf("Platform %q may not be reachable: %v", platform, err)
+		}
+	}
 	return nil
 }
 
@@ -36,18 +36,27 @@ func IsJsonOutput(ctx context.Context) bool {
 	return false
 }
 
-// WithRunOn sets the value for the run-on flag in ctx
+// WithRunOn sets the platform selected by the user in ctx, with the run-on flag or the preferences.
+// An empty value means that no platform has been selected
 func WithRunOn(ctx context.Context, val string) context.Context {
 	return context.WithValue(ctx, runOnKey, val)
 }
 
-// GetRunOn gets value of run-on flag in ctx
+// GetRunOn gets the platform selected by the user in ctx, or the default platform if no platform has been selected
 func GetRunOn(ctx context.Context) string {
+	if value := GetRunOnIfSet(ctx); value != "" {
+		return value
+	}
+	return commonflags.RunOnDefault
+}
+
+// GetRunOnIfSet gets the platform selected by the user in ctx, or an empty string if no platform has been selected
+func GetRunOnIfSet(ctx context.Context) string {
 	value := ctx.Value(runOnKey)
 	if cast, ok := value.(string); ok {
 		return cast
 	}
-	return commonflags.RunOnDefault
+	return ""
 }
 
 // WithVariables sets the value for the --var-file and --var flags in ctx
@@ -50,3 +50,23 @@ func TestRunOn(t *testing.T) {
 		t.Errorf("GetOutput should return %q (default) but returns %q", commonflags.RunOnDefault, res)
 	}
 }
+
+func TestRunOnIfSet(t *testing.T) {
+	ctx := context.TODO()
+	ctx = WithRunOn(ctx, commonflags.RunOnPodman)
+	res := GetRunOnIfSet(ctx)
+	if res != commonflags.RunOnPodman {
+		t.Errorf("GetRunOnIfSet should return %q but returns %q", commonflags.RunOnPodman, res)
+	}
+
+	ctx = context.TODO()
+	ctx = WithRunOn(ctx, "")
+	res = GetRunOnIfSet(ctx)
+	if res != "" {
+		t.Errorf("GetRunOnIfSet should return an empty string but returns %q", res)
+	}
+	res = GetRunOn(ctx)
+	if res != commonflags.RunOnDefault {
+		t.Errorf("GetRunOn should return %q (default) but returns %q", commonflags.RunOnDefault, res)
+	}
+}
@@ -11,13 +11,15 @@ import (
 
 	"github.com/redhat-developer/odo/pkg/odo/cli/feature"
 	"github.com/redhat-developer/odo/pkg/odo/cmdline"
+	"github.com/redhat-developer/odo/pkg/platform"
 )
 
 const (
 	// RunOnFlagName is the name of the flag allowing user to specify target platform
 	RunOnFlagName = "run-on"
-	RunOnCluster  = "cluster"
-	RunOnPodman   = "podman"
+	RunOnCluster  = platform.Cluster
+	RunOnPodman   = platform.Podman
+	RunOnDocker   = platform.Docker
 	RunOnDefault  = RunOnCluster
 )
 
@@ -35,7 +37,7 @@ func UseRunOnFlag(cmd *cobra.Command) {
 // package
 func AddRunOnFlag(ctx context.Context) {
 	if feature.IsEnabled(ctx, feature.GenericRunOnFlag) {
-		flag.CommandLine.String(RunOnFlagName, "", `Specify target platform, supported platforms: "cluster" (default), "podman" (experimental)`)
+		flag.CommandLine.String(RunOnFlagName, "", `Specify target platform, supported platforms: "cluster" (default), "podman" (experimental), "docker" (experimental)`)
 		_ = pflag.CommandLine.MarkHidden(RunOnFlagName)
 	}
 }
@@ -49,8 +51,8 @@ func CheckRunOnCommand(cmd *cobra.Command) error {
 	runOn := cmd.Annotations["runOn"]
 
 	// Check the valid output
-	if hasFlagChanged && runOnFlag.Value.String() != RunOnPodman && runOnFlag.Value.String() != RunOnCluster {
-		return fmt.Errorf(`%s is not a valid target platform for --run-on, please select either "cluster" (default) or "podman" (experimental)`, runOnFlag.Value.String())
+	if hasFlagChanged && runOnFlag.Value.String() != RunOnPodman && runOnFlag.Value.String() != RunOnCluster && runOnFlag.Value.String() != RunOnDocker {
+		return fmt.Errorf(`%s is not a valid target platform for --run-on, please select either "cluster" (default), "podman" (experimental) or "docker" (experimental)`, runOnFlag.Value.String())
 	}
 
 	// Check that if -o json has been passed, that the command actually USES json.. if not, error out.
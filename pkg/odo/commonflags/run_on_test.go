@@ -39,7 +39,20 @@ func TestUseRunOnFlagWrongValue(t *testing.T) {
 		t.Errorf("Set error should be nil but is %v", err)
 	}
 	err = CheckRunOnCommand(cmd)
-	if err.Error() != `wrong-value is not a valid target platform for --run-on, please select either "cluster" (default) or "podman" (experimental)` {
+	if err.Error() != `wrong-value is not a valid target platform for --run-on, please select either "cluster" (default), "podman" (experimental) or "docker" (experimental)` {
 		t.Errorf("Check error is %v", err)
 	}
 }
+
+func TestUseRunOnFlagDocker(t *testing.T) {
+	cmd := &cobra.Command{}
+	UseRunOnFlag(cmd)
+	err := pflag.CommandLine.Set("run-on", "docker")
+	if err != nil {
+		t.Errorf("Set error should be nil but is %v", err)
+	}
+	err = CheckRunOnCommand(cmd)
+	if err != nil {
+		t.Errorf("Check error should be nil but is %v", err)
+	}
+}
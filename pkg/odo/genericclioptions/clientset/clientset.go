@@ -12,14 +12,18 @@
 package clientset
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"k8s.io/klog"
 
+	envcontext "github.com/redhat-developer/odo/pkg/config/context"
 	"github.com/redhat-developer/odo/pkg/dev/kubedev"
 	"github.com/redhat-developer/odo/pkg/dev/podmandev"
 	"github.com/redhat-developer/odo/pkg/exec"
 	"github.com/redhat-developer/odo/pkg/logs"
+	"github.com/redhat-developer/odo/pkg/odo/cli/feature"
 	"github.com/redhat-developer/odo/pkg/odo/commonflags"
 	"github.com/redhat-developer/odo/pkg/podman"
 	"github.com/redhat-developer/odo/pkg/portForward"
@@ -32,6 +36,7 @@ import (
 	"github.com/redhat-developer/odo/pkg/binding"
 	_delete "github.com/redhat-developer/odo/pkg/component/delete"
 	"github.com/redhat-developer/odo/pkg/deploy"
+	"github.com/redhat-developer/odo/pkg/docker"
 	_init "github.com/redhat-developer/odo/pkg/init"
 	"github.com/redhat-developer/odo/pkg/kclient"
 	"github.com/redhat-developer/odo/pkg/preference"
@@ -66,6 +71,8 @@ const (
 	LOGS = "DEP_LOGS"
 	// PODMAN instantiates client for pkg/podman
 	PODMAN = "DEP_PODMAN"
+	// PODMAN_NULLABLE instantiates client for pkg/podman, can be nil when the platform is not Podman
+	PODMAN_NULLABLE = "DEP_PODMAN_NULLABLE"
 	// PORT_FORWARD instantiates client for pkg/portForward
 	PORT_FORWARD = "PORT_FORWARD"
 	// PREFERENCE instantiates client for pkg/preference
@@ -87,18 +94,18 @@ const (
 // Clients will be created only once and be reused for sub-dependencies
 var subdeps map[string][]string = map[string][]string{
 	ALIZER:           {REGISTRY},
-	DELETE_COMPONENT: {KUBERNETES_NULLABLE, EXEC},
-	DEPLOY:           {KUBERNETES, FILESYSTEM},
-	DEV:              {BINDING, DELETE_COMPONENT, EXEC, FILESYSTEM, KUBERNETES_NULLABLE, PODMAN, PORT_FORWARD, PREFERENCE, STATE, SYNC, WATCH},
+	DELETE_COMPONENT: {KUBERNETES_NULLABLE, PODMAN_NULLABLE, EXEC},
+	DEPLOY:           {KUBERNETES_NULLABLE, FILESYSTEM, PODMAN_NULLABLE},
+	DEV:              {BINDING, DELETE_COMPONENT, EXEC, FILESYSTEM, KUBERNETES_NULLABLE, PODMAN_NULLABLE, PORT_FORWARD, PREFERENCE, STATE, SYNC, WATCH},
 	EXEC:             {KUBERNETES_NULLABLE},
 	INIT:             {ALIZER, FILESYSTEM, PREFERENCE, REGISTRY},
-	LOGS:             {KUBERNETES_NULLABLE, PODMAN},
+	LOGS:             {KUBERNETES_NULLABLE, PODMAN_NULLABLE},
 	PORT_FORWARD:     {KUBERNETES_NULLABLE, STATE},
 	PROJECT:          {KUBERNETES},
 	REGISTRY:         {FILESYSTEM, PREFERENCE},
 	STATE:            {FILESYSTEM},
-	SYNC:             {EXEC},
-	WATCH:            {KUBERNETES_NULLABLE},
+	SYNC:             {EXEC, PREFERENCE},
+	WATCH:            {KUBERNETES_NULLABLE, PODMAN_NULLABLE, STATE},
 	BINDING:          {PROJECT, KUBERNETES_NULLABLE},
 	/* Add sub-dependencies here, if any */
 }
@@ -163,8 +170,22 @@ func Fetch(command *cobra.Command, platform string) (*Clientset, error) {
 		}
 
 	}
-	if isDefined(command, PODMAN) {
-		dep.PodmanClient = podman.NewPodmanCli()
+	if isDefined(command, PODMAN) || isDefined(command, PODMAN_NULLABLE) {
+		switch platform {
+		case commonflags.RunOnDocker:
+			// Docker is operated through the Podman client interface, pods being run as containers sharing a network namespace
+			dep.PodmanClient = docker.NewDockerCli()
+		default:
+			dep.PodmanClient, err = podman.NewClient(envcontext.GetEnvConfig(command.Context()))
+			if err != nil {
+				// only return error if the platform is Podman, or if PODMAN_NULLABLE is not defined in combination with PODMAN
+				if platform == commonflags.RunOnPodman || (isDefined(command, PODMAN) && !isDefined(command, PODMAN_NULLABLE)) {
+					return nil, err
+				}
+				klog.V(4).Infof("unable to create the Podman client: %v", err)
+				dep.PodmanClient = nil
+			}
+		}
 	}
 	if isDefined(command, PREFERENCE) {
 		dep.PreferenceClient, err = preference.NewClient(command.Context())
@@ -184,17 +205,26 @@ func Fetch(command *cobra.Command, platform string) (*Clientset, error) {
 		switch platform {
 		case commonflags.RunOnCluster:
 			dep.ExecClient = exec.NewExecClient(dep.KubernetesClient)
-		case commonflags.RunOnPodman:
+		case commonflags.RunOnPodman, commonflags.RunOnDocker:
 			dep.ExecClient = exec.NewExecClient(dep.PodmanClient)
 		default:
 			panic(fmt.Sprintf("not implemented yet for platform %q", platform))
 		}
 	}
 	if isDefined(command, DELETE_COMPONENT) {
-		dep.DeleteClient = _delete.NewDeleteComponentClient(dep.KubernetesClient, dep.ExecClient)
+		dep.DeleteClient = _delete.NewDeleteComponentClient(dep.KubernetesClient, dep.PodmanClient, dep.ExecClient)
 	}
 	if isDefined(command, DEPLOY) {
-		dep.DeployClient = deploy.NewDeployClient(dep.KubernetesClient, dep.FS)
+		switch platform {
+		case commonflags.RunOnCluster:
+			dep.DeployClient = deploy.NewDeployClient(dep.KubernetesClient, dep.FS)
+		case commonflags.RunOnPodman:
+			dep.DeployClient = deploy.NewPodmanDeployClient(dep.PodmanClient, dep.FS)
+		case commonflags.RunOnDocker:
+			return nil, fmt.Errorf("this command is not supported on platform %q", platform)
+		default:
+			panic(fmt.Sprintf("not implemented yet for platform %q", platform))
+		}
 	}
 	if isDefined(command, INIT) {
 		dep.InitClient = _init.NewInitClient(dep.FS, dep.PreferenceClient, dep.RegistryClient, dep.AlizerClient)
@@ -203,7 +233,7 @@ func Fetch(command *cobra.Command, platform string) (*Clientset, error) {
 		switch platform {
 		case commonflags.RunOnCluster:
 			dep.LogsClient = logs.NewLogsClient(dep.KubernetesClient)
-		case commonflags.RunOnPodman:
+		case commonflags.RunOnPodman, commonflags.RunOnDocker:
 			dep.LogsClient = logs.NewLogsClient(dep.PodmanClient)
 		default:
 			panic(fmt.Sprintf("not implemented yet for platform %q", platform))
@@ -218,15 +248,15 @@ func Fetch(command *cobra.Command, platform string) (*Clientset, error) {
 	if isDefined(command, SYNC) {
 		switch platform {
 		case commonflags.RunOnCluster:
-			dep.SyncClient = sync.NewSyncClient(dep.KubernetesClient, dep.ExecClient)
-		case commonflags.RunOnPodman:
-			dep.SyncClient = sync.NewSyncClient(dep.PodmanClient, dep.ExecClient)
+			dep.SyncClient = sync.NewSyncClient(dep.KubernetesClient, dep.ExecClient, dep.PreferenceClient)
+		case commonflags.RunOnPodman, commonflags.RunOnDocker:
+			dep.SyncClient = sync.NewSyncClient(dep.PodmanClient, dep.ExecClient, dep.PreferenceClient)
 		default:
 			panic(fmt.Sprintf("not implemented yet for platform %q", platform))
 		}
 	}
 	if isDefined(command, WATCH) {
-		dep.WatchClient = watch.NewWatchClient(dep.KubernetesClient)
+		dep.WatchClient = watch.NewWatchClient(dep.KubernetesClient, dep.PodmanClient, dep.StateClient)
 	}
 	if isDefined(command, BINDING) {
 		dep.BindingClient = binding.NewBindingClient(dep.ProjectClient, dep.KubernetesClient)
@@ -248,8 +278,9 @@ func Fetch(command *cobra.Command, platform string) (*Clientset, error) {
 				dep.ExecClient,
 				dep.DeleteClient,
 			)
-		case commonflags.RunOnPodman:
+		case commonflags.RunOnPodman, commonflags.RunOnDocker:
 			dep.DevClient = podmandev.NewDevClient(
+				platform,
 				dep.PodmanClient,
 				dep.SyncClient,
 				dep.ExecClient,
@@ -264,3 +295,20 @@ func Fetch(command *cobra.Command, platform string) (*Clientset, error) {
 	/* Instantiate new clients here. Take care to instantiate after all sub-dependencies */
 	return &dep, nil
 }
+
+// GetPlatformClients returns the clients to use to search for components on the platforms selected by the value of the --run-on flag.
+// When the flag is not set, components are searched on the cluster, and on Podman if the experimental mode is enabled.
+// A nil client is returned for a platform on which components must not be searched
+func GetPlatformClients(ctx context.Context, clientset *Clientset, runOnFlag string) (kclient.ClientInterface, podman.Client) {
+	switch runOnFlag {
+	case commonflags.RunOnCluster:
+		return clientset.KubernetesClient, nil
+	case commonflags.RunOnPodman, commonflags.RunOnDocker:
+		return nil, clientset.PodmanClient
+	default:
+		if feature.IsEnabled(ctx, feature.GenericRunOnFlag) {
+			return clientset.KubernetesClient, clientset.PodmanClient
+		}
+		return clientset.KubernetesClient, nil
+	}
+}
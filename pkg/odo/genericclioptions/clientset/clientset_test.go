@@ -0,0 +1,77 @@
+package clientset
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/redhat-developer/odo/pkg/config"
+	envcontext "github.com/redhat-developer/odo/pkg/config/context"
+	"github.com/redhat-developer/odo/pkg/odo/commonflags"
+	"github.com/redhat-developer/odo/pkg/podman"
+)
+
+func TestFetch_podmanClient(t *testing.T) {
+	invalidContainerHost := "tcp://127.0.0.1:8080"
+
+	tests := []struct {
+		name             string
+		dependencies     []string
+		platform         string
+		wantErr          bool
+		wantPodmanClient bool
+	}{
+		{
+			name:         "watch on the cluster with an invalid CONTAINER_HOST",
+			dependencies: []string{WATCH},
+			platform:     commonflags.RunOnCluster,
+		},
+		{
+			name:         "watch on Podman with an invalid CONTAINER_HOST",
+			dependencies: []string{WATCH},
+			platform:     commonflags.RunOnPodman,
+			wantErr:      true,
+		},
+		{
+			name:         "Podman required on the cluster with an invalid CONTAINER_HOST",
+			dependencies: []string{PODMAN},
+			platform:     commonflags.RunOnCluster,
+			wantErr:      true,
+		},
+		{
+			name:             "watch on Docker",
+			dependencies:     []string{WATCH},
+			platform:         commonflags.RunOnDocker,
+			wantPodmanClient: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// no cluster is accessible
+			t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "config"))
+
+			cmd := &cobra.Command{}
+			Add(cmd, tt.dependencies...)
+			cmd.SetContext(envcontext.WithEnvConfig(context.Background(), config.Configuration{
+				OdoPodmanBackend: podman.BackendSocket,
+				ContainerHost:    &invalidContainerHost,
+			}))
+
+			got, err := Fetch(cmd, tt.platform)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (got.PodmanClient != nil) != tt.wantPodmanClient {
+				t.Errorf("Fetch() PodmanClient = %v, want a client: %v", got.PodmanClient, tt.wantPodmanClient)
+			}
+			if got.WatchClient == nil {
+				t.Errorf("Fetch() WatchClient is nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package genericclioptions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/redhat-developer/odo/pkg/config"
+	"github.com/redhat-developer/odo/pkg/odo/cli/feature"
+	"github.com/redhat-developer/odo/pkg/odo/cmdline"
+	"github.com/redhat-developer/odo/pkg/odo/commonflags"
+	"github.com/redhat-developer/odo/pkg/podman"
+	"github.com/redhat-developer/odo/pkg/preference"
+)
+
+var (
+	lookPath = exec.LookPath
+	stat     = os.Stat
+)
+
+// getPlatform returns the platform on which the command runs: the value of the --run-on flag if set,
+// or else the Platform preference if set and if the command supports the --run-on flag, or else the default platform.
+// The returned boolean is true if the platform is defined by the preference.
+func getPlatform(ctx context.Context, cmd *cobra.Command, cmdline cmdline.Cmdline, userConfig preference.Client) (string, bool) {
+	if cmdline.FlagValueIfSet(commonflags.RunOnFlagName) != "" ||
+		userConfig == nil ||
+		cmd.Annotations["runOn"] != "true" ||
+		!feature.IsEnabled(ctx, feature.GenericRunOnFlag) {
+		return commonflags.GetRunOnValue(cmdline), false
+	}
+	if platform := userConfig.Platform(); platform != nil && *platform != "" {
+		return *platform, true
+	}
+	return commonflags.GetRunOnValue(cmdline), false
+}
+
+// CheckPlatform returns an error if the platform does not seem to be reachable:
+// the docker or podman binary (or the Podman socket) cannot be found, or no current context is defined in the kubeconfig.
+// The check does not connect to the platform.
+func CheckPlatform(envConfig config.Configuration, platform string) error {
+	switch platform {
+	case commonflags.RunOnDocker:
+		if _, err := lookPath("docker"); err != nil {
+			return fmt.Errorf("docker executable not found: %w", err)
+		}
+	case commonflags.RunOnPodman:
+		if envConfig.OdoPodmanBackend == podman.BackendSocket {
+			socketPath, err := podman.GetSocketPath(envConfig.ContainerHost, envConfig.XdgRuntimeDir)
+			if err != nil {
+				return err
+			}
+			if _, err = stat(socketPath); err != nil {
+				return fmt.Errorf("podman socket not found: %w", err)
+			}
+			return nil
+		}
+		if _, err := lookPath("podman"); err != nil {
+			return fmt.Errorf("podman executable not found: %w", err)
+		}
+	case commonflags.RunOnCluster:
+		kubeconfig, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
+		if err != nil {
+			return fmt.Errorf("unable to load kubeconfig: %w", err)
+		}
+		if kubeconfig.CurrentContext == "" {
+			return errors.New("no current context defined in kubeconfig")
+		}
+	}
+	return nil
+}
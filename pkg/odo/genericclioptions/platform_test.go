@@ -0,0 +1,253 @@
+package genericclioptions
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/cobra"
+	"k8s.io/utils/pointer"
+
+	"github.com/redhat-developer/odo/pkg/config"
+	envcontext "github.com/redhat-developer/odo/pkg/config/context"
+	"github.com/redhat-developer/odo/pkg/odo/cmdline"
+	"github.com/redhat-developer/odo/pkg/odo/commonflags"
+	"github.com/redhat-developer/odo/pkg/podman"
+	"github.com/redhat-developer/odo/pkg/preference"
+)
+
+func Test_getPlatform(t *testing.T) {
+	tests := []struct {
+		name               string
+		experimental       bool
+		runOnAnnotation    bool
+		flag               string
+		preference         *string
+		noPreferenceClient bool
+		want               string
+		wantFromPreference bool
+	}{
+		{
+			name:            "default platform",
+			experimental:    true,
+			runOnAnnotation: true,
+			want:            commonflags.RunOnDefault,
+		},
+		{
+			name:            "flag is used",
+			experimental:    true,
+			runOnAnnotation: true,
+			flag:            commonflags.RunOnPodman,
+			want:            commonflags.RunOnPodman,
+		},
+		{
+			name:            "flag takes precedence over preference",
+			experimental:    true,
+			runOnAnnotation: true,
+			flag:            commonflags.RunOnCluster,
+			preference:      pointer.String(commonflags.RunOnPodman),
+			want:            commonflags.RunOnCluster,
+		},
+		{
+			name:               "preference takes precedence over default platform",
+			experimental:       true,
+			runOnAnnotation:    true,
+			preference:         pointer.String(commonflags.RunOnDocker),
+			want:               commonflags.RunOnDocker,
+			wantFromPreference: true,
+		},
+		{
+			name:            "empty preference is ignored",
+			experimental:    true,
+			runOnAnnotation: true,
+			preference:      pointer.String(""),
+			want:            commonflags.RunOnDefault,
+		},
+		{
+			name:            "preference is ignored when the experimental mode is disabled",
+			runOnAnnotation: true,
+			preference:      pointer.String(commonflags.RunOnPodman),
+			want:            commonflags.RunOnDefault,
+		},
+		{
+			name:         "preference is ignored when the command does not support the --run-on flag",
+			experimental: true,
+			preference:   pointer.String(commonflags.RunOnPodman),
+			want:         commonflags.RunOnDefault,
+		},
+		{
+			name:               "no preference client",
+			experimental:       true,
+			runOnAnnotation:    true,
+			noPreferenceClient: true,
+			want:               commonflags.RunOnDefault,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ctx := envcontext.WithEnvConfig(context.Background(), config.Configuration{
+				OdoExperimentalMode: tt.experimental,
+			})
+			cmd := &cobra.Command{Annotations: map[string]string{}}
+			if tt.runOnAnnotation {
+				cmd.Annotations["runOn"] = "true"
+			}
+			cmdLine := cmdline.NewMockCmdline(ctrl)
+			cmdLine.EXPECT().FlagValueIfSet(commonflags.RunOnFlagName).Return(tt.flag).AnyTimes()
+			var userConfig preference.Client
+			if !tt.noPreferenceClient {
+				prefClient := preference.NewMockClient(ctrl)
+				prefClient.EXPECT().Platform().Return(tt.preference).AnyTimes()
+				userConfig = prefClient
+			}
+
+			got, gotFromPreference := getPlatform(ctx, cmd, cmdLine, userConfig)
+			if got != tt.want {
+				t.Errorf("getPlatform() = %q, want %q", got, tt.want)
+			}
+			if gotFromPreference != tt.wantFromPreference {
+				t.Errorf("getPlatform() fromPreference = %v, want %v", gotFromPreference, tt.wantFromPreference)
+			}
+		})
+	}
+}
+
+func TestCheckPlatform(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigWithContext := filepath.Join(tmpDir, "kubeconfig-with-context")
+	kubeconfigWithoutContext := filepath.Join(tmpDir, "kubeconfig-without-context")
+	for path, content := range map[string]string{
+		kubeconfigWithContext: `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:6443
+  name: mycluster
+contexts:
+- context:
+    cluster: mycluster
+  name: mycontext
+current-context: mycontext
+`,
+		kubeconfigWithoutContext: `apiVersion: v1
+kind: Config
+`,
+	} {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		envConfig  config.Configuration
+		platform   string
+		kubeconfig string
+		// executables are the executables found in the PATH
+		executables []string
+		// files are the existing files
+		files   []string
+		wantErr bool
+	}{
+		{
+			name:        "docker executable found",
+			platform:    commonflags.RunOnDocker,
+			executables: []string{"docker"},
+		},
+		{
+			name:        "docker executable not found",
+			platform:    commonflags.RunOnDocker,
+			executables: []string{"podman"},
+			wantErr:     true,
+		},
+		{
+			name:        "podman executable found",
+			envConfig:   config.Configuration{OdoPodmanBackend: podman.BackendCli},
+			platform:    commonflags.RunOnPodman,
+			executables: []string{"podman"},
+		},
+		{
+			name:        "podman executable not found",
+			envConfig:   config.Configuration{OdoPodmanBackend: podman.BackendCli},
+			platform:    commonflags.RunOnPodman,
+			executables: []string{"docker"},
+			wantErr:     true,
+		},
+		{
+			name: "podman socket found",
+			envConfig: config.Configuration{
+				OdoPodmanBackend: podman.BackendSocket,
+				XdgRuntimeDir:    pointer.String("/run/user/1000"),
+			},
+			platform: commonflags.RunOnPodman,
+			files:    []string{"/run/user/1000/podman/podman.sock"},
+		},
+		{
+			name: "podman socket not found, even if the podman executable is found",
+			envConfig: config.Configuration{
+				OdoPodmanBackend: podman.BackendSocket,
+				XdgRuntimeDir:    pointer.String("/run/user/1000"),
+			},
+			platform:    commonflags.RunOnPodman,
+			executables: []string{"podman"},
+			wantErr:     true,
+		},
+		{
+			name: "podman socket defined by an unsupported CONTAINER_HOST",
+			envConfig: config.Configuration{
+				OdoPodmanBackend: podman.BackendSocket,
+				ContainerHost:    pointer.String("tcp://127.0.0.1:8080"),
+			},
+			platform: commonflags.RunOnPodman,
+			wantErr:  true,
+		},
+		{
+			name:       "current context defined in kubeconfig",
+			platform:   commonflags.RunOnCluster,
+			kubeconfig: kubeconfigWithContext,
+		},
+		{
+			name:       "no current context defined in kubeconfig",
+			platform:   commonflags.RunOnCluster,
+			kubeconfig: kubeconfigWithoutContext,
+			wantErr:    true,
+		},
+	}
+	origLookPath, origStat := lookPath, stat
+	defer func() {
+		lookPath, stat = origLookPath, origStat
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookPath = func(file string) (string, error) {
+				for _, executable := range tt.executables {
+					if executable == file {
+						return "/usr/bin/" + file, nil
+					}
+				}
+				return "", errors.New("executable file not found in $PATH")
+			}
+			stat = func(name string) (fs.FileInfo, error) {
+				for _, file := range tt.files {
+					if file == name {
+						return nil, nil
+					}
+				}
+				return nil, fs.ErrNotExist
+			}
+			if tt.kubeconfig != "" {
+				t.Setenv("KUBECONFIG", tt.kubeconfig)
+			}
+
+			err := CheckPlatform(tt.envConfig, tt.platform)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckPlatform() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -194,7 +194,13 @@ func GenericRun(o Runnable, cmd *cobra.Command, args []string) error {
 	}
 
 	cmdLineObj := cmdline.NewCobra(cmd)
-	platform := commonflags.GetRunOnValue(cmdLineObj)
+	platform, fromPreference := getPlatform(ctx, cmd, cmdLineObj, userConfig)
+	if fromPreference {
+		klog.V(4).Infof("Using platform %q defined in preferences", platform)
+		if err = CheckPlatform(envConfig, platform); err != nil {
+			log.Warningf("Platform %q defined in preferences may not be reachable: %v", platform, err)
+		}
+	}
 	deps, err := clientset.Fetch(cmd, platform)
 	if err != nil {
 		return err
@@ -203,7 +209,12 @@ func GenericRun(o Runnable, cmd *cobra.Command, args []string) error {
 	o.SetClientset(deps)
 
 	ctx = fcontext.WithJsonOutput(ctx, commonflags.GetJsonOutputValue(cmdLineObj))
-	ctx = fcontext.WithRunOn(ctx, platform)
+	// the platform selected by the user, empty if the default platform is used
+	runOn := cmdLineObj.FlagValueIfSet(commonflags.RunOnFlagName)
+	if fromPreference {
+		runOn = platform
+	}
+	ctx = fcontext.WithRunOn(ctx, runOn)
 	ctx = odocontext.WithApplication(ctx, defaultAppName)
 
 	if deps.KubernetesClient != nil {
@@ -11,10 +11,14 @@ const (
 )
 
 // ApplyIgnore will take the current ignores []string and append the mandatory odo-file-index.json and
-// .git ignores; or find the .odoignore/.gitignore file in the directory and use that instead.
+// .git ignores; or read the .odoignore file of the directory, or if not found the .gitignore files of the directory
+// and its subdirectories, and use their rules instead.
 func ApplyIgnore(ignores *[]string, sourcePath string) (err error) {
 	if len(*ignores) == 0 {
-		rules, err := dfutil.GetIgnoreRulesFromDirectory(sourcePath)
+		if sourcePath == "" {
+			sourcePath = "."
+		}
+		rules, err := pkgUtil.GetIgnoreRulesFromDirectory(sourcePath)
 		if err != nil {
 			return err
 		}
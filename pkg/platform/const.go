@@ -0,0 +1,10 @@
+package platform
+
+const (
+	// Cluster is the name of the Kubernetes or OpenShift cluster platform
+	Cluster = "cluster"
+	// Podman is the name of the Podman platform
+	Podman = "podman"
+	// Docker is the name of the Docker platform
+	Docker = "docker"
+)
@@ -2,6 +2,7 @@ package platform
 
 import (
 	"io"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -18,6 +19,11 @@ type Client interface {
 	// All logs for all containers part of the pod are returned if an empty string is provided as container name.
 	GetPodLogs(podName, containerName string, followLog bool) (io.ReadCloser, error)
 
+	// FollowPodLogsSince follows the logs of the specified pod container emitted since the given time, or all its logs if since is zero.
+	// Each line is prefixed with its timestamp in RFC3339Nano format, followed by a space.
+	// The precision of since can be reduced to the second, so lines emitted at the second of since can be returned.
+	FollowPodLogsSince(podName, containerName string, since time.Time) (io.ReadCloser, error)
+
 	// GetPodsMatchingSelector returns all pods matching the given label selector.
 	GetPodsMatchingSelector(selector string) (*corev1.PodList, error)
 
@@ -0,0 +1,30 @@
+package podman
+
+import (
+	"fmt"
+
+	"github.com/redhat-developer/odo/pkg/config"
+)
+
+const (
+	// BackendCli is the backend executing the podman binary
+	BackendCli = "cli"
+	// BackendSocket is the backend communicating with the libpod REST API through the Podman socket
+	BackendSocket = "socket"
+)
+
+// NewClient returns a Podman client, using the backend defined by the ODO_PODMAN_BACKEND environment variable
+func NewClient(envConfig config.Configuration) (Client, error) {
+	switch envConfig.OdoPodmanBackend {
+	case BackendCli:
+		return NewPodmanCli(), nil
+	case BackendSocket:
+		socketPath, err := GetSocketPath(envConfig.ContainerHost, envConfig.XdgRuntimeDir)
+		if err != nil {
+			return nil, err
+		}
+		return NewPodmanSocket(socketPath), nil
+	default:
+		return nil, fmt.Errorf("invalid value %q for ODO_PODMAN_BACKEND, supported values are %q and %q", envConfig.OdoPodmanBackend, BackendCli, BackendSocket)
+	}
+}
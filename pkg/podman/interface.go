@@ -1,15 +1,19 @@
 package podman
 
 import (
+	"context"
 	"io"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type Client interface {
-	// PlayKube creates the Pod with Podman
-	PlayKube(pod *corev1.Pod) error
+	// PlayKube creates the Pod with Podman, along with the resources (ConfigMaps, Secrets and PersistentVolumeClaims)
+	// referenced by the pod
+	PlayKube(pod *corev1.Pod, resources []unstructured.Unstructured) error
 
 	// PodStop stops the pod with given podname
 	PodStop(podname string) error
@@ -17,6 +21,9 @@ type Client interface {
 	// PodRm deletes the pod with given podname
 	PodRm(podname string) error
 
+	// KubeGenerate returns the Kubernetes definition of the pod with the given name, including its volumes
+	KubeGenerate(name string) (*corev1.Pod, error)
+
 	// VolumeLs lists the names of existing volumes
 	VolumeLs() (map[string]bool, error)
 
@@ -29,6 +36,11 @@ type Client interface {
 	// All logs for all containers part of the pod are returned if an empty string is provided as container name.
 	GetPodLogs(podName, containerName string, followLog bool) (io.ReadCloser, error)
 
+	// FollowPodLogsSince follows the logs of the specified pod container emitted since the given time, or all its logs if since is zero.
+	// Each line is prefixed with its timestamp in RFC3339Nano format, followed by a space.
+	// The precision of since can be reduced to the second, so lines emitted at the second of since can be returned.
+	FollowPodLogsSince(podName, containerName string, since time.Time) (io.ReadCloser, error)
+
 	// GetPodsMatchingSelector returns all pods matching the given label selector.
 	GetPodsMatchingSelector(selector string) (*corev1.PodList, error)
 
@@ -38,7 +50,11 @@ type Client interface {
 	// GetAllPodsInNamespaceMatchingSelector returns all pods matching the given label selector and in the specified namespace.
 	GetAllPodsInNamespaceMatchingSelector(selector string, ns string) (*corev1.PodList, error)
 
-	// GetRunningPodFromSelector returns any pod matching the given label selector.
-	// If multiple pods are found, implementations might have different behavior, by either returning an error or returning any element.
+	// PodWatcher returns a watcher on the pods matching the given label selector.
+	// The statuses of the pods describe the states of their containers.
+	PodWatcher(ctx context.Context, selector string) (watch.Interface, error)
+
+	// GetRunningPodFromSelector returns the running pod matching the given label selector.
+	// A kclient.PodNotFoundError is returned if no running pod is found, and an error if several running pods are found.
 	GetRunningPodFromSelector(selector string) (*corev1.Pod, error)
 }
@@ -0,0 +1,52 @@
+package podman
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/kubectl/pkg/scheme"
+)
+
+func (o *PodmanCli) KubeGenerate(name string) (*corev1.Pod, error) {
+	out, err := exec.Command("podman", "generate", "kube", name).Output()
+	if err != nil {
+		if exiterr, ok := err.(*exec.ExitError); ok {
+			err = fmt.Errorf("%s: %s", err, string(exiterr.Stderr))
+		}
+		return nil, err
+	}
+	return parseKube(out)
+}
+
+// parseKube returns the pod defined in the YAML output of `podman generate kube`,
+// which can contain several documents
+func parseKube(content []byte) (*corev1.Pod, error) {
+	reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	decoder := scheme.Codecs.UniversalDeserializer()
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if pod, ok := obj.(*corev1.Pod); ok {
+			return pod, nil
+		}
+	}
+	return nil, errors.New("no pod definition found")
+}
@@ -0,0 +1,88 @@
+package podman
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseKube(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantName    string
+		wantVolumes []string
+		wantErr     bool
+	}{
+		{
+			name: "pod with volumes",
+			content: `# Save the output of this file and use kubectl create -f to import
+# it into Kubernetes.
+apiVersion: v1
+kind: Pod
+metadata:
+  name: mycmp-app
+spec:
+  containers:
+  - name: runtime
+    image: registry.access.redhat.com/ubi8/nodejs-16
+  volumes:
+  - name: odo-projects-mycmp-app-pvc
+    persistentVolumeClaim:
+      claimName: odo-projects-mycmp-app
+  - name: odo-shared-data-mycmp-app-pvc
+    persistentVolumeClaim:
+      claimName: odo-shared-data-mycmp-app
+`,
+			wantName:    "mycmp-app",
+			wantVolumes: []string{"odo-projects-mycmp-app", "odo-shared-data-mycmp-app"},
+		},
+		{
+			name: "service before pod",
+			content: `apiVersion: v1
+kind: Service
+metadata:
+  name: mycmp-app
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: mycmp-app
+`,
+			wantName: "mycmp-app",
+		},
+		{
+			name: "no pod",
+			content: `apiVersion: v1
+kind: Service
+metadata:
+  name: mycmp-app
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseKube([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseKube() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.GetName() != tt.wantName {
+				t.Errorf("parseKube() name = %q, want %q", got.GetName(), tt.wantName)
+			}
+			var gotVolumes []string
+			for _, volume := range got.Spec.Volumes {
+				if volume.VolumeSource.PersistentVolumeClaim != nil {
+					gotVolumes = append(gotVolumes, volume.PersistentVolumeClaim.ClaimName)
+				}
+			}
+			if diff := cmp.Diff(tt.wantVolumes, gotVolumes); diff != "" {
+				t.Errorf("parseKube() volumes mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,10 +1,84 @@
 package podman
 
-import "io"
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"k8s.io/klog"
+)
 
 // GetPodLogs returns the logs of the specified pod container.
 // All logs for all containers part of the pod are returned if an empty string is provided as container name.
 func (o *PodmanCli) GetPodLogs(podName, containerName string, followLog bool) (io.ReadCloser, error) {
-	// TODO(feloy)
-	return nil, nil
+	return runLogs(getLogsArgs(podName, containerName, followLog))
+}
+
+// FollowPodLogsSince follows the logs of the pod container emitted since the given time, or all its logs if since is zero,
+// each line being prefixed with its timestamp
+func (o *PodmanCli) FollowPodLogsSince(podName, containerName string, since time.Time) (io.ReadCloser, error) {
+	return runLogs(getFollowLogsSinceArgs(podName, containerName, since))
+}
+
+// runLogs starts the `podman logs` command with the given arguments, and returns its output
+func runLogs(args []string) (io.ReadCloser, error) {
+	cmd := exec.Command("podman", args...)
+	klog.V(4).Infof("executing podman %v", args)
+
+	// podman logs writes the container stdout and stderr on its own stdout and stderr,
+	// both are merged into the returned stream
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		_ = pw.CloseWithError(cmd.Wait())
+	}()
+
+	return &logsReader{
+		PipeReader: pr,
+		cmd:        cmd,
+	}, nil
+}
+
+// getLogsArgs returns the arguments to pass to podman to get the logs of the pod container,
+// or of all containers of the pod if containerName is empty
+func getLogsArgs(podName, containerName string, followLog bool) []string {
+	args := []string{"logs"}
+	if followLog {
+		args = append(args, "--follow")
+	}
+	if containerName == "" {
+		return append(append([]string{"pod"}, args...), podName)
+	}
+	return append(args, fmt.Sprintf("%s-%s", podName, containerName))
+}
+
+// getFollowLogsSinceArgs returns the arguments to pass to podman to follow the timestamped logs of the pod container,
+// emitted since the given time if not zero
+func getFollowLogsSinceArgs(podName, containerName string, since time.Time) []string {
+	args := []string{"logs", "--follow", "--timestamps"}
+	if !since.IsZero() {
+		args = append(args, "--since", strconv.FormatInt(since.Unix(), 10))
+	}
+	return append(args, fmt.Sprintf("%s-%s", podName, containerName))
+}
+
+// logsReader reads the output of a `podman logs` command.
+// Closing it stops the command, if still running.
+type logsReader struct {
+	*io.PipeReader
+	cmd *exec.Cmd
+}
+
+func (o *logsReader) Close() error {
+	// Kill returns an error if the process already exited, which can be ignored
+	_ = o.cmd.Process.Kill()
+	return o.PipeReader.Close()
 }
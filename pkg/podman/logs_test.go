@@ -0,0 +1,72 @@
+package podman
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_getLogsArgs(t *testing.T) {
+	tests := []struct {
+		name          string
+		containerName string
+		followLog     bool
+		want          []string
+	}{
+		{
+			name:          "logs of a container",
+			containerName: "runtime",
+			want:          []string{"logs", "mycmp-app-runtime"},
+		},
+		{
+			name:          "follow logs of a container",
+			containerName: "runtime",
+			followLog:     true,
+			want:          []string{"logs", "--follow", "mycmp-app-runtime"},
+		},
+		{
+			name: "logs of all containers of the pod",
+			want: []string{"pod", "logs", "mycmp-app"},
+		},
+		{
+			name:      "follow logs of all containers of the pod",
+			followLog: true,
+			want:      []string{"pod", "logs", "--follow", "mycmp-app"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getLogsArgs("mycmp-app", tt.containerName, tt.followLog)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("getLogsArgs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_getFollowLogsSinceArgs(t *testing.T) {
+	tests := []struct {
+		name  string
+		since time.Time
+		want  []string
+	}{
+		{
+			name: "all logs",
+			want: []string{"logs", "--follow", "--timestamps", "mycmp-app-runtime"},
+		},
+		{
+			name:  "logs since a time",
+			since: time.Date(2023, 1, 2, 10, 0, 0, 500000000, time.UTC),
+			want:  []string{"logs", "--follow", "--timestamps", "--since", "1672653600", "mycmp-app-runtime"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getFollowLogsSinceArgs("mycmp-app", "runtime", tt.since)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("getFollowLogsSinceArgs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -5,12 +5,15 @@
 package podman
 
 import (
+	context "context"
 	io "io"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	v1 "k8s.io/api/core/v1"
 	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	watch "k8s.io/apimachinery/pkg/watch"
 )
 
 // MockClient is a mock of Client interface.
@@ -80,6 +83,21 @@ func (mr *MockClientMockRecorder) GetAllResourcesFromSelector(selector, ns inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllResourcesFromSelector", reflect.TypeOf((*MockClient)(nil).GetAllResourcesFromSelector), selector, ns)
 }
 
+// FollowPodLogsSince mocks base method.
+func (m *MockClient) FollowPodLogsSince(podName, containerName string, since time.Time) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowPodLogsSince", podName, containerName, since)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FollowPodLogsSince indicates an expected call of FollowPodLogsSince.
+func (mr *MockClientMockRecorder) FollowPodLogsSince(podName, containerName, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowPodLogsSince", reflect.TypeOf((*MockClient)(nil).FollowPodLogsSince), podName, containerName, since)
+}
+
 // GetPodLogs mocks base method.
 func (m *MockClient) GetPodLogs(podName, containerName string, followLog bool) (io.ReadCloser, error) {
 	m.ctrl.T.Helper()
@@ -125,18 +143,33 @@ func (mr *MockClientMockRecorder) GetRunningPodFromSelector(selector interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningPodFromSelector", reflect.TypeOf((*MockClient)(nil).GetRunningPodFromSelector), selector)
 }
 
+// KubeGenerate mocks base method.
+func (m *MockClient) KubeGenerate(name string) (*v1.Pod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KubeGenerate", name)
+	ret0, _ := ret[0].(*v1.Pod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KubeGenerate indicates an expected call of KubeGenerate.
+func (mr *MockClientMockRecorder) KubeGenerate(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KubeGenerate", reflect.TypeOf((*MockClient)(nil).KubeGenerate), name)
+}
+
 // PlayKube mocks base method.
-func (m *MockClient) PlayKube(pod *v1.Pod) error {
+func (m *MockClient) PlayKube(pod *v1.Pod, resources []unstructured.Unstructured) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PlayKube", pod)
+	ret := m.ctrl.Call(m, "PlayKube", pod, resources)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // PlayKube indicates an expected call of PlayKube.
-func (mr *MockClientMockRecorder) PlayKube(pod interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) PlayKube(pod, resources interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlayKube", reflect.TypeOf((*MockClient)(nil).PlayKube), pod)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlayKube", reflect.TypeOf((*MockClient)(nil).PlayKube), pod, resources)
 }
 
 // PodRm mocks base method.
@@ -167,6 +200,21 @@ func (mr *MockClientMockRecorder) PodStop(podname interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PodStop", reflect.TypeOf((*MockClient)(nil).PodStop), podname)
 }
 
+// PodWatcher mocks base method.
+func (m *MockClient) PodWatcher(ctx context.Context, selector string) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PodWatcher", ctx, selector)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PodWatcher indicates an expected call of PodWatcher.
+func (mr *MockClientMockRecorder) PodWatcher(ctx, selector interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PodWatcher", reflect.TypeOf((*MockClient)(nil).PodWatcher), ctx, selector)
+}
+
 // VolumeLs mocks base method.
 func (m *MockClient) VolumeLs() (map[string]bool, error) {
 	m.ctrl.T.Helper()
@@ -3,10 +3,12 @@ package podman
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	jsonserializer "k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"k8s.io/klog"
 	"k8s.io/kubectl/pkg/scheme"
@@ -18,8 +20,9 @@ func NewPodmanCli() *PodmanCli {
 	return &PodmanCli{}
 }
 
-func (o *PodmanCli) PlayKube(pod *corev1.Pod) error {
-	serializer := jsonserializer.NewSerializerWithOptions(
+// newYamlSerializer returns a serializer encoding Kubernetes resources as YAML, as expected by `podman play kube`
+func newYamlSerializer() *jsonserializer.Serializer {
+	return jsonserializer.NewSerializerWithOptions(
 		jsonserializer.SimpleMetaFactory{},
 		scheme.Scheme,
 		scheme.Scheme,
@@ -27,7 +30,25 @@ func (o *PodmanCli) PlayKube(pod *corev1.Pod) error {
 			Yaml: true,
 		},
 	)
+}
+
+// encodeKube writes the YAML definitions of resources followed by the one of pod, as a multi-document YAML
+func encodeKube(w io.Writer, pod *corev1.Pod, resources []unstructured.Unstructured) error {
+	serializer := newYamlSerializer()
+	for i := range resources {
+		err := serializer.Encode(&resources[i], w)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, "---\n")
+		if err != nil {
+			return err
+		}
+	}
+	return serializer.Encode(pod, w)
+}
 
+func (o *PodmanCli) PlayKube(pod *corev1.Pod, resources []unstructured.Unstructured) error {
 	cmd := exec.Command("podman", "play", "kube", "-")
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
@@ -44,7 +65,7 @@ func (o *PodmanCli) PlayKube(pod *corev1.Pod) error {
 		return err
 	}
 
-	err = serializer.Encode(pod, stdin)
+	err = encodeKube(stdin, pod, resources)
 	if err != nil {
 		return err
 	}
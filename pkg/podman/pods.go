@@ -1,31 +1,198 @@
 package podman
 
 import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+
+	"github.com/redhat-developer/odo/pkg/kclient"
 )
 
+// ListPodsReport is the subset of the `podman pod ps --format json` output used by odo
+type ListPodsReport struct {
+	Name       string
+	ID         string `json:"Id"`
+	InfraID    string `json:"InfraId"`
+	Status     string
+	Labels     map[string]string
+	Containers []ListPodContainer
+}
+
+// ListPodContainer is the description of a container, part of the `podman pod ps --format json` output
+type ListPodContainer struct {
+	ID     string `json:"Id"`
+	Names  string
+	Status string
+}
+
 // GetPodsMatchingSelector returns all pods matching the given label selector.
 func (o *PodmanCli) GetPodsMatchingSelector(selector string) (*corev1.PodList, error) {
-	// TODO(feloy) when pod is created with labels
-	return nil, nil
+	podReports, err := o.podPs()
+	if err != nil {
+		return nil, err
+	}
+	return toPodList(podReports, selector)
 }
 
 // GetAllResourcesFromSelector returns all resources of any kind matching the given label selector.
+// Only pods are returned, as pods are the only resources labelled by odo on Podman.
 func (o *PodmanCli) GetAllResourcesFromSelector(selector string, ns string) ([]unstructured.Unstructured, error) {
-	// TODO(feloy) when pod is created with labels
-	return nil, nil
+	pods, err := o.GetPodsMatchingSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return toUnstructuredList(pods)
 }
 
 // GetAllPodsInNamespaceMatchingSelector returns all pods matching the given label selector and in the specified namespace.
+// Podman has no notion of namespace, so the namespace is ignored.
 func (o *PodmanCli) GetAllPodsInNamespaceMatchingSelector(selector string, ns string) (*corev1.PodList, error) {
-	// TODO(feloy) when pod is created with labels
-	return nil, nil
+	return o.GetPodsMatchingSelector(selector)
 }
 
-// GetRunningPodFromSelector returns any pod matching the given label selector.
-// If multiple pods are found, implementations might have different behavior, by either returning an error or returning any element.
+// GetRunningPodFromSelector returns the running pod matching the given label selector.
+// A kclient.PodNotFoundError is returned if no running pod is found, and an error if several running pods are found.
 func (o *PodmanCli) GetRunningPodFromSelector(selector string) (*corev1.Pod, error) {
-	// TODO(feloy) when pod is created with labels
-	return nil, nil
+	pods, err := o.GetPodsMatchingSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return GetRunningPod(pods, selector)
+}
+
+// podPs returns the list of pods, as reported by `podman pod ps`
+func (o *PodmanCli) podPs() ([]ListPodsReport, error) {
+	out, err := exec.Command("podman", "pod", "ps", "--format", "json").Output()
+	if err != nil {
+		if exiterr, ok := err.(*exec.ExitError); ok {
+			err = fmt.Errorf("%s: %s", err, string(exiterr.Stderr))
+		}
+		return nil, err
+	}
+	var result []ListPodsReport
+	err = json.Unmarshal(out, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse output of podman pod ps: %w", err)
+	}
+	klog.V(4).Infof("%d pods found in podman", len(result))
+	return result, nil
+}
+
+// toPodList returns the pods from podReports matching selector, as Kubernetes pods
+func toPodList(podReports []ListPodsReport, selector string) (*corev1.PodList, error) {
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return nil, err
+	}
+	result := corev1.PodList{}
+	for _, podReport := range podReports {
+		if !sel.Matches(labels.Set(podReport.Labels)) {
+			continue
+		}
+		result.Items = append(result.Items, toPod(podReport))
+	}
+	return &result, nil
+}
+
+// toUnstructuredList returns the pods as unstructured resources
+func toUnstructuredList(pods *corev1.PodList) ([]unstructured.Unstructured, error) {
+	result := make([]unstructured.Unstructured, 0, len(pods.Items))
+	for i := range pods.Items {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&pods.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, unstructured.Unstructured{Object: content})
+	}
+	return result, nil
+}
+
+// GetRunningPod returns the only running pod of the list, as the kubernetes client does.
+// A PodNotFoundError is returned if no pod is running, and an error if several pods are running
+func GetRunningPod(pods *corev1.PodList, selector string) (*corev1.Pod, error) {
+	var result *corev1.Pod
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase != corev1.PodRunning {
+			continue
+		}
+		if result != nil {
+			return nil, fmt.Errorf("multiple Pods exist for the selector: %v. Only one must be present", selector)
+		}
+		result = &pods.Items[i]
+	}
+	if result == nil {
+		return nil, &kclient.PodNotFoundError{Selector: selector}
+	}
+	return result, nil
+}
+
+// toPod returns a Kubernetes pod describing the podman pod.
+// The infra container is not part of the returned containers, and the names of the containers
+// are returned without the pod name prefix added by `podman play kube`.
+func toPod(podReport ListPodsReport) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   podReport.Name,
+			UID:    types.UID(podReport.ID),
+			Labels: podReport.Labels,
+		},
+		Status: corev1.PodStatus{
+			Phase: toPodPhase(podReport.Status),
+		},
+	}
+	pod.APIVersion, pod.Kind = corev1.SchemeGroupVersion.WithKind("Pod").ToAPIVersionAndKind()
+
+	for _, container := range podReport.Containers {
+		if container.ID == podReport.InfraID {
+			continue
+		}
+		name := strings.TrimPrefix(container.Names, podReport.Name+"-")
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+			Name: name,
+		})
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, toContainerStatus(name, container))
+	}
+	return pod
+}
+
+// toPodPhase converts a podman pod status into a Kubernetes pod phase
+func toPodPhase(status string) corev1.PodPhase {
+	switch strings.ToLower(status) {
+	case "running", "degraded":
+		return corev1.PodRunning
+	case "created", "paused":
+		return corev1.PodPending
+	case "exited", "stopped":
+		return corev1.PodSucceeded
+	case "dead", "error":
+		return corev1.PodFailed
+	default:
+		return corev1.PodUnknown
+	}
+}
+
+func toContainerStatus(name string, container ListPodContainer) corev1.ContainerStatus {
+	status := corev1.ContainerStatus{
+		Name:        name,
+		ContainerID: container.ID,
+	}
+	switch strings.ToLower(container.Status) {
+	case "running":
+		status.Ready = true
+		status.State.Running = &corev1.ContainerStateRunning{}
+	case "created", "configured", "initialized", "paused":
+		status.State.Waiting = &corev1.ContainerStateWaiting{Reason: container.Status}
+	default:
+		status.State.Terminated = &corev1.ContainerStateTerminated{Reason: container.Status}
+	}
+	return status
 }
@@ -0,0 +1,208 @@
+package podman
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/redhat-developer/odo/pkg/kclient"
+)
+
+func Test_toPodList(t *testing.T) {
+	podReports := []ListPodsReport{
+		{
+			Name:    "mycmp-app",
+			ID:      "pod1",
+			InfraID: "infra1",
+			Status:  "Running",
+			Labels: map[string]string{
+				"component":    "mycmp",
+				"odo.dev/mode": "Dev",
+			},
+			Containers: []ListPodContainer{
+				{
+					ID:     "infra1",
+					Names:  "pod1-infra",
+					Status: "running",
+				},
+				{
+					ID:     "ctr1",
+					Names:  "mycmp-app-runtime",
+					Status: "running",
+				},
+				{
+					ID:     "ctr2",
+					Names:  "mycmp-app-tools",
+					Status: "exited",
+				},
+			},
+		},
+		{
+			Name:   "other-app",
+			ID:     "pod2",
+			Status: "Exited",
+			Labels: map[string]string{
+				"component":    "other",
+				"odo.dev/mode": "Dev",
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		selector  string
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "empty selector returns all pods",
+			selector:  "",
+			wantNames: []string{"mycmp-app", "other-app"},
+		},
+		{
+			name:      "selector matching one pod",
+			selector:  "component=mycmp,odo.dev/mode=Dev",
+			wantNames: []string{"mycmp-app"},
+		},
+		{
+			name:     "selector matching no pod",
+			selector: "component=mycmp,odo.dev/mode=Deploy",
+		},
+		{
+			name:     "unparsable selector",
+			selector: "component in",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toPodList(podReports, tt.selector)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toPodList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			var gotNames []string
+			for _, pod := range got.Items {
+				gotNames = append(gotNames, pod.GetName())
+			}
+			if diff := cmp.Diff(tt.wantNames, gotNames, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("toPodList() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_toPod(t *testing.T) {
+	podReport := ListPodsReport{
+		Name:    "mycmp-app",
+		ID:      "pod1",
+		InfraID: "infra1",
+		Status:  "Degraded",
+		Containers: []ListPodContainer{
+			{
+				ID:     "infra1",
+				Names:  "pod1-infra",
+				Status: "running",
+			},
+			{
+				ID:     "ctr1",
+				Names:  "mycmp-app-runtime",
+				Status: "running",
+			},
+			{
+				ID:     "ctr2",
+				Names:  "mycmp-app-tools",
+				Status: "exited",
+			},
+		},
+	}
+
+	got := toPod(podReport)
+
+	if got.Status.Phase != corev1.PodRunning {
+		t.Errorf("toPod() phase = %v, want %v", got.Status.Phase, corev1.PodRunning)
+	}
+	wantContainers := []corev1.Container{{Name: "runtime"}, {Name: "tools"}}
+	if diff := cmp.Diff(wantContainers, got.Spec.Containers); diff != "" {
+		t.Errorf("toPod() containers mismatch (-want +got):\n%s", diff)
+	}
+	if len(got.Status.ContainerStatuses) != 2 {
+		t.Fatalf("toPod() got %d container statuses, want 2", len(got.Status.ContainerStatuses))
+	}
+	if !got.Status.ContainerStatuses[0].Ready || got.Status.ContainerStatuses[0].State.Running == nil {
+		t.Errorf("toPod() container runtime should be running")
+	}
+	if got.Status.ContainerStatuses[1].Ready || got.Status.ContainerStatuses[1].State.Terminated == nil {
+		t.Errorf("toPod() container tools should be terminated")
+	}
+}
+
+func TestGetRunningPod(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		pods            []corev1.Pod
+		wantName        string
+		wantNotFoundErr bool
+		wantErr         bool
+	}{
+		{
+			name:     "one running pod",
+			pods:     []corev1.Pod{pod("mycmp-app", corev1.PodRunning)},
+			wantName: "mycmp-app",
+		},
+		{
+			name:     "one running pod and a stopped pod",
+			pods:     []corev1.Pod{pod("old-app", corev1.PodSucceeded), pod("mycmp-app", corev1.PodRunning)},
+			wantName: "mycmp-app",
+		},
+		{
+			name:            "no pod",
+			wantNotFoundErr: true,
+			wantErr:         true,
+		},
+		{
+			name:            "no running pod",
+			pods:            []corev1.Pod{pod("mycmp-app", corev1.PodPending)},
+			wantNotFoundErr: true,
+			wantErr:         true,
+		},
+		{
+			name:    "several running pods",
+			pods:    []corev1.Pod{pod("mycmp-app", corev1.PodRunning), pod("other-app", corev1.PodRunning)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetRunningPod(&corev1.PodList{Items: tt.pods}, "component=mycmp")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRunningPod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var notFoundErr *kclient.PodNotFoundError
+			if errors.As(err, &notFoundErr) != tt.wantNotFoundErr {
+				t.Errorf("GetRunningPod() error = %v, want PodNotFoundError %v", err, tt.wantNotFoundErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.GetName() != tt.wantName {
+				t.Errorf("GetRunningPod() = %q, want %q", got.GetName(), tt.wantName)
+			}
+		})
+	}
+}
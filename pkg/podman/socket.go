@@ -0,0 +1,244 @@
+package podman
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog"
+)
+
+const (
+	// apiPrefix is the prefix of the libpod REST API paths
+	apiPrefix = "/v4.0.0/libpod"
+
+	// defaultRootSocketPath is the path of the Podman socket for the root user
+	defaultRootSocketPath = "/run/podman/podman.sock"
+)
+
+// PodmanSocket is an implementation of Client communicating with the libpod REST API
+// exposed by the Podman service on a unix socket
+type PodmanSocket struct {
+	socketPath string
+	httpClient *http.Client
+}
+
+var _ Client = (*PodmanSocket)(nil)
+
+func NewPodmanSocket(socketPath string) *PodmanSocket {
+	o := &PodmanSocket{
+		socketPath: socketPath,
+	}
+	o.httpClient = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", o.socketPath)
+			},
+		},
+	}
+	return o
+}
+
+// GetSocketPath returns the path of the Podman socket.
+// containerHost is the value of the CONTAINER_HOST environment variable, if defined, which must use the unix scheme.
+// Otherwise, the rootless socket is used if xdgRuntimeDir (the value of XDG_RUNTIME_DIR) is defined, or the root socket.
+func GetSocketPath(containerHost *string, xdgRuntimeDir *string) (string, error) {
+	if containerHost != nil && *containerHost != "" {
+		u, err := url.Parse(*containerHost)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse CONTAINER_HOST %q: %w", *containerHost, err)
+		}
+		if u.Scheme != "unix" {
+			return "", fmt.Errorf("scheme %q of CONTAINER_HOST is not supported, only unix sockets are supported", u.Scheme)
+		}
+		return u.Path, nil
+	}
+	if xdgRuntimeDir != nil && *xdgRuntimeDir != "" {
+		return filepath.Join(*xdgRuntimeDir, "podman", "podman.sock"), nil
+	}
+	return defaultRootSocketPath, nil
+}
+
+// APIError is an error returned by the libpod REST API
+type APIError struct {
+	StatusCode int
+	Cause      string `json:"cause"`
+	Message    string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("podman API returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound returns true if err is an APIError indicating that the resource is not found
+func IsNotFound(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// ExecError is returned when a command executed into a container exits with a non-zero status
+type ExecError struct {
+	ExitCode int
+}
+
+func (e *ExecError) Error() string {
+	return fmt.Sprintf("command exited with status %d", e.ExitCode)
+}
+
+// apiURL returns the URL of the API for path
+func apiURL(path string) string {
+	// the host is ignored, as connections are made on the unix socket
+	return "http://d" + apiPrefix + path
+}
+
+// newAPIError builds an APIError from the body of an error response
+func newAPIError(resp *http.Response) error {
+	apiErr := APIError{
+		StatusCode: resp.StatusCode,
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(body, &apiErr); err != nil || apiErr.Message == "" {
+		apiErr.Message = string(bytes.TrimSpace(body))
+	}
+	return &apiErr
+}
+
+// do executes a request on the API and returns the response if its status is one of the expected statuses.
+// Otherwise, an APIError is returned
+func (o *PodmanSocket) do(method string, path string, contentType string, body io.Reader, expectedStatuses ...int) (*http.Response, error) {
+	return o.doContext(context.Background(), method, path, contentType, body, expectedStatuses...)
+}
+
+// doContext executes a request on the API, as do, the request being cancelled when ctx is done
+func (o *PodmanSocket) doContext(ctx context.Context, method string, path string, contentType string, body io.Reader, expectedStatuses ...int) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, apiURL(path), body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	klog.V(4).Infof("podman API request: %s %s", method, path)
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the podman socket %q: %w", o.socketPath, err)
+	}
+	for _, status := range expectedStatuses {
+		if resp.StatusCode == status {
+			return resp, nil
+		}
+	}
+	defer resp.Body.Close()
+	return nil, newAPIError(resp)
+}
+
+// doJSON executes a request on the API, with in encoded as JSON as body if not nil,
+// and decodes the response into out, if not nil
+func (o *PodmanSocket) doJSON(method string, path string, in interface{}, out interface{}, expectedStatuses ...int) error {
+	var body io.Reader
+	var contentType string
+	if in != nil {
+		buf, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(buf)
+		contentType = "application/json"
+	}
+	resp, err := o.do(method, path, contentType, body, expectedStatuses...)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (o *PodmanSocket) PlayKube(pod *corev1.Pod, resources []unstructured.Unstructured) error {
+	var buf bytes.Buffer
+	err := encodeKube(&buf, pod, resources)
+	if err != nil {
+		return err
+	}
+	resp, err := o.do(http.MethodPost, "/play/kube", "application/x-yaml", &buf, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	out, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Info(string(out))
+	return nil
+}
+
+func (o *PodmanSocket) PodStop(podname string) error {
+	err := o.doJSON(http.MethodPost, "/pods/"+url.PathEscape(podname)+"/stop", nil, nil, http.StatusOK, http.StatusNotModified)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("Stopped pod %s", podname)
+	return nil
+}
+
+func (o *PodmanSocket) PodRm(podname string) error {
+	err := o.doJSON(http.MethodDelete, "/pods/"+url.PathEscape(podname), nil, nil, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("Deleted pod %s", podname)
+	return nil
+}
+
+func (o *PodmanSocket) KubeGenerate(name string) (*corev1.Pod, error) {
+	resp, err := o.do(http.MethodGet, "/generate/kube?names="+url.QueryEscape(name), "", nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	out, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseKube(out)
+}
+
+func (o *PodmanSocket) VolumeRm(volumeName string) error {
+	err := o.doJSON(http.MethodDelete, "/volumes/"+url.PathEscape(volumeName), nil, nil, http.StatusNoContent)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("Deleted volume %s", volumeName)
+	return nil
+}
+
+func (o *PodmanSocket) VolumeLs() (map[string]bool, error) {
+	var volumes []struct {
+		Name string
+	}
+	err := o.doJSON(http.MethodGet, "/volumes/json", nil, &volumes, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]bool, len(volumes))
+	for _, volume := range volumes {
+		result[volume.Name] = true
+	}
+	return result, nil
+}
@@ -0,0 +1,123 @@
+package podman
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+
+	"k8s.io/klog"
+)
+
+type execCreateRequest struct {
+	AttachStdin  bool
+	AttachStdout bool
+	AttachStderr bool
+	Cmd          []string
+	Tty          bool
+}
+
+type execCreateResponse struct {
+	ID string `json:"Id"`
+}
+
+type execStartRequest struct {
+	Detach bool
+	Tty    bool
+}
+
+type execInspectResponse struct {
+	ExitCode int
+	Running  bool
+}
+
+func (o *PodmanSocket) ExecCMDInContainer(containerName, podName string, cmd []string, stdout io.Writer, stderr io.Writer, stdin io.Reader, tty bool) error {
+	name := fmt.Sprintf("%s-%s", podName, containerName)
+
+	klog.V(4).Infof("exec in container %s: %v", name, cmd)
+	var created execCreateResponse
+	err := o.doJSON(http.MethodPost, "/containers/"+url.PathEscape(name)+"/exec", execCreateRequest{
+		AttachStdin:  stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          cmd,
+		Tty:          tty,
+	}, &created, http.StatusCreated)
+	if err != nil {
+		return err
+	}
+
+	err = o.startExec(created.ID, stdout, stderr, stdin, tty)
+	if err != nil {
+		return err
+	}
+
+	var inspect execInspectResponse
+	err = o.doJSON(http.MethodGet, "/exec/"+url.PathEscape(created.ID)+"/json", nil, &inspect, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	if inspect.ExitCode != 0 {
+		return &ExecError{ExitCode: inspect.ExitCode}
+	}
+	return nil
+}
+
+// startExec starts the exec session and streams its input and outputs until the command exits.
+// The API hijacks the HTTP connection to stream the data, so the request is sent on a dedicated connection.
+func (o *PodmanSocket) startExec(id string, stdout io.Writer, stderr io.Writer, stdin io.Reader, tty bool) error {
+	conn, err := net.Dial("unix", o.socketPath)
+	if err != nil {
+		return fmt.Errorf("unable to connect to the podman socket %q: %w", o.socketPath, err)
+	}
+	defer conn.Close()
+
+	body, err := json.Marshal(execStartRequest{Tty: tty})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, apiURL("/exec/"+url.PathEscape(id)+"/start"), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "tcp")
+	err = req.Write(conn)
+	if err != nil {
+		return err
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusSwitchingProtocols {
+		defer resp.Body.Close()
+		return newAPIError(resp)
+	}
+
+	if stdin != nil {
+		go func() {
+			_, _ = io.Copy(conn, stdin)
+			if unixConn, ok := conn.(*net.UnixConn); ok {
+				_ = unixConn.CloseWrite()
+			}
+		}()
+	}
+
+	// the body of the response is the raw stream, read from the connection directly
+	if tty {
+		if stdout == nil {
+			stdout = io.Discard
+		}
+		_, err = io.Copy(stdout, br)
+		return err
+	}
+	return demuxStream(br, stdout, stderr)
+}
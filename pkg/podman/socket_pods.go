@@ -0,0 +1,261 @@
+package podman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// GetPodsMatchingSelector returns all pods matching the given label selector.
+func (o *PodmanSocket) GetPodsMatchingSelector(selector string) (*corev1.PodList, error) {
+	var podReports []ListPodsReport
+	err := o.doJSON(http.MethodGet, "/pods/json", nil, &podReports, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return toPodList(podReports, selector)
+}
+
+// GetAllResourcesFromSelector returns all resources of any kind matching the given label selector.
+// Only pods are returned, as pods are the only resources labelled by odo on Podman.
+func (o *PodmanSocket) GetAllResourcesFromSelector(selector string, ns string) ([]unstructured.Unstructured, error) {
+	pods, err := o.GetPodsMatchingSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return toUnstructuredList(pods)
+}
+
+// GetAllPodsInNamespaceMatchingSelector returns all pods matching the given label selector and in the specified namespace.
+// Podman has no notion of namespace, so the namespace is ignored.
+func (o *PodmanSocket) GetAllPodsInNamespaceMatchingSelector(selector string, ns string) (*corev1.PodList, error) {
+	return o.GetPodsMatchingSelector(selector)
+}
+
+// GetRunningPodFromSelector returns the running pod matching the given label selector.
+// A kclient.PodNotFoundError is returned if no running pod is found, and an error if several running pods are found.
+func (o *PodmanSocket) GetRunningPodFromSelector(selector string) (*corev1.Pod, error) {
+	pods, err := o.GetPodsMatchingSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return GetRunningPod(pods, selector)
+}
+
+// GetPodLogs returns the logs of the specified pod container.
+// All logs for all containers part of the pod are returned if an empty string is provided as container name.
+func (o *PodmanSocket) GetPodLogs(podName, containerName string, followLog bool) (io.ReadCloser, error) {
+	if containerName != "" {
+		return o.getContainerLogs(fmt.Sprintf("%s-%s", podName, containerName), logsQuery(followLog))
+	}
+
+	var podReports []ListPodsReport
+	err := o.doJSON(http.MethodGet, "/pods/json", nil, &podReports, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	var containerNames []string
+	for _, podReport := range podReports {
+		if podReport.Name != podName {
+			continue
+		}
+		for _, container := range podReport.Containers {
+			if container.ID != podReport.InfraID {
+				containerNames = append(containerNames, container.Names)
+			}
+		}
+	}
+	if len(containerNames) == 0 {
+		return nil, fmt.Errorf("no container found for pod %q", podName)
+	}
+
+	var readers []io.ReadCloser
+	for _, name := range containerNames {
+		rd, err := o.getContainerLogs(name, logsQuery(followLog))
+		if err != nil {
+			for _, r := range readers {
+				_ = r.Close()
+			}
+			return nil, err
+		}
+		readers = append(readers, rd)
+	}
+	return mergeReaders(readers), nil
+}
+
+// FollowPodLogsSince follows the logs of the pod container emitted since the given time, or all its logs if since is zero,
+// each line being prefixed with its timestamp
+func (o *PodmanSocket) FollowPodLogsSince(podName, containerName string, since time.Time) (io.ReadCloser, error) {
+	query := logsQuery(true)
+	query.Set("timestamps", "true")
+	if !since.IsZero() {
+		query.Set("since", strconv.FormatInt(since.Unix(), 10))
+	}
+	return o.getContainerLogs(fmt.Sprintf("%s-%s", podName, containerName), query)
+}
+
+// logsQuery returns the query parameters to get the stdout and stderr logs of a container, followed if followLog is true
+func logsQuery(followLog bool) url.Values {
+	query := url.Values{}
+	query.Set("stdout", "true")
+	query.Set("stderr", "true")
+	if followLog {
+		query.Set("follow", "true")
+	}
+	return query
+}
+
+// getContainerLogs returns the demultiplexed logs of the container with the given name, requested with the given query parameters
+func (o *PodmanSocket) getContainerLogs(name string, query url.Values) (io.ReadCloser, error) {
+	resp, err := o.do(http.MethodGet, "/containers/"+url.PathEscape(name)+"/logs?"+query.Encode(), "", nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer resp.Body.Close()
+		_ = pw.CloseWithError(demuxStream(resp.Body, pw, pw))
+	}()
+	return &responseReader{
+		PipeReader: pr,
+		body:       resp.Body,
+	}, nil
+}
+
+// responseReader reads data extracted from the body of a response.
+// Closing it closes the body of the response.
+type responseReader struct {
+	*io.PipeReader
+	body io.Closer
+}
+
+func (o *responseReader) Close() error {
+	_ = o.body.Close()
+	return o.PipeReader.Close()
+}
+
+// mergeReaders returns a reader returning the lines read from all readers, in the order they are received.
+// Closing the returned reader closes all readers.
+func mergeReaders(readers []io.ReadCloser) io.ReadCloser {
+	pr, pw := io.Pipe()
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, rd := range readers {
+		wg.Add(1)
+		go func(rd io.Reader) {
+			defer wg.Done()
+			lw := &lineWriter{w: pw, mu: &mu}
+			_, _ = io.Copy(lw, rd)
+			_ = lw.Flush()
+		}(rd)
+	}
+	go func() {
+		wg.Wait()
+		_ = pw.Close()
+	}()
+	return &multiReadCloser{
+		PipeReader: pr,
+		readers:    readers,
+	}
+}
+
+type multiReadCloser struct {
+	*io.PipeReader
+	readers []io.ReadCloser
+}
+
+func (o *multiReadCloser) Close() error {
+	for _, rd := range o.readers {
+		_ = rd.Close()
+	}
+	return o.PipeReader.Close()
+}
+
+// lineWriter writes complete lines to w, so lines written concurrently by several lineWriters sharing the same mutex
+// are not mixed up
+type lineWriter struct {
+	w   io.Writer
+	mu  *sync.Mutex
+	buf strings.Builder
+}
+
+func (o *lineWriter) Write(p []byte) (int, error) {
+	o.buf.Write(p)
+	content := o.buf.String()
+	idx := strings.LastIndexByte(content, '\n')
+	if idx == -1 {
+		return len(p), nil
+	}
+	o.buf.Reset()
+	o.buf.WriteString(content[idx+1:])
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, err := io.WriteString(o.w, content[:idx+1])
+	return len(p), err
+}
+
+// Flush writes any incomplete line
+func (o *lineWriter) Flush() error {
+	if o.buf.Len() == 0 {
+		return nil
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, err := io.WriteString(o.w, o.buf.String())
+	o.buf.Reset()
+	return err
+}
+
+func (o *PodmanSocket) PodWatcher(ctx context.Context, selector string) (watch.Interface, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	filters, err := json.Marshal(map[string][]string{
+		"type": {"container", "pod"},
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("stream", "true")
+	query.Set("filters", string(filters))
+	resp, err := o.doContext(ctx, http.MethodGet, "/events?"+query.Encode(), "", nil, http.StatusOK)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return WatchPods(ctx, cancel, resp.Body, PodmanEvents, func() (*corev1.PodList, error) {
+		var podReports []ListPodsReport
+		err := o.doJSON(http.MethodGet, "/pods/json", nil, &podReports, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+		return toPodListWithStates(podReports, selector, o.containerInspect)
+	}), nil
+}
+
+// containerInspect returns the description of the containers with the given IDs, indexed by ID
+func (o *PodmanSocket) containerInspect(ids []string) (map[string]ContainerInspect, error) {
+	result := make(map[string]ContainerInspect, len(ids))
+	for _, id := range ids {
+		var inspect ContainerInspect
+		err := o.doJSON(http.MethodGet, "/containers/"+url.PathEscape(id)+"/json", nil, &inspect, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+		result[id] = inspect
+	}
+	return result, nil
+}
@@ -0,0 +1,397 @@
+package podman
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/pointer"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// newFakeServer starts an HTTP server listening on a unix socket and returns a PodmanSocket connected to it
+func newFakeServer(t *testing.T, handler http.Handler) *PodmanSocket {
+	socketPath := filepath.Join(t.TempDir(), "podman.sock")
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = l
+	server.Start()
+	t.Cleanup(server.Close)
+	return NewPodmanSocket(socketPath)
+}
+
+// frame returns a frame of a multiplexed stream
+func frame(stream byte, payload string) []byte {
+	header := make([]byte, 8)
+	header[0] = stream
+	binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+	return append(header, []byte(payload)...)
+}
+
+func TestGetSocketPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		containerHost *string
+		xdgRuntimeDir *string
+		want          string
+		wantErr       bool
+	}{
+		{
+			name: "root socket by default",
+			want: "/run/podman/podman.sock",
+		},
+		{
+			name:          "rootless socket",
+			xdgRuntimeDir: pointer.String("/run/user/1000"),
+			want:          "/run/user/1000/podman/podman.sock",
+		},
+		{
+			name:          "CONTAINER_HOST takes precedence",
+			containerHost: pointer.String("unix:///tmp/podman.sock"),
+			xdgRuntimeDir: pointer.String("/run/user/1000"),
+			want:          "/tmp/podman.sock",
+		},
+		{
+			name:          "unsupported scheme",
+			containerHost: pointer.String("ssh://user@host/run/podman/podman.sock"),
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetSocketPath(tt.containerHost, tt.xdgRuntimeDir)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetSocketPath() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetSocketPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodmanSocket_PlayKube(t *testing.T) {
+	var gotBody string
+	o := newFakeServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != apiPrefix+"/play/kube" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		_, _ = w.Write([]byte(`{"Pods":[]}`))
+	}))
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mycmp-app",
+		},
+	}
+	pod.APIVersion, pod.Kind = corev1.SchemeGroupVersion.WithKind("Pod").ToAPIVersionAndKind()
+	configMap := unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetName("myconfig")
+	err := o.PlayKube(pod, []unstructured.Unstructured{configMap})
+	if err != nil {
+		t.Fatalf("PlayKube() unexpected error: %v", err)
+	}
+	docs := strings.Split(gotBody, "---\n")
+	if len(docs) != 2 {
+		t.Fatalf("PlayKube() body should contain 2 documents, got %q", gotBody)
+	}
+	if !strings.Contains(docs[0], "kind: ConfigMap") || !strings.Contains(docs[0], "name: myconfig") {
+		t.Errorf("PlayKube() first document should be the ConfigMap definition, got %q", docs[0])
+	}
+	if !strings.Contains(docs[1], "kind: Pod") || !strings.Contains(docs[1], "name: mycmp-app") {
+		t.Errorf("PlayKube() second document should be the pod definition, got %q", docs[1])
+	}
+}
+
+func TestPodmanSocket_Errors(t *testing.T) {
+	o := newFakeServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"cause":"no such pod","message":"unable to find pod mycmp-app","response":404}`))
+	}))
+
+	err := o.PodStop("mycmp-app")
+	if err == nil {
+		t.Fatal("PodStop() expected an error")
+	}
+	if !IsNotFound(err) {
+		t.Errorf("PodStop() error should be a not found error, got %v", err)
+	}
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("PodStop() error should be an APIError, got %T", err)
+	}
+	if apiErr.Cause != "no such pod" || apiErr.Message != "unable to find pod mycmp-app" {
+		t.Errorf("PodStop() unexpected error content: %+v", apiErr)
+	}
+}
+
+func TestPodmanSocket_Volumes(t *testing.T) {
+	var deleted []string
+	o := newFakeServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == apiPrefix+"/volumes/json":
+			_, _ = w.Write([]byte(`[{"Name":"odo-projects-mycmp-app"},{"Name":"other"}]`))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, apiPrefix+"/volumes/"):
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, apiPrefix+"/volumes/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	got, err := o.VolumeLs()
+	if err != nil {
+		t.Fatalf("VolumeLs() unexpected error: %v", err)
+	}
+	want := map[string]bool{"odo-projects-mycmp-app": true, "other": true}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("VolumeLs() mismatch (-want +got):\n%s", diff)
+	}
+
+	err = o.VolumeRm("odo-projects-mycmp-app")
+	if err != nil {
+		t.Fatalf("VolumeRm() unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"odo-projects-mycmp-app"}, deleted); diff != "" {
+		t.Errorf("VolumeRm() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPodmanSocket_ExecCMDInContainer(t *testing.T) {
+	tests := []struct {
+		name       string
+		exitCode   int
+		wantStdout string
+		wantStderr string
+		wantErr    bool
+	}{
+		{
+			name:       "command succeeds",
+			wantStdout: "hello\n",
+			wantStderr: "warning\n",
+		},
+		{
+			name:       "command fails",
+			exitCode:   2,
+			wantStdout: "hello\n",
+			wantStderr: "warning\n",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotCreate execCreateRequest
+			var gotStdin string
+			o := newFakeServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == apiPrefix+"/containers/mycmp-app-runtime/exec":
+					_ = json.NewDecoder(r.Body).Decode(&gotCreate)
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"Id":"exec1"}`))
+				case r.Method == http.MethodPost && r.URL.Path == apiPrefix+"/exec/exec1/start":
+					_, _ = io.Copy(io.Discard, r.Body)
+					conn, bufrw, err := w.(http.Hijacker).Hijack()
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					defer conn.Close()
+					_, _ = bufrw.WriteString("HTTP/1.1 200 OK\r\nContent-Type: application/vnd.docker.raw-stream\r\n\r\n")
+					_ = bufrw.Flush()
+					line, _ := bufio.NewReader(bufrw).ReadString('\n')
+					gotStdin = line
+					_, _ = conn.Write(frame(streamStdout, "hello\n"))
+					_, _ = conn.Write(frame(streamStderr, "warning\n"))
+				case r.Method == http.MethodGet && r.URL.Path == apiPrefix+"/exec/exec1/json":
+					_ = json.NewEncoder(w).Encode(execInspectResponse{ExitCode: tt.exitCode})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			var stdout, stderr bytes.Buffer
+			err := o.ExecCMDInContainer("runtime", "mycmp-app", []string{"cat"}, &stdout, &stderr, strings.NewReader("input\n"), false)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExecCMDInContainer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if execErr, ok := err.(*ExecError); !ok || execErr.ExitCode != tt.exitCode {
+					t.Errorf("ExecCMDInContainer() error should be an ExecError with exit code %d, got %v", tt.exitCode, err)
+				}
+			}
+			if diff := cmp.Diff([]string{"cat"}, gotCreate.Cmd); diff != "" {
+				t.Errorf("ExecCMDInContainer() command mismatch (-want +got):\n%s", diff)
+			}
+			if gotStdin != "input\n" {
+				t.Errorf("ExecCMDInContainer() stdin = %q, want %q", gotStdin, "input\n")
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("ExecCMDInContainer() stdout = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+			if stderr.String() != tt.wantStderr {
+				t.Errorf("ExecCMDInContainer() stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestPodmanSocket_GetPodLogs(t *testing.T) {
+	o := newFakeServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == apiPrefix+"/containers/mycmp-app-runtime/logs":
+			if r.URL.Query().Get("stdout") != "true" || r.URL.Query().Get("stderr") != "true" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write(frame(streamStdout, "line 1\n"))
+			_, _ = w.Write(frame(streamStderr, "line 2\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	rd, err := o.GetPodLogs("mycmp-app", "runtime", false)
+	if err != nil {
+		t.Fatalf("GetPodLogs() unexpected error: %v", err)
+	}
+	defer rd.Close()
+	got, err := io.ReadAll(rd)
+	if err != nil {
+		t.Fatalf("GetPodLogs() unexpected error reading logs: %v", err)
+	}
+	if string(got) != "line 1\nline 2\n" {
+		t.Errorf("GetPodLogs() = %q, want %q", string(got), "line 1\nline 2\n")
+	}
+}
+
+func TestPodmanSocket_FollowPodLogsSince(t *testing.T) {
+	o := newFakeServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == apiPrefix+"/containers/mycmp-app-runtime/logs":
+			query := r.URL.Query()
+			if query.Get("follow") != "true" || query.Get("timestamps") != "true" || query.Get("since") != "1672653600" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write(frame(streamStdout, "2023-01-02T10:00:01Z line 1\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	rd, err := o.FollowPodLogsSince("mycmp-app", "runtime", time.Date(2023, 1, 2, 10, 0, 0, 500000000, time.UTC))
+	if err != nil {
+		t.Fatalf("FollowPodLogsSince() unexpected error: %v", err)
+	}
+	defer rd.Close()
+	got, err := io.ReadAll(rd)
+	if err != nil {
+		t.Fatalf("FollowPodLogsSince() unexpected error reading logs: %v", err)
+	}
+	if string(got) != "2023-01-02T10:00:01Z line 1\n" {
+		t.Errorf("FollowPodLogsSince() = %q, want %q", string(got), "2023-01-02T10:00:01Z line 1\n")
+	}
+}
+
+func TestPodmanSocket_GetPodsMatchingSelector(t *testing.T) {
+	o := newFakeServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != apiPrefix+"/pods/json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"Name":"mycmp-app","Id":"pod1","InfraId":"infra1","Status":"Running","Labels":{"component":"mycmp"},
+			 "Containers":[{"Id":"infra1","Names":"pod1-infra","Status":"running"},{"Id":"ctr1","Names":"mycmp-app-runtime","Status":"running"}]},
+			{"Name":"other-app","Id":"pod2","Status":"Running","Labels":{"component":"other"}}
+		]`))
+	}))
+
+	got, err := o.GetRunningPodFromSelector("component=mycmp")
+	if err != nil {
+		t.Fatalf("GetRunningPodFromSelector() unexpected error: %v", err)
+	}
+	if got.GetName() != "mycmp-app" {
+		t.Errorf("GetRunningPodFromSelector() = %q, want %q", got.GetName(), "mycmp-app")
+	}
+	if diff := cmp.Diff([]corev1.Container{{Name: "runtime"}}, got.Spec.Containers); diff != "" {
+		t.Errorf("GetRunningPodFromSelector() containers mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPodmanSocket_PodWatcher(t *testing.T) {
+	o := newFakeServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == apiPrefix+"/events":
+			if r.URL.Query().Get("stream") != "true" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		case r.Method == http.MethodGet && r.URL.Path == apiPrefix+"/pods/json":
+			_, _ = w.Write([]byte(`[
+				{"Name":"mycmp-app","Id":"pod1","InfraId":"infra1","Status":"Degraded","Labels":{"component":"mycmp"},
+				 "Containers":[{"Id":"infra1","Names":"pod1-infra","Status":"running"},{"Id":"ctr1","Names":"mycmp-app-runtime","Status":"exited"}]}
+			]`))
+		case r.Method == http.MethodGet && r.URL.Path == apiPrefix+"/containers/ctr1/json":
+			_, _ = w.Write([]byte(`{"Id":"ctr1","RestartCount":3,"State":{"Status":"exited","ExitCode":137,"OOMKilled":true}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	watcher, err := o.PodWatcher(context.Background(), "component=mycmp")
+	if err != nil {
+		t.Fatalf("PodWatcher() unexpected error: %v", err)
+	}
+	defer watcher.Stop()
+
+	select {
+	case ev := <-watcher.ResultChan():
+		if ev.Type != watch.Added {
+			t.Errorf("PodWatcher() event type = %q, want %q", ev.Type, watch.Added)
+		}
+		pod := ev.Object.(*corev1.Pod)
+		want := []corev1.ContainerStatus{
+			{
+				Name:         "runtime",
+				ContainerID:  "ctr1",
+				RestartCount: 3,
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 137,
+					Reason:   "OOMKilled",
+				}},
+			},
+		}
+		if diff := cmp.Diff(want, pod.Status.ContainerStatuses); diff != "" {
+			t.Errorf("PodWatcher() container statuses mismatch (-want +got):\n%s", diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for an event")
+	}
+}
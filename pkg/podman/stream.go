@@ -0,0 +1,53 @@
+package podman
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Stream types used in the header of the frames of a multiplexed stream
+const (
+	streamStdin  = 0
+	streamStdout = 1
+	streamStderr = 2
+)
+
+// demuxStream reads a stream multiplexing the stdout and stderr of a container,
+// as returned by the exec and logs endpoints of the API when no TTY is allocated,
+// and writes each frame to stdout or stderr.
+//
+// Each frame is composed of an 8 bytes header followed by the payload.
+// The first byte of the header indicates the stream (stdout or stderr),
+// and the last 4 bytes the size of the payload, encoded as big endian.
+func demuxStream(r io.Reader, stdout io.Writer, stderr io.Writer) error {
+	header := make([]byte, 8)
+	for {
+		_, err := io.ReadFull(r, header)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var w io.Writer
+		switch header[0] {
+		case streamStdin, streamStdout:
+			w = stdout
+		case streamStderr:
+			w = stderr
+		default:
+			return fmt.Errorf("unexpected stream type %d", header[0])
+		}
+		if w == nil {
+			w = io.Discard
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[4:]))
+		_, err = io.CopyN(w, r, size)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package podman
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os/exec"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog"
+)
+
+// Event is the subset of an event reported by Podman used by odo.
+// The CLI reports the kind of event in the Status field, the API in both the status and Action fields.
+type Event struct {
+	Type   string
+	Status string
+	Action string
+	Name   string
+}
+
+// PodmanEvents are the events reported by Podman on containers and pods which can change the state of a pod.
+// Other events (exec, attach, etc) are ignored
+var PodmanEvents = map[string]bool{
+	"create":  true,
+	"start":   true,
+	"restart": true,
+	"died":    true,
+	"oom":     true,
+	"kill":    true,
+	"stop":    true,
+	"pause":   true,
+	"unpause": true,
+	"remove":  true,
+}
+
+// kind returns the kind of event (start, died, etc)
+func (e Event) kind() string {
+	if e.Status != "" {
+		return e.Status
+	}
+	return e.Action
+}
+
+// ContainerInspect is the subset of the `podman container inspect` output used by odo
+type ContainerInspect struct {
+	ID           string `json:"Id"`
+	RestartCount int32
+	State        ContainerInspectState
+}
+
+// ContainerInspectState is the state of a container, part of the `podman container inspect` output
+type ContainerInspectState struct {
+	Status    string
+	ExitCode  int32
+	OOMKilled bool
+	Error     string
+}
+
+func (o *PodmanCli) PodWatcher(ctx context.Context, selector string) (watch.Interface, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	cmd := exec.CommandContext(ctx, "podman", "events", "--format", "json", "--filter", "type=container", "--filter", "type=pod")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if err = cmd.Start(); err != nil {
+		cancel()
+		return nil, err
+	}
+	events := &cmdReadCloser{
+		ReadCloser: stdout,
+		cmd:        cmd,
+	}
+	return WatchPods(ctx, cancel, events, PodmanEvents, func() (*corev1.PodList, error) {
+		podReports, err := o.podPs()
+		if err != nil {
+			return nil, err
+		}
+		return toPodListWithStates(podReports, selector, o.containerInspect)
+	}), nil
+}
+
+// containerInspect returns the description of the containers with the given IDs, indexed by ID
+func (o *PodmanCli) containerInspect(ids []string) (map[string]ContainerInspect, error) {
+	out, err := exec.Command("podman", append([]string{"container", "inspect", "--format", "json"}, ids...)...).Output()
+	if err != nil {
+		if exiterr, ok := err.(*exec.ExitError); ok {
+			klog.V(4).Infof("unable to inspect containers: %s", string(exiterr.Stderr))
+		}
+		return nil, err
+	}
+	var inspects []ContainerInspect
+	err = json.Unmarshal(out, &inspects)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]ContainerInspect, len(inspects))
+	for _, inspect := range inspects {
+		result[inspect.ID] = inspect
+	}
+	return result, nil
+}
+
+// cmdReadCloser reads the output of a command, and waits for the command to terminate when closed
+type cmdReadCloser struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+func (o *cmdReadCloser) Close() error {
+	_ = o.ReadCloser.Close()
+	return o.cmd.Wait()
+}
+
+// toPodListWithStates returns the pods from podReports matching selector, as Kubernetes pods,
+// with the states of their containers described by inspect
+func toPodListWithStates(podReports []ListPodsReport, selector string, inspect func(ids []string) (map[string]ContainerInspect, error)) (*corev1.PodList, error) {
+	pods, err := toPodList(podReports, selector)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			ids = append(ids, status.ContainerID)
+		}
+	}
+	if len(ids) == 0 {
+		return pods, nil
+	}
+	inspects, err := inspect(ids)
+	if err != nil {
+		// containers can be deleted between the list and the inspection, the state reported by the list is used
+		klog.V(4).Infof("unable to inspect containers: %v", err)
+		return pods, nil
+	}
+	for i := range pods.Items {
+		for j := range pods.Items[i].Status.ContainerStatuses {
+			status := &pods.Items[i].Status.ContainerStatuses[j]
+			if containerInspect, found := inspects[status.ContainerID]; found {
+				SetContainerState(status, containerInspect)
+			}
+		}
+	}
+	return pods, nil
+}
+
+// SetContainerState sets the state and restart count of the container status from the inspection of the container
+func SetContainerState(status *corev1.ContainerStatus, inspect ContainerInspect) {
+	status.RestartCount = inspect.RestartCount
+	status.State = corev1.ContainerState{}
+	status.Ready = false
+	switch strings.ToLower(inspect.State.Status) {
+	case "running":
+		status.Ready = true
+		status.State.Running = &corev1.ContainerStateRunning{}
+	case "created", "configured", "initialized", "paused":
+		status.State.Waiting = &corev1.ContainerStateWaiting{Reason: inspect.State.Status}
+	default:
+		reason := "Completed"
+		switch {
+		case inspect.State.OOMKilled:
+			reason = "OOMKilled"
+		case inspect.State.ExitCode != 0:
+			reason = "Error"
+		}
+		status.State.Terminated = &corev1.ContainerStateTerminated{
+			ExitCode: inspect.State.ExitCode,
+			Reason:   reason,
+			Message:  inspect.State.Error,
+		}
+	}
+}
+
+// podWatcher implements watch.Interface for pods on Podman
+type podWatcher struct {
+	result chan watch.Event
+	cancel context.CancelFunc
+}
+
+func (o *podWatcher) Stop() {
+	o.cancel()
+}
+
+func (o *podWatcher) ResultChan() <-chan watch.Event {
+	return o.result
+}
+
+// WatchPods returns a watcher sending an Added event for each pod returned by listPods,
+// and then the changes of the pods returned by listPods, each time an event part of relevantEvents is read from events.
+// events is a stream of JSON Podman (or Docker) events, closed when ctx is cancelled.
+// The result channel is never closed, as the events stop when the watcher is stopped or ctx is cancelled.
+func WatchPods(ctx context.Context, cancel context.CancelFunc, events io.ReadCloser, relevantEvents map[string]bool, listPods func() (*corev1.PodList, error)) watch.Interface {
+	w := &podWatcher{
+		result: make(chan watch.Event),
+		cancel: cancel,
+	}
+
+	go func() {
+		defer events.Close()
+		defer cancel()
+
+		previous := map[string]*corev1.Pod{}
+		// send sends the changes between the previous pods and the current pods,
+		// and returns false if the watcher has been stopped
+		send := func() bool {
+			pods, err := listPods()
+			if err != nil {
+				klog.V(4).Infof("unable to list pods: %v", err)
+				return true
+			}
+			current := make(map[string]*corev1.Pod, len(pods.Items))
+			var changes []watch.Event
+			for i := range pods.Items {
+				pod := &pods.Items[i]
+				current[pod.GetName()] = pod
+				previousPod, found := previous[pod.GetName()]
+				switch {
+				case !found:
+					changes = append(changes, watch.Event{Type: watch.Added, Object: pod})
+				case !equality.Semantic.DeepEqual(previousPod, pod):
+					changes = append(changes, watch.Event{Type: watch.Modified, Object: pod})
+				}
+			}
+			for name, pod := range previous {
+				if _, found := current[name]; !found {
+					changes = append(changes, watch.Event{Type: watch.Deleted, Object: pod})
+				}
+			}
+			previous = current
+			for _, change := range changes {
+				select {
+				case w.result <- change:
+				case <-ctx.Done():
+					return false
+				}
+			}
+			return true
+		}
+
+		if !send() {
+			return
+		}
+		decoder := json.NewDecoder(events)
+		for {
+			var event Event
+			err := decoder.Decode(&event)
+			if err != nil {
+				if ctx.Err() == nil {
+					klog.V(4).Infof("podman events stream terminated: %v", err)
+				}
+				return
+			}
+			if !relevantEvents[event.kind()] {
+				continue
+			}
+			klog.V(4).Infof("podman event: %s %s %s", event.Type, event.Name, event.kind())
+			if !send() {
+				return
+			}
+		}
+	}()
+	return w
+}
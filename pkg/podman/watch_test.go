@@ -0,0 +1,152 @@
+package podman
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func TestSetContainerState(t *testing.T) {
+	tests := []struct {
+		name    string
+		inspect ContainerInspect
+		want    corev1.ContainerStatus
+	}{
+		{
+			name: "running container",
+			inspect: ContainerInspect{
+				RestartCount: 2,
+				State:        ContainerInspectState{Status: "running"},
+			},
+			want: corev1.ContainerStatus{
+				Name:         "runtime",
+				Ready:        true,
+				RestartCount: 2,
+				State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			},
+		},
+		{
+			name: "container killed because out of memory",
+			inspect: ContainerInspect{
+				State: ContainerInspectState{Status: "exited", ExitCode: 137, OOMKilled: true},
+			},
+			want: corev1.ContainerStatus{
+				Name: "runtime",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 137,
+					Reason:   "OOMKilled",
+				}},
+			},
+		},
+		{
+			name: "container exited with an error",
+			inspect: ContainerInspect{
+				State: ContainerInspectState{Status: "exited", ExitCode: 1},
+			},
+			want: corev1.ContainerStatus{
+				Name: "runtime",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1,
+					Reason:   "Error",
+				}},
+			},
+		},
+		{
+			name: "container exited successfully",
+			inspect: ContainerInspect{
+				State: ContainerInspectState{Status: "exited"},
+			},
+			want: corev1.ContainerStatus{
+				Name: "runtime",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Reason: "Completed",
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := corev1.ContainerStatus{
+				Name:  "runtime",
+				Ready: true,
+			}
+			SetContainerState(&got, tt.inspect)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("SetContainerState() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWatchPods(t *testing.T) {
+	newPod := func(name string, phase corev1.PodPhase) corev1.Pod {
+		pod := corev1.Pod{}
+		pod.SetName(name)
+		pod.Status.Phase = phase
+		return pod
+	}
+	lists := []corev1.PodList{
+		{Items: []corev1.Pod{newPod("mycmp-app", corev1.PodPending)}},
+		{Items: []corev1.Pod{newPod("mycmp-app", corev1.PodRunning)}},
+		{Items: []corev1.Pod{newPod("mycmp-app", corev1.PodRunning)}},
+		{},
+	}
+	listPods := func() (*corev1.PodList, error) {
+		if len(lists) == 0 {
+			t.Error("unexpected call to listPods")
+			return &corev1.PodList{}, nil
+		}
+		list := lists[0]
+		lists = lists[1:]
+		return &list, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r, w := io.Pipe()
+	watcher := WatchPods(ctx, cancel, r, PodmanEvents, listPods)
+	defer watcher.Stop()
+
+	go func() {
+		for _, event := range []string{
+			`{"Type":"container","Status":"start","Name":"mycmp-app-runtime"}`,
+			// ignored, exec events do not change the state of the pod
+			`{"Type":"container","Status":"exec_died","Name":"mycmp-app-runtime"}`,
+			// events from the API
+			`{"Type":"container","status":"died","Action":"died"}`,
+			`{"Type":"pod","status":"remove","Action":"remove"}`,
+		} {
+			_, _ = io.WriteString(w, event+"\n")
+		}
+	}()
+
+	type result struct {
+		Type  watch.EventType
+		Name  string
+		Phase corev1.PodPhase
+	}
+	want := []result{
+		{Type: watch.Added, Name: "mycmp-app", Phase: corev1.PodPending},
+		{Type: watch.Modified, Name: "mycmp-app", Phase: corev1.PodRunning},
+		{Type: watch.Deleted, Name: "mycmp-app", Phase: corev1.PodRunning},
+	}
+	var got []result
+	for len(got) < len(want) {
+		select {
+		case ev := <-watcher.ResultChan():
+			pod := ev.Object.(*corev1.Pod)
+			got = append(got, result{Type: ev.Type, Name: pod.GetName(), Phase: pod.Status.Phase})
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for events, got %v", got)
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("WatchPods() mismatch (-want +got):\n%s", diff)
+	}
+}
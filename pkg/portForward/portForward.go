@@ -15,6 +15,7 @@ import (
 	"github.com/redhat-developer/odo/pkg/kclient"
 	"github.com/redhat-developer/odo/pkg/libdevfile"
 	"github.com/redhat-developer/odo/pkg/log"
+	"github.com/redhat-developer/odo/pkg/machineoutput"
 	"github.com/redhat-developer/odo/pkg/state"
 	"github.com/redhat-developer/odo/pkg/util"
 	"github.com/redhat-developer/odo/pkg/watch"
@@ -104,13 +105,20 @@ func (o *PFClient) StartPortForwarding(
 		backo := watch.NewExpBackoff()
 		for {
 			o.finishedChan = make(chan struct{}, 1)
-			portsBuf := NewPortWriter(log.GetStdout(), len(portPairsSlice), ceMapping)
+			portsOut := log.GetStdout()
+			if log.IsJSON() {
+				// the forwarded ports are reported with an event
+				portsOut = io.Discard
+			}
+			portsBuf := NewPortWriter(portsOut, len(portPairsSlice), ceMapping)
 
 			go func() {
 				portsBuf.Wait()
 				err = o.stateClient.SetForwardedPorts(portsBuf.GetForwardedPorts())
 				if err != nil {
 					err = fmt.Errorf("unable to save forwarded ports to state file: %v", err)
+				} else {
+					machineoutput.NewMachineEventLoggingClient().PortsForwarded(portsBuf.GetForwardedPorts(), machineoutput.TimestampNow())
 				}
 				devstateChan <- err
 			}()
@@ -45,6 +45,12 @@ type odoSettings struct {
 
 	// ConsentTelemetry if true collects telemetry for odo
 	ConsentTelemetry *bool `yaml:"ConsentTelemetry,omitempty"`
+
+	// Platform on which commands run when the --run-on flag is not used
+	Platform *string `yaml:"Platform,omitempty"`
+
+	// SyncCompression if true compresses the files synced into the containers
+	SyncCompression *bool `yaml:"SyncCompression,omitempty"`
 }
 
 // Registry includes the registry metadata
@@ -305,6 +311,20 @@ func (c *preferenceInfo) SetConfiguration(parameter string, value string) error
 				return fmt.Errorf("unable to set %q to %q, value must be a boolean", parameter, value)
 			}
 			c.OdoSettings.ConsentTelemetry = &val
+
+		case "synccompression":
+			val, err := strconv.ParseBool(strings.ToLower(value))
+			if err != nil {
+				return fmt.Errorf("unable to set %q to %q, value must be a boolean", parameter, value)
+			}
+			c.OdoSettings.SyncCompression = &val
+
+		case "platform":
+			val := strings.ToLower(value)
+			if !isSupportedPlatform(val) {
+				return fmt.Errorf("unable to set %q to %q, value must be one of %s", parameter, value, strings.Join(supportedPlatforms, ", "))
+			}
+			c.OdoSettings.Platform = &val
 		}
 	} else {
 		return fmt.Errorf("unknown parameter : %q is not a parameter in odo preference, run `odo preference -h` to see list of available parameters", parameter)
@@ -317,6 +337,16 @@ func (c *preferenceInfo) SetConfiguration(parameter string, value string) error
 	return nil
 }
 
+// isSupportedPlatform returns true if platform is an accepted value of the Platform preference
+func isSupportedPlatform(platform string) bool {
+	for _, p := range supportedPlatforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
+
 // parseDuration parses the value set for a parameter;
 // if the value is for e.g. "4m", it is parsed by the time pkg and converted to an appropriate time.Duration
 // it returns an error if one occurred, or if the parsed value is less than minimumDurationValue
@@ -399,6 +429,18 @@ func (c *preferenceInfo) GetEphemeral() bool {
 	return kpointer.BoolDeref(c.OdoSettings.Ephemeral, DefaultEphemeralSetting)
 }
 
+// GetPlatform returns the value of Platform from preferences
+// and if absent then returns default
+func (c *preferenceInfo) GetPlatform() string {
+	return kpointer.StringDeref(c.OdoSettings.Platform, DefaultPlatformSetting)
+}
+
+// GetSyncCompression returns the value of SyncCompression from preferences
+// and if absent then returns default
+func (c *preferenceInfo) GetSyncCompression() bool {
+	return kpointer.BoolDeref(c.OdoSettings.SyncCompression, DefaultSyncCompressionSetting)
+}
+
 func (c *preferenceInfo) UpdateNotification() *bool {
 	return c.OdoSettings.UpdateNotification
 }
@@ -427,6 +469,14 @@ func (c *preferenceInfo) ConsentTelemetry() *bool {
 	return c.OdoSettings.ConsentTelemetry
 }
 
+func (c *preferenceInfo) Platform() *string {
+	return c.OdoSettings.Platform
+}
+
+func (c *preferenceInfo) SyncCompression() *bool {
+	return c.OdoSettings.SyncCompression
+}
+
 // RegistryList returns the list of registries,
 // in reverse order compared to what is declared in the preferences file.
 //
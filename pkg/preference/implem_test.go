@@ -348,6 +348,36 @@ func TestSetConfiguration(t *testing.T) {
 			wantErr: false,
 			want:    false,
 		},
+		{
+			name:           fmt.Sprintf("set %s to false", SyncCompressionSetting),
+			parameter:      "synccompression",
+			value:          "false",
+			existingConfig: Preference{},
+			wantErr:        false,
+			want:           false,
+		},
+		{
+			name:           fmt.Sprintf("set %s to a non boolean value", SyncCompressionSetting),
+			parameter:      "synccompression",
+			value:          "fast",
+			existingConfig: Preference{},
+			wantErr:        true,
+		},
+		{
+			name:           fmt.Sprintf("set %s to podman", PlatformSetting),
+			parameter:      "platform",
+			value:          "Podman",
+			existingConfig: Preference{},
+			wantErr:        false,
+			want:           "podman",
+		},
+		{
+			name:           fmt.Sprintf("set %s to an unsupported platform", PlatformSetting),
+			parameter:      "platform",
+			value:          "kind",
+			existingConfig: Preference{},
+			wantErr:        true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -377,6 +407,14 @@ func TestSetConfiguration(t *testing.T) {
 					if *cfg.OdoSettings.RegistryCacheTime != tt.want {
 						t.Errorf("unexpected value after execution of SetConfiguration\ngot: %v \nexpected: %d\n", *cfg.OdoSettings.RegistryCacheTime, tt.want)
 					}
+				case "synccompression":
+					if *cfg.OdoSettings.SyncCompression != tt.want {
+						t.Errorf("unexpected value after execution of SetConfiguration\ngot: %v \nexpected: %v\n", *cfg.OdoSettings.SyncCompression, tt.want)
+					}
+				case "platform":
+					if *cfg.OdoSettings.Platform != tt.want {
+						t.Errorf("unexpected value after execution of SetConfiguration\ngot: %v \nexpected: %v\n", *cfg.OdoSettings.Platform, tt.want)
+					}
 				}
 			} else if tt.wantErr && err != nil {
 				// negative cases
@@ -67,6 +67,20 @@ func toPreferenceItems(prefInfo preferenceInfo) []PreferenceItem {
 			Type:        getType(prefInfo.GetEphemeral()),
 			Description: EphemeralSettingDescription,
 		},
+		{
+			Name:        PlatformSetting,
+			Value:       settings.Platform,
+			Default:     DefaultPlatformSetting,
+			Type:        getType(prefInfo.GetPlatform()),
+			Description: PlatformSettingDescription,
+		},
+		{
+			Name:        SyncCompressionSetting,
+			Value:       settings.SyncCompression,
+			Default:     DefaultSyncCompressionSetting,
+			Type:        getType(prefInfo.GetSyncCompression()),
+			Description: SyncCompressionSettingDescription,
+		},
 	}
 }
 
@@ -104,6 +104,20 @@ func (mr *MockClientMockRecorder) GetEphemeralSourceVolume() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEphemeralSourceVolume", reflect.TypeOf((*MockClient)(nil).GetEphemeralSourceVolume))
 }
 
+// GetPlatform mocks base method.
+func (m *MockClient) GetPlatform() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlatform")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetPlatform indicates an expected call of GetPlatform.
+func (mr *MockClientMockRecorder) GetPlatform() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlatform", reflect.TypeOf((*MockClient)(nil).GetPlatform))
+}
+
 // GetPushTimeout mocks base method.
 func (m *MockClient) GetPushTimeout() time.Duration {
 	m.ctrl.T.Helper()
@@ -132,6 +146,20 @@ func (mr *MockClientMockRecorder) GetRegistryCacheTime() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegistryCacheTime", reflect.TypeOf((*MockClient)(nil).GetRegistryCacheTime))
 }
 
+// GetSyncCompression mocks base method.
+func (m *MockClient) GetSyncCompression() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncCompression")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// GetSyncCompression indicates an expected call of GetSyncCompression.
+func (mr *MockClientMockRecorder) GetSyncCompression() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncCompression", reflect.TypeOf((*MockClient)(nil).GetSyncCompression))
+}
+
 // GetTimeout mocks base method.
 func (m *MockClient) GetTimeout() time.Duration {
 	m.ctrl.T.Helper()
@@ -188,6 +216,20 @@ func (mr *MockClientMockRecorder) NewPreferenceList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewPreferenceList", reflect.TypeOf((*MockClient)(nil).NewPreferenceList))
 }
 
+// Platform mocks base method.
+func (m *MockClient) Platform() *string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Platform")
+	ret0, _ := ret[0].(*string)
+	return ret0
+}
+
+// Platform indicates an expected call of Platform.
+func (mr *MockClientMockRecorder) Platform() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Platform", reflect.TypeOf((*MockClient)(nil).Platform))
+}
+
 // PushTimeout mocks base method.
 func (m *MockClient) PushTimeout() *time.Duration {
 	m.ctrl.T.Helper()
@@ -272,6 +314,20 @@ func (mr *MockClientMockRecorder) SetConfiguration(parameter, value interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConfiguration", reflect.TypeOf((*MockClient)(nil).SetConfiguration), parameter, value)
 }
 
+// SyncCompression mocks base method.
+func (m *MockClient) SyncCompression() *bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncCompression")
+	ret0, _ := ret[0].(*bool)
+	return ret0
+}
+
+// SyncCompression indicates an expected call of SyncCompression.
+func (mr *MockClientMockRecorder) SyncCompression() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncCompression", reflect.TypeOf((*MockClient)(nil).SyncCompression))
+}
+
 // Timeout mocks base method.
 func (m *MockClient) Timeout() *time.Duration {
 	m.ctrl.T.Helper()
@@ -15,6 +15,8 @@ type Client interface {
 	GetEphemeralSourceVolume() bool
 	GetConsentTelemetry() bool
 	GetRegistryCacheTime() time.Duration
+	GetPlatform() string
+	GetSyncCompression() bool
 	RegistryHandler(operation string, registryName string, registryURL string, forceFlag bool, isSecure bool) error
 
 	UpdateNotification() *bool
@@ -23,6 +25,8 @@ type Client interface {
 	RegistryCacheTime() *time.Duration
 	EphemeralSourceVolume() *bool
 	ConsentTelemetry() *bool
+	Platform() *string
+	SyncCompression() *bool
 	RegistryList() []Registry
 	RegistryNameExists(name string) bool
 
@@ -3,6 +3,7 @@ package preference
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/redhat-developer/odo/pkg/util"
@@ -61,6 +62,18 @@ const (
 
 	// DefaultConsentTelemetry is a default value for ConsentTelemetry preference
 	DefaultConsentTelemetrySetting = false
+
+	// PlatformSetting specifies the platform on which commands run when the --run-on flag is not used
+	PlatformSetting = "Platform"
+
+	// DefaultPlatformSetting is a default value for Platform preference
+	DefaultPlatformSetting = "cluster"
+
+	// SyncCompressionSetting specifies if the files synced into the containers are compressed
+	SyncCompressionSetting = "SyncCompression"
+
+	// DefaultSyncCompressionSetting is a default value for SyncCompression preference
+	DefaultSyncCompressionSetting = true
 )
 
 // TimeoutSettingDescription is human-readable description for the timeout setting
@@ -78,6 +91,16 @@ var EphemeralSettingDescription = fmt.Sprintf("If true, odo will create an empty
 // ConsentTelemetrySettingDescription adds a description for TelemetryConsentSetting
 var ConsentTelemetrySettingDescription = fmt.Sprintf("If true, odo will collect telemetry for the user's odo usage (Default: %t)\n\t\t    For more information: https://developers.redhat.com/article/tool-data-collection", DefaultConsentTelemetrySetting)
 
+// PlatformSettingDescription adds a description for PlatformSetting
+var PlatformSettingDescription = fmt.Sprintf("Platform on which to run commands supporting the --run-on flag, when the flag is not used; one of %s (Default: %s)", strings.Join(supportedPlatforms, ", "), DefaultPlatformSetting)
+
+// SyncCompressionSettingDescription adds a description for SyncCompressionSetting
+var SyncCompressionSettingDescription = fmt.Sprintf("If true, odo will compress the files synced into the containers, when the containers can decompress them (Default: %t)", DefaultSyncCompressionSetting)
+
+// supportedPlatforms are the accepted values of the Platform preference.
+// They are the values accepted by the --run-on flag, which cannot be imported here without an import cycle.
+var supportedPlatforms = []string{"cluster", "podman", "docker"}
+
 // This value can be provided to set a seperate directory for users 'homedir' resolution
 // note for mocking purpose ONLY
 var customHomeDir = os.Getenv("CUSTOM_HOMEDIR")
@@ -91,6 +114,8 @@ var (
 		RegistryCacheTimeSetting:  RegistryCacheTimeSettingDescription,
 		EphemeralSetting:          EphemeralSettingDescription,
 		ConsentTelemetrySetting:   ConsentTelemetrySettingDescription,
+		PlatformSetting:           PlatformSettingDescription,
+		SyncCompressionSetting:    SyncCompressionSettingDescription,
 	}
 
 	// set-like map to quickly check if a parameter is supported
@@ -9,6 +9,10 @@ type Client interface {
 	// GetForwardedPorts returns the ports forwarded by the current odo dev session
 	GetForwardedPorts() ([]api.ForwardedPort, error)
 
+	// SetAPIServer sets the address of the API server of the current odo dev session, and the token to access it,
+	// in the state file and saves it to the file
+	SetAPIServer(address string, token string) error
+
 	// SaveExit resets the state file to indicate odo is not running
 	SaveExit() error
 }
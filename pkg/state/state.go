@@ -6,12 +6,15 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/redhat-developer/odo/pkg/api"
 	"github.com/redhat-developer/odo/pkg/testingutil/filesystem"
 )
 
+// State is safe for concurrent use, as the API server of odo dev reads the forwarded ports
 type State struct {
+	mu      sync.Mutex
 	content Content
 	fs      filesystem.Filesystem
 }
@@ -25,12 +28,16 @@ func NewStateClient(fs filesystem.Filesystem) *State {
 }
 
 func (o *State) SetForwardedPorts(fwPorts []api.ForwardedPort) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 	// TODO(feloy) When other data is persisted into the state file, it will be needed to read the file first
 	o.content.ForwardedPorts = fwPorts
 	return o.save()
 }
 
 func (o *State) GetForwardedPorts() ([]api.ForwardedPort, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 	err := o.read()
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
@@ -41,8 +48,20 @@ func (o *State) GetForwardedPorts() ([]api.ForwardedPort, error) {
 	return o.content.ForwardedPorts, err
 }
 
+func (o *State) SetAPIServer(address string, token string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.content.APIServerAddress = address
+	o.content.APIServerToken = token
+	return o.save()
+}
+
 func (o *State) SaveExit() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 	o.content.ForwardedPorts = nil
+	o.content.APIServerAddress = ""
+	o.content.APIServerToken = ""
 	return o.save()
 }
 
@@ -58,7 +77,14 @@ func (o *State) save() error {
 	if err != nil {
 		return err
 	}
-	return o.fs.WriteFile(_filepath, jsonContent, 0644)
+	// the file contains the token of the API server, it must be readable only by the user
+	err = o.fs.WriteFile(_filepath, jsonContent, 0600)
+	if err != nil {
+		return err
+	}
+	// WriteFile does not change the permissions of an existing file, written by a previous session.
+	// The path is cleaned, as the in-memory filesystem used in tests does not clean it on Chmod
+	return o.fs.Chmod(filepath.Clean(_filepath), 0600)
 }
 
 func (o *State) read() error {
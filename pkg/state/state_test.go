@@ -3,6 +3,7 @@ package state
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -87,6 +88,51 @@ func TestState_SetForwardedPorts(t *testing.T) {
 	}
 }
 
+func TestState_SetAPIServer(t *testing.T) {
+	forwardedPort1 := api.ForwardedPort{
+		ContainerName: "acontainer",
+		LocalAddress:  "localhost",
+		LocalPort:     40001,
+		ContainerPort: 3000,
+	}
+
+	fs := filesystem.NewFakeFs()
+	o := State{
+		fs: fs,
+	}
+	if err := o.SetForwardedPorts([]api.ForwardedPort{forwardedPort1}); err != nil {
+		t.Fatalf("State.SetForwardedPorts() error = %v", err)
+	}
+	if err := o.SetAPIServer("127.0.0.1:20000", "a-token"); err != nil {
+		t.Fatalf("State.SetAPIServer() error = %v", err)
+	}
+
+	jsonContent, err := fs.ReadFile(_filepath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var content Content
+	err = json.Unmarshal(jsonContent, &content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Content{
+		ForwardedPorts:   []api.ForwardedPort{forwardedPort1},
+		APIServerAddress: "127.0.0.1:20000",
+		APIServerToken:   "a-token",
+	}
+	if diff := cmp.Diff(expected, content); diff != "" {
+		t.Errorf("content mismatch (-want +got):\n%s", diff)
+	}
+	info, err := fs.Stat(_filepath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("file permissions = %v, want %v", perm, os.FileMode(0600))
+	}
+}
+
 func TestState_SaveExit(t *testing.T) {
 	type fields struct {
 		fs                  func() filesystem.Filesystem
@@ -126,6 +172,12 @@ func TestState_SaveExit(t *testing.T) {
 				if len(content.ForwardedPorts) != 0 {
 					return fmt.Errorf("Forwarded ports is %+v, should be empty", content.ForwardedPorts)
 				}
+				if content.APIServerAddress != "" {
+					return fmt.Errorf("API server address is %q, should be empty", content.APIServerAddress)
+				}
+				if content.APIServerToken != "" {
+					return fmt.Errorf("API server token is %q, should be empty", content.APIServerToken)
+				}
 				return nil
 			},
 		},
@@ -7,4 +7,8 @@ import (
 type Content struct {
 	// ForwardedPorts are the ports forwarded during odo dev session
 	ForwardedPorts []api.ForwardedPort `json:"forwardedPorts"`
+	// APIServerAddress is the address of the API server of the odo dev session, in the form host:port, if started
+	APIServerAddress string `json:"apiServerAddress,omitempty"`
+	// APIServerToken is the token to pass as a bearer token in the requests to the API server of the odo dev session, if started
+	APIServerToken string `json:"apiServerToken,omitempty"`
 }
@@ -0,0 +1,151 @@
+package sync
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"k8s.io/klog"
+)
+
+// compression is a compression format of the archives sent to the containers
+type compression struct {
+	// name is the name of the format, and of the command decompressing it in the containers
+	name string
+	// newWriter returns a writer compressing the data written into w
+	newWriter func(w io.Writer) (io.WriteCloser, error)
+}
+
+// compressions are the supported compression formats, by order of preference
+var compressions = []compression{
+	{
+		name: "zstd",
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedFastest))
+		},
+	},
+	{
+		name: "gzip",
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriterLevel(w, gzip.BestSpeed)
+		},
+	},
+}
+
+// getCmdToDetectDecompressors returns the command listing the decompression commands available in a container, one per line
+func getCmdToDetectDecompressors() []string {
+	names := make([]string, 0, len(compressions))
+	for _, c := range compressions {
+		names = append(names, c.name)
+	}
+	return []string{"sh", "-c", fmt.Sprintf("for c in %s; do command -v $c >/dev/null 2>&1 && echo $c; done; true", strings.Join(names, " "))}
+}
+
+// selectCompression returns the preferred compression format supported by the decompressors listed in output,
+// or nil if no decompressor is available
+func selectCompression(output string) *compression {
+	available := map[string]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		available[strings.TrimSpace(line)] = true
+	}
+	for i := range compressions {
+		if available[compressions[i].name] {
+			return &compressions[i]
+		}
+	}
+	return nil
+}
+
+// getCompression returns the compression format to use to send archives to the container, or nil if the archives
+// must not be compressed, either because compression is disabled by the preferences, or because no decompressor is available
+// in the container. The decompressors are detected once per container.
+func (a SyncClient) getCompression(compInfo ComponentInfo) *compression {
+	if a.prefClient != nil && !a.prefClient.GetSyncCompression() {
+		return nil
+	}
+
+	key := compInfo.PodName + "/" + compInfo.ContainerName
+	if c, found := a.compressions[key]; found {
+		return c
+	}
+
+	var stdout, stderr bytes.Buffer
+	err := a.platformClient.ExecCMDInContainer(compInfo.ContainerName, compInfo.PodName, getCmdToDetectDecompressors(), &stdout, &stderr, nil, false)
+	if err != nil {
+		klog.V(4).Infof("unable to detect decompressors in container %q, files will not be compressed: %v: %s", compInfo.ContainerName, err, stderr.String())
+		a.compressions[key] = nil
+		return nil
+	}
+	c := selectCompression(stdout.String())
+	if c == nil {
+		klog.V(4).Infof("no decompressor found in container %q, files will not be compressed", compInfo.ContainerName)
+	} else {
+		klog.V(4).Infof("files will be compressed with %s for container %q", c.name, compInfo.ContainerName)
+	}
+	a.compressions[key] = c
+	return c
+}
+
+// SyncStats are the statistics of the transfer of files into containers
+type SyncStats struct {
+	// Files is the number of files and directories transferred
+	Files int
+	// Bytes is the size of the archives transferred, before compression
+	Bytes int64
+	// TransferredBytes is the size of the data sent to the containers, after compression
+	TransferredBytes int64
+	// Compression is the name of the compression format used, or empty if the archives are not compressed
+	Compression string
+	// Duration is the time spent transferring the files
+	Duration time.Duration
+}
+
+// add adds the statistics of other to s
+func (s *SyncStats) add(other SyncStats) {
+	s.Files += other.Files
+	s.Bytes += other.Bytes
+	s.TransferredBytes += other.TransferredBytes
+	s.Duration += other.Duration
+	if other.Compression != "" {
+		s.Compression = other.Compression
+	}
+}
+
+func (s SyncStats) String() string {
+	transfer := "uncompressed"
+	if s.Compression != "" {
+		transfer = fmt.Sprintf("%s transferred with %s", formatBytes(s.TransferredBytes), s.Compression)
+	}
+	return fmt.Sprintf("%d files, %s (%s) in %s", s.Files, formatBytes(s.Bytes), transfer, s.Duration.Round(time.Millisecond))
+}
+
+// formatBytes returns a human-readable representation of a number of bytes
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// countingWriter is a writer counting the bytes written into w
+type countingWriter struct {
+	w     io.Writer
+	count int64
+}
+
+func (o *countingWriter) Write(p []byte) (int, error) {
+	n, err := o.w.Write(p)
+	o.count += int64(n)
+	return n, err
+}
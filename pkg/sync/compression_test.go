@@ -0,0 +1,162 @@
+package sync
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/klauspost/compress/zstd"
+)
+
+func Test_selectCompression(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name:   "no decompressor",
+			output: "",
+			want:   "",
+		},
+		{
+			name:   "gzip only",
+			output: "gzip\n",
+			want:   "gzip",
+		},
+		{
+			name:   "zstd preferred over gzip",
+			output: "zstd\ngzip\n",
+			want:   "zstd",
+		},
+		{
+			name:   "unknown decompressor",
+			output: "bzip2\n",
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectCompression(tt.output)
+			gotName := ""
+			if got != nil {
+				gotName = got.name
+			}
+			if gotName != tt.want {
+				t.Errorf("selectCompression() = %q, want %q", gotName, tt.want)
+			}
+		})
+	}
+}
+
+func Test_compressions(t *testing.T) {
+	readers := map[string]func(io.Reader) (io.Reader, error){
+		"zstd": func(r io.Reader) (io.Reader, error) {
+			return zstd.NewReader(r)
+		},
+		"gzip": func(r io.Reader) (io.Reader, error) {
+			return gzip.NewReader(r)
+		},
+	}
+	content := bytes.Repeat([]byte("some content to compress "), 1000)
+	for _, c := range compressions {
+		t.Run(c.name, func(t *testing.T) {
+			var compressed bytes.Buffer
+			w, err := c.newWriter(&compressed)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err = w.Write(content); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err = w.Close(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if compressed.Len() >= len(content) {
+				t.Errorf("content not compressed: %d bytes, compressed %d bytes", len(content), compressed.Len())
+			}
+
+			newReader, found := readers[c.name]
+			if !found {
+				t.Fatalf("no reader for compression %q", c.name)
+			}
+			r, err := newReader(&compressed)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(content, got) {
+				t.Errorf("decompressed content differs from original content")
+			}
+		})
+	}
+}
+
+func Test_getCmdToExtractArchive(t *testing.T) {
+	tests := []struct {
+		name         string
+		decompressor string
+		want         []string
+	}{
+		{
+			name: "uncompressed",
+			want: []string{"tar", "xf", "-", "-C", "/projects", "--no-same-owner"},
+		},
+		{
+			name:         "compressed",
+			decompressor: "zstd",
+			want:         []string{"sh", "-c", `zstd -dc | tar xf - -C "$1" --no-same-owner`, "sh", "/projects"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getCmdToExtractArchive("/projects", tt.decompressor)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("getCmdToExtractArchive() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncStats_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats SyncStats
+		want  string
+	}{
+		{
+			name: "uncompressed",
+			stats: SyncStats{
+				Files:            3,
+				Bytes:            512,
+				TransferredBytes: 512,
+				Duration:         1500 * time.Millisecond,
+			},
+			want: "3 files, 512 B (uncompressed) in 1.5s",
+		},
+		{
+			name: "compressed",
+			stats: SyncStats{
+				Files:            1200,
+				Bytes:            3 * 1024 * 1024,
+				TransferredBytes: 1536,
+				Compression:      "zstd",
+				Duration:         2 * time.Second,
+			},
+			want: "1200 files, 3.0 MiB (1.5 KiB transferred with zstd) in 2s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stats.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,12 +3,14 @@ package sync
 import (
 	taro "archive/tar"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/redhat-developer/odo/pkg/log"
 	"github.com/redhat-developer/odo/pkg/testingutil/filesystem"
@@ -25,7 +27,9 @@ import (
 // During copying binary components, localPath represent base directory path to binary and copyFiles contains path of binary
 // During copying local source components, localPath represent base directory path whereas copyFiles is empty
 // During `odo watch`, localPath represent base directory path whereas copyFiles contains list of changed Files
-func (a SyncClient) CopyFile(localPath string, compInfo ComponentInfo, targetPath string, copyFiles []string, globExps []string, ret util.IndexerRet) error {
+// The archive is compressed when the container is able to decompress it, unless compression is disabled by the preferences.
+// It returns the statistics of the transfer.
+func (a SyncClient) CopyFile(localPath string, compInfo ComponentInfo, targetPath string, copyFiles []string, globExps []string, ret util.IndexerRet) (SyncStats, error) {
 
 	// Destination is set to "ToSlash" as all containers being ran within OpenShift / S2I are all
 	// Linux based and thus: "\opt\app-root\src" would not work correctly.
@@ -33,31 +37,70 @@ func (a SyncClient) CopyFile(localPath string, compInfo ComponentInfo, targetPat
 	targetPath = filepath.ToSlash(targetPath)
 
 	klog.V(4).Infof("CopyFile arguments: localPath %s, dest %s, targetPath %s, copyFiles %s, globalExps %s", localPath, dest, targetPath, copyFiles, globExps)
+
+	var stats SyncStats
+	comp := a.getCompression(compInfo)
+	if comp != nil {
+		stats.Compression = comp.name
+	}
+
+	start := time.Now()
 	reader, writer := io.Pipe()
+	transferred := &countingWriter{w: writer}
+	archived := transferred
+	done := make(chan struct{})
+	var tarErr error
 	// inspired from https://github.com/kubernetes/kubernetes/blob/master/pkg/kubectl/cmd/cp.go#L235
 	go func() {
+		defer close(done)
 		defer writer.Close()
 
-		err := makeTar(localPath, dest, writer, copyFiles, globExps, ret, filesystem.DefaultFs{})
-		if err != nil {
-			log.Errorf("Error while creating tar: %#v", err)
-			os.Exit(1)
+		var compressor io.WriteCloser
+		if comp != nil {
+			compressor, tarErr = comp.newWriter(transferred)
+			if tarErr != nil {
+				_ = writer.CloseWithError(tarErr)
+				return
+			}
+			archived = &countingWriter{w: compressor}
 		}
 
+		stats.Files, tarErr = makeTar(localPath, dest, archived, copyFiles, globExps, ret, filesystem.DefaultFs{})
+		if tarErr == nil && compressor != nil {
+			tarErr = compressor.Close()
+		}
+		if tarErr != nil {
+			_ = writer.CloseWithError(tarErr)
+		}
 	}()
 
-	err := a.ExtractProjectToComponent(compInfo.ContainerName, compInfo.PodName, targetPath, reader)
+	decompressor := ""
+	if comp != nil {
+		decompressor = comp.name
+	}
+	err := a.ExtractProjectToComponent(compInfo.ContainerName, compInfo.PodName, targetPath, decompressor, reader)
+	// unblock the archive creation, if the archive has not been read entirely
+	_ = reader.Close()
+	<-done
 	if err != nil {
-		return err
+		return SyncStats{}, err
+	}
+	if tarErr != nil && !errors.Is(tarErr, io.ErrClosedPipe) {
+		return SyncStats{}, fmt.Errorf("error while creating tar: %w", tarErr)
 	}
 
-	return nil
+	stats.Duration = time.Since(start)
+	stats.Bytes = archived.count
+	stats.TransferredBytes = transferred.count
+	klog.V(3).Infof("Synced into container %s: %s", compInfo.ContainerName, stats)
+	return stats, nil
 }
 
-// ExtractProjectToComponent extracts the project archive(tar) to the target path from the reader stdin
-func (a SyncClient) ExtractProjectToComponent(containerName, podName string, targetPath string, stdin io.Reader) error {
+// ExtractProjectToComponent extracts the project archive(tar) to the target path from the reader stdin.
+// If decompressor is not empty, the archive is decompressed in the container by the decompressor command before being extracted.
+func (a SyncClient) ExtractProjectToComponent(containerName, podName string, targetPath string, decompressor string, stdin io.Reader) error {
 	// cmdArr will run inside container
-	cmdArr := []string{"tar", "xf", "-", "-C", targetPath, "--no-same-owner"}
+	cmdArr := getCmdToExtractArchive(targetPath, decompressor)
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 	klog.V(3).Infof("Executing command %s", strings.Join(cmdArr, " "))
@@ -74,6 +117,15 @@ func (a SyncClient) ExtractProjectToComponent(containerName, podName string, tar
 	return err
 }
 
+// getCmdToExtractArchive returns the command used to extract an archive read from stdin into targetPath,
+// after decompressing it with the decompressor command, if not empty
+func getCmdToExtractArchive(targetPath string, decompressor string) []string {
+	if decompressor == "" {
+		return []string{"tar", "xf", "-", "-C", targetPath, "--no-same-owner"}
+	}
+	return []string{"sh", "-c", decompressor + ` -dc | tar xf - -C "$1" --no-same-owner`, "sh", targetPath}
+}
+
 // checkFileExist check if given file exists or not
 func checkFileExistWithFS(fileName string, fs filesystem.Filesystem) bool {
 	_, err := fs.Stat(fileName)
@@ -82,8 +134,8 @@ func checkFileExistWithFS(fileName string, fs filesystem.Filesystem) bool {
 
 // makeTar function is copied from https://github.com/kubernetes/kubernetes/blob/master/pkg/kubectl/cmd/cp.go#L309
 // srcPath is ignored if files is set
-func makeTar(srcPath, destPath string, writer io.Writer, files []string, globExps []string, ret util.IndexerRet, fs filesystem.Filesystem) error {
-	// TODO: use compression here?
+// It returns the number of files and directories written into the archive
+func makeTar(srcPath, destPath string, writer io.Writer, files []string, globExps []string, ret util.IndexerRet, fs filesystem.Filesystem) (int, error) {
 	tarWriter := taro.NewWriter(writer)
 	defer tarWriter.Close()
 	srcPath = filepath.Clean(srcPath)
@@ -93,6 +145,7 @@ func makeTar(srcPath, destPath string, writer io.Writer, files []string, globExp
 	// are converted to forward.
 	destPath = filepath.ToSlash(filepath.Clean(destPath))
 	uniquePaths := make(map[string]bool)
+	count := 0
 	klog.V(4).Infof("makeTar arguments: srcPath: %s, destPath: %s, files: %+v", srcPath, destPath, files)
 	if len(files) != 0 {
 		ignoreMatcher := gitignore.CompileIgnoreLines(globExps...)
@@ -108,7 +161,7 @@ func makeTar(srcPath, destPath string, writer io.Writer, files []string, globExp
 
 				rel, err := filepath.Rel(srcPath, fileName)
 				if err != nil {
-					return err
+					return 0, err
 				}
 
 				matched := ignoreMatcher.MatchesPath(rel)
@@ -122,7 +175,7 @@ func makeTar(srcPath, destPath string, writer io.Writer, files []string, globExp
 				// now that the file exists, now we need to get the absolute path
 				fileAbsolutePath, err := dfutil.GetAbsPath(fileName)
 				if err != nil {
-					return err
+					return 0, err
 				}
 				klog.V(4).Infof("Got abs path: %s", fileAbsolutePath)
 				klog.V(4).Infof("Making %s relative to %s", srcPath, fileAbsolutePath)
@@ -131,7 +184,7 @@ func makeTar(srcPath, destPath string, writer io.Writer, files []string, globExp
 				// we get the relative path by joining the two
 				destFile, err := filepath.Rel(filepath.FromSlash(srcPath), filepath.FromSlash(fileAbsolutePath))
 				if err != nil {
-					return err
+					return 0, err
 				}
 
 				// Now we get the source file and join it to the base directory.
@@ -147,13 +200,14 @@ func makeTar(srcPath, destPath string, writer io.Writer, files []string, globExp
 				// The file could be a regular file or even a folder, so use recursiveTar which handles symlinks, regular files and folders
 				err = linearTar(filepath.Dir(srcPath), srcFile, filepath.Dir(destPath), destFile, tarWriter, fs)
 				if err != nil {
-					return err
+					return 0, err
 				}
+				count++
 			}
 		}
 	}
 
-	return nil
+	return count, nil
 }
 
 // linearTar function is a modified version of https://github.com/kubernetes/kubernetes/blob/master/pkg/kubectl/cmd/cp.go#L319
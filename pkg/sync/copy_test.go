@@ -336,7 +336,7 @@ func Test_makeTar(t *testing.T) {
 			go func() {
 				defer tarWriter.Close()
 				wantErr := tt.wantErr
-				if err := makeTar(tt.args.srcPath, tt.args.destPath, writer, tt.args.files, tt.args.globExps, tt.args.ret, fs); (err != nil) != wantErr {
+				if _, err := makeTar(tt.args.srcPath, tt.args.destPath, writer, tt.args.files, tt.args.globExps, tt.args.ret, fs); (err != nil) != wantErr {
 					t.Errorf("makeTar() error = %v, wantErr %v", err, tt.wantErr)
 					return
 				}
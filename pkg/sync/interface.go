@@ -20,10 +20,30 @@ type SyncParameters struct {
 	IgnoredFiles             []string // IgnoredFiles is the list of files to not push up to a component
 	DevfileScanIndexForWatch bool     // DevfileScanIndexForWatch is true if watch's push should regenerate the index file during SyncFiles, false otherwise. See 'pkg/sync/adapter.go' for details
 	ForcePush                bool
-	CompInfo                 ComponentInfo
+	CompInfos                []ComponentInfo // CompInfos describes the containers into which the files are synced, one for each container mounting the sources
 	Files                    map[string]string
+	Stats                    *SyncStats // Optional: if not nil, Stats is filled with the statistics of the transfer of the files into the containers
+}
+
+// SyncBackPath is a path in a container to copy back into the local workspace
+type SyncBackPath struct {
+	// ContainerPath is the path of a file or directory in the container, absolute or relative to the sync folder of the container
+	ContainerPath string
+	// LocalPath is the path of the file or directory in the local workspace, relative to the component directory
+	LocalPath string
+}
+
+// SyncBackParameters is a struct containing the parameters to be used when copying files from a container back to the local workspace
+type SyncBackParameters struct {
+	Path     string         // Path refers to the component directory into which the files are copied
+	CompInfo ComponentInfo  // CompInfo describes the container from which the files are copied
+	Paths    []SyncBackPath // Paths are the paths to copy
 }
 
 type Client interface {
 	SyncFiles(syncParameters SyncParameters) (bool, error)
+	// SyncBack copies the paths from the container back to the local workspace, and records the copied files
+	// into the file index, so they are not detected as local changes to sync into the container.
+	// It returns the list of local files written.
+	SyncBack(syncBackParameters SyncBackParameters) ([]string, error)
 }
@@ -33,6 +33,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// SyncBack mocks base method.
+func (m *MockClient) SyncBack(syncBackParameters SyncBackParameters) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncBack", syncBackParameters)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncBack indicates an expected call of SyncBack.
+func (mr *MockClientMockRecorder) SyncBack(syncBackParameters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncBack", reflect.TypeOf((*MockClient)(nil).SyncBack), syncBackParameters)
+}
+
 // SyncFiles mocks base method.
 func (m *MockClient) SyncFiles(syncParameters SyncParameters) (bool, error) {
 	m.ctrl.T.Helper()
@@ -11,6 +11,7 @@ import (
 
 	"github.com/redhat-developer/odo/pkg/exec"
 	"github.com/redhat-developer/odo/pkg/platform"
+	"github.com/redhat-developer/odo/pkg/preference"
 	"github.com/redhat-developer/odo/pkg/util"
 
 	"k8s.io/klog"
@@ -20,15 +21,21 @@ import (
 type SyncClient struct {
 	platformClient platform.Client
 	execClient     exec.Client
+	prefClient     preference.Client
+
+	// compressions caches the compression formats supported by the containers, indexed by pod and container names
+	compressions map[string]*compression
 }
 
 var _ Client = (*SyncClient)(nil)
 
 // NewSyncClient instantiates a new SyncClient
-func NewSyncClient(platformClient platform.Client, execClient exec.Client) *SyncClient {
+func NewSyncClient(platformClient platform.Client, execClient exec.Client, prefClient preference.Client) *SyncClient {
 	return &SyncClient{
 		platformClient: platformClient,
 		execClient:     execClient,
+		prefClient:     prefClient,
+		compressions:   map[string]*compression{},
 	}
 }
 
@@ -144,19 +151,28 @@ func (a SyncClient) SyncFiles(syncParameters SyncParameters) (bool, error) {
 		}
 	}
 
-	err := a.pushLocal(syncParameters.Path,
-		changedFiles,
-		deletedFiles,
-		syncParameters.ForcePush,
-		syncParameters.IgnoredFiles,
-		syncParameters.CompInfo,
-		ret,
-	)
-	if err != nil {
-		return false, fmt.Errorf("failed to sync to component with name %s: %w", syncParameters.CompInfo.ComponentName, err)
+	// The changes are computed once, and pushed into every container mounting the sources,
+	// each container having its own sync folder depending on its sourceMapping
+	var stats SyncStats
+	for _, compInfo := range syncParameters.CompInfos {
+		containerStats, err := a.pushLocal(syncParameters.Path,
+			changedFiles,
+			deletedFiles,
+			syncParameters.ForcePush,
+			syncParameters.IgnoredFiles,
+			compInfo,
+			ret,
+		)
+		if err != nil {
+			return false, fmt.Errorf("failed to sync to container %s of component with name %s: %w", compInfo.ContainerName, compInfo.ComponentName, err)
+		}
+		stats.add(containerStats)
+	}
+	if syncParameters.Stats != nil {
+		*syncParameters.Stats = stats
 	}
 	if forceWrite {
-		err = util.WriteFile(ret.NewFileMap, ret.ResolvedPath)
+		err := util.WriteFileIndex(&util.FileIndex{Files: ret.NewFileMap, Summary: ret.Summary}, ret.ResolvedPath)
 		if err != nil {
 			return false, fmt.Errorf("failed to write file: %w", err)
 		}
@@ -166,15 +182,16 @@ func (a SyncClient) SyncFiles(syncParameters SyncParameters) (bool, error) {
 }
 
 // pushLocal syncs source code from the user's disk to the component
-func (a SyncClient) pushLocal(path string, files []string, delFiles []string, isForcePush bool, globExps []string, compInfo ComponentInfo, ret util.IndexerRet) error {
+// It returns the statistics of the transfer of the files, if any
+func (a SyncClient) pushLocal(path string, files []string, delFiles []string, isForcePush bool, globExps []string, compInfo ComponentInfo, ret util.IndexerRet) (SyncStats, error) {
 	klog.V(4).Infof("Push: componentName: %s, path: %s, files: %s, delFiles: %s, isForcePush: %+v", compInfo.ComponentName, path, files, delFiles, isForcePush)
 
 	// Edge case: check to see that the path is NOT empty.
 	emptyDir, err := dfutil.IsEmpty(path)
 	if err != nil {
-		return fmt.Errorf("unable to check directory: %s: %w", path, err)
+		return SyncStats{}, fmt.Errorf("unable to check directory: %s: %w", path, err)
 	} else if emptyDir {
-		return fmt.Errorf("directory/file %s is empty", path)
+		return SyncStats{}, fmt.Errorf("directory/file %s is empty", path)
 	}
 
 	// Sync the files to the pod
@@ -187,7 +204,7 @@ func (a SyncClient) pushLocal(path string, files []string, delFiles []string, is
 
 		_, _, err = a.execClient.ExecuteCommand(cmdArr, compInfo.PodName, compInfo.ContainerName, false, nil, nil)
 		if err != nil {
-			return err
+			return SyncStats{}, err
 		}
 	}
 	// If there were any files deleted locally, delete them remotely too.
@@ -196,25 +213,26 @@ func (a SyncClient) pushLocal(path string, files []string, delFiles []string, is
 
 		_, _, err = a.execClient.ExecuteCommand(cmdArr, compInfo.PodName, compInfo.ContainerName, false, nil, nil)
 		if err != nil {
-			return err
+			return SyncStats{}, err
 		}
 	}
 
 	if !isForcePush {
 		if len(files) == 0 && len(delFiles) == 0 {
-			return nil
+			return SyncStats{}, nil
 		}
 	}
 
 	if isForcePush || len(files) > 0 {
 		klog.V(4).Infof("Copying files %s to pod", strings.Join(files, " "))
-		err = a.CopyFile(path, compInfo, syncFolder, files, globExps, ret)
+		stats, err := a.CopyFile(path, compInfo, syncFolder, files, globExps, ret)
 		if err != nil {
-			return fmt.Errorf("unable push files to pod: %w", err)
+			return SyncStats{}, fmt.Errorf("unable push files to pod: %w", err)
 		}
+		return stats, nil
 	}
 
-	return nil
+	return SyncStats{}, nil
 }
 
 // updateIndexWithWatchChanges uses the pushParameters.WatchDeletedFiles and pushParamters.WatchFiles to update
@@ -272,7 +290,7 @@ func updateIndexWithWatchChanges(syncParameters SyncParameters) error {
 	}
 
 	// Write the result
-	return util.WriteFile(fileIndex.Files, indexFilePath)
+	return util.WriteFileIndex(fileIndex, indexFilePath)
 
 }
 
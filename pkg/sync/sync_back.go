@@ -0,0 +1,176 @@
+package sync
+
+import (
+	taro "archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/redhat-developer/odo/pkg/util"
+
+	"k8s.io/klog"
+)
+
+// SyncBack copies the paths from the container back to the local workspace, and records the copied files
+// into the file index, so they are not detected as local changes to sync into the container.
+// It returns the list of local files written.
+func (a SyncClient) SyncBack(syncBackParameters SyncBackParameters) ([]string, error) {
+	var written []string
+	for _, p := range syncBackParameters.Paths {
+		files, err := a.syncBackPath(syncBackParameters.Path, syncBackParameters.CompInfo, p)
+		written = append(written, files...)
+		if err != nil {
+			// record the files already written before returning
+			if indexErr := recordSyncedBackFiles(syncBackParameters.Path, written); indexErr != nil {
+				klog.V(4).Infof("unable to record files synced back into the index: %v", indexErr)
+			}
+			return written, fmt.Errorf("unable to sync back %s from container %s: %w", p.ContainerPath, syncBackParameters.CompInfo.ContainerName, err)
+		}
+	}
+	if len(written) == 0 {
+		return nil, nil
+	}
+	return written, recordSyncedBackFiles(syncBackParameters.Path, written)
+}
+
+// syncBackPath archives the path in the container with tar, and extracts the archive into the local workspace
+func (a SyncClient) syncBackPath(componentPath string, compInfo ComponentInfo, p SyncBackPath) ([]string, error) {
+	containerPath := p.ContainerPath
+	if !path.IsAbs(containerPath) {
+		containerPath = path.Join(compInfo.SyncFolder, containerPath)
+	}
+	localPath := filepath.Join(componentPath, filepath.FromSlash(p.LocalPath))
+	if !isInDirectory(componentPath, localPath) {
+		return nil, fmt.Errorf("local path %q is outside of the component directory", p.LocalPath)
+	}
+	klog.V(4).Infof("SyncBack: container %s, containerPath %s, localPath %s", compInfo.ContainerName, containerPath, localPath)
+
+	reader, writer := io.Pipe()
+	var stderr bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		err := a.platformClient.ExecCMDInContainer(compInfo.ContainerName, compInfo.PodName, getCmdToArchivePath(containerPath), writer, &stderr, nil, false)
+		// a nil error closes the pipe normally
+		_ = writer.CloseWithError(err)
+		done <- err
+	}()
+
+	files, err := extractSyncedBackArchive(reader, localPath)
+	// unblock the command if the extraction stopped before the end of the archive
+	_ = reader.Close()
+	execErr := <-done
+	if err != nil && !errors.Is(err, execErr) {
+		return files, err
+	}
+	if execErr != nil {
+		return files, fmt.Errorf("%w: %s", execErr, strings.TrimSpace(stderr.String()))
+	}
+	return files, nil
+}
+
+// getCmdToArchivePath returns the command writing into its standard output a tar archive of the content of containerPath
+// if it is a directory, with entries prefixed with "./", or of the file containerPath only, with an entry named as its base name
+func getCmdToArchivePath(containerPath string) []string {
+	return []string{"sh", "-c", `if [ -d "$1" ]; then tar cf - -C "$1" .; else tar cf - -C "$(dirname "$1")" "$(basename "$1")"; fi`, "sh", containerPath}
+}
+
+// extractSyncedBackArchive extracts the tar archive read from r into localPath, and returns the list of files written.
+// When the archive contains the content of a directory, localPath is the directory into which the content is extracted,
+// otherwise localPath is the file into which the single file of the archive is written.
+// Entries other than files and directories are ignored.
+func extractSyncedBackArchive(r io.Reader, localPath string) ([]string, error) {
+	var written []string
+	tarReader := taro.NewReader(r)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return written, nil
+		}
+		if err != nil {
+			return written, err
+		}
+
+		target := localPath
+		if header.Name == "." || strings.HasPrefix(header.Name, "./") {
+			target = filepath.Join(localPath, filepath.FromSlash(path.Clean(header.Name)))
+		}
+		if !isInDirectory(localPath, target) {
+			return written, fmt.Errorf("archive entry %q is outside of %s", header.Name, localPath)
+		}
+
+		switch header.Typeflag {
+		case taro.TypeDir:
+			if err = os.MkdirAll(target, 0750); err != nil {
+				return written, err
+			}
+		case taro.TypeReg:
+			if err = writeSyncedBackFile(tarReader, target, header.FileInfo().Mode().Perm(), header.ModTime); err != nil {
+				return written, err
+			}
+			written = append(written, target)
+		default:
+			klog.V(4).Infof("ignoring archive entry %q of type %q", header.Name, header.Typeflag)
+		}
+	}
+}
+
+// writeSyncedBackFile writes the content read from r into target, and sets its modification time to modTime
+func writeSyncedBackFile(r io.Reader, target string, perm os.FileMode, modTime time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+		return err
+	}
+	// #nosec G304 -- target is checked to be inside the local path
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	// #nosec G110 -- the archive comes from the container of the component
+	if _, err = io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Chtimes(target, modTime, modTime)
+}
+
+// isInDirectory returns true if target is dir or is a path under dir
+func isInDirectory(dir string, target string) bool {
+	rel, err := filepath.Rel(dir, target)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// recordSyncedBackFiles adds the files to the file index of the component directory
+func recordSyncedBackFiles(componentPath string, files []string) error {
+	if len(files) == 0 {
+		return nil
+	}
+	indexFilePath, err := util.ResolveIndexFilePath(componentPath)
+	if err != nil {
+		return fmt.Errorf("unable to resolve path: %s: %w", componentPath, err)
+	}
+	fileIndex, err := util.ReadFileIndex(indexFilePath)
+	if err != nil {
+		return fmt.Errorf("unable to read index from path: %s: %w", indexFilePath, err)
+	}
+	for _, file := range files {
+		relativePath, fileData, err := util.GenerateNewFileDataEntry(file, componentPath)
+		if err != nil {
+			klog.V(4).Infof("Error occurred for %s: %v", file, err)
+			continue
+		}
+		fileIndex.Files[relativePath] = *fileData
+		klog.V(4).Infof("Added synced back file in index: %s", relativePath)
+	}
+	return util.WriteFileIndex(fileIndex, indexFilePath)
+}
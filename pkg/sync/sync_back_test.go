@@ -0,0 +1,243 @@
+package sync
+
+import (
+	taro "archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/redhat-developer/odo/pkg/kclient"
+	"github.com/redhat-developer/odo/pkg/util"
+)
+
+type archiveEntry struct {
+	name    string
+	dir     bool
+	content string
+}
+
+func makeSyncBackArchive(t *testing.T, entries []archiveEntry, modTime time.Time) []byte {
+	var buf bytes.Buffer
+	tw := taro.NewWriter(&buf)
+	for _, e := range entries {
+		header := &taro.Header{
+			Name:    e.name,
+			Mode:    0644,
+			ModTime: modTime,
+		}
+		if e.dir {
+			header.Typeflag = taro.TypeDir
+			header.Mode = 0755
+		} else {
+			header.Typeflag = taro.TypeReg
+			header.Size = int64(len(e.content))
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !e.dir {
+			if _, err := tw.Write([]byte(e.content)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func Test_extractSyncedBackArchive(t *testing.T) {
+	tests := []struct {
+		name      string
+		localPath string
+		entries   []archiveEntry
+		wantFiles map[string]string
+		wantErr   bool
+	}{
+		{
+			name:      "content of a directory",
+			localPath: "generated",
+			entries: []archiveEntry{
+				{name: "./", dir: true},
+				{name: "./a.txt", content: "a"},
+				{name: "./sub/", dir: true},
+				{name: "./sub/b.txt", content: "b"},
+			},
+			wantFiles: map[string]string{
+				filepath.Join("generated", "a.txt"):        "a",
+				filepath.Join("generated", "sub", "b.txt"): "b",
+			},
+		},
+		{
+			name:      "single file",
+			localPath: "package-lock.json",
+			entries: []archiveEntry{
+				{name: "package-lock.json", content: "{}"},
+			},
+			wantFiles: map[string]string{
+				"package-lock.json": "{}",
+			},
+		},
+		{
+			name:      "single file renamed",
+			localPath: filepath.Join("out", "lock.json"),
+			entries: []archiveEntry{
+				{name: "package-lock.json", content: "{}"},
+			},
+			wantFiles: map[string]string{
+				filepath.Join("out", "lock.json"): "{}",
+			},
+		},
+		{
+			name:      "entry outside of the local path",
+			localPath: "generated",
+			entries: []archiveEntry{
+				{name: "./../evil.txt", content: "evil"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			modTime := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+			archive := makeSyncBackArchive(t, tt.entries, modTime)
+
+			got, err := extractSyncedBackArchive(bytes.NewReader(archive), filepath.Join(dir, tt.localPath))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractSyncedBackArchive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if _, err = os.Stat(filepath.Join(dir, "evil.txt")); err == nil {
+					t.Errorf("file outside of the local path should not be written")
+				}
+				return
+			}
+
+			gotFiles := map[string]string{}
+			for _, f := range got {
+				rel, err := filepath.Rel(dir, f)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				content, err := os.ReadFile(f)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				gotFiles[rel] = string(content)
+
+				info, err := os.Stat(f)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !info.ModTime().Equal(modTime) {
+					t.Errorf("modification time of %s = %v, want %v", rel, info.ModTime(), modTime)
+				}
+			}
+			if diff := cmp.Diff(tt.wantFiles, gotFiles); diff != "" {
+				t.Errorf("extractSyncedBackArchive() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncClient_SyncBack(t *testing.T) {
+	compInfo := ComponentInfo{
+		ComponentName: "comp",
+		PodName:       "pod",
+		ContainerName: "runtime",
+		SyncFolder:    "/projects",
+	}
+
+	tests := []struct {
+		name          string
+		execErr       error
+		wantErr       bool
+		wantContainer string
+	}{
+		{
+			name:          "files are copied and recorded into the index",
+			wantContainer: "/projects/target",
+		},
+		{
+			name:          "error executing the command",
+			execErr:       errors.New("tar failed"),
+			wantErr:       true,
+			wantContainer: "/projects/target",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.Mkdir(filepath.Join(dir, util.DotOdoDirectory), 0750); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			archive := makeSyncBackArchive(t, []archiveEntry{
+				{name: "./", dir: true},
+				{name: "./a.txt", content: "a"},
+			}, time.Now())
+
+			ctrl := gomock.NewController(t)
+			kc := kclient.NewMockClientInterface(ctrl)
+			kc.EXPECT().ExecCMDInContainer("runtime", "pod", gomock.Any(), gomock.Any(), gomock.Any(), nil, false).
+				DoAndReturn(func(containerName, podName string, cmd []string, stdout, stderr io.Writer, stdin io.Reader, tty bool) error {
+					if got := cmd[len(cmd)-1]; got != tt.wantContainer {
+						t.Errorf("container path = %q, want %q", got, tt.wantContainer)
+					}
+					if tt.execErr != nil {
+						return tt.execErr
+					}
+					_, err := stdout.Write(archive)
+					return err
+				})
+
+			syncClient := NewSyncClient(kc, nil, nil)
+			_, err := syncClient.SyncBack(SyncBackParameters{
+				Path:     dir,
+				CompInfo: compInfo,
+				Paths: []SyncBackPath{
+					{ContainerPath: "target", LocalPath: "target"},
+				},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SyncBack() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			indexFilePath, err := util.ResolveIndexFilePath(dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			fileIndex, err := util.ReadFileIndex(indexFilePath)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var gotIndexed []string
+			for f := range fileIndex.Files {
+				gotIndexed = append(gotIndexed, f)
+			}
+			if diff := cmp.Diff([]string{filepath.Join("target", "a.txt")}, gotIndexed); diff != "" {
+				t.Errorf("indexed files mismatch (-want +got):\n%s", diff)
+			}
+
+			changed, err := util.RemoveUnchangedFiles([]string{filepath.Join(dir, "target", "a.txt")}, dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(changed) != 0 {
+				t.Errorf("files synced back should not be detected as changed, got %v", changed)
+			}
+		})
+	}
+}
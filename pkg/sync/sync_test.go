@@ -15,6 +15,7 @@ import (
 
 	"github.com/redhat-developer/odo/pkg/exec"
 	"github.com/redhat-developer/odo/pkg/kclient"
+	"github.com/redhat-developer/odo/pkg/preference"
 	"github.com/redhat-developer/odo/pkg/util"
 	"github.com/redhat-developer/odo/tests/helper"
 )
@@ -99,6 +100,8 @@ func TestSyncFiles(t *testing.T) {
 	kc := kclient.NewMockClientInterface(ctrl)
 	kc.EXPECT().ExecCMDInContainer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil).AnyTimes()
+	prefClient := preference.NewMockClient(ctrl)
+	prefClient.EXPECT().GetSyncCompression().Return(true).AnyTimes()
 
 	// Assert that Bar() is invoked.
 	defer ctrl.Finish()
@@ -116,8 +119,10 @@ func TestSyncFiles(t *testing.T) {
 				WatchFiles:        []string{},
 				WatchDeletedFiles: []string{},
 				IgnoredFiles:      []string{},
-				CompInfo: ComponentInfo{
-					ContainerName: "abcd",
+				CompInfos: []ComponentInfo{
+					{
+						ContainerName: "abcd",
+					},
 				},
 				ForcePush: true,
 			},
@@ -131,8 +136,10 @@ func TestSyncFiles(t *testing.T) {
 				WatchFiles:        []string{},
 				WatchDeletedFiles: []string{},
 				IgnoredFiles:      []string{},
-				CompInfo: ComponentInfo{
-					ContainerName: "abcd",
+				CompInfos: []ComponentInfo{
+					{
+						ContainerName: "abcd",
+					},
 				},
 				ForcePush: false,
 			},
@@ -146,8 +153,10 @@ func TestSyncFiles(t *testing.T) {
 				WatchFiles:        []string{},
 				WatchDeletedFiles: []string{},
 				IgnoredFiles:      []string{},
-				CompInfo: ComponentInfo{
-					ContainerName: "abcd",
+				CompInfos: []ComponentInfo{
+					{
+						ContainerName: "abcd",
+					},
 				},
 				ForcePush: false,
 			},
@@ -161,9 +170,11 @@ func TestSyncFiles(t *testing.T) {
 				WatchFiles:        []string{path.Join(directory, "test.log")},
 				WatchDeletedFiles: []string{},
 				IgnoredFiles:      []string{},
-				CompInfo: ComponentInfo{
-					ComponentName: testComponentName,
-					ContainerName: "abcd",
+				CompInfos: []ComponentInfo{
+					{
+						ComponentName: testComponentName,
+						ContainerName: "abcd",
+					},
 				},
 				ForcePush: false,
 			},
@@ -174,7 +185,7 @@ func TestSyncFiles(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			execClient := exec.NewExecClient(kc)
-			syncAdapter := NewSyncClient(kc, execClient)
+			syncAdapter := NewSyncClient(kc, execClient, prefClient)
 			isPushRequired, err := syncAdapter.SyncFiles(tt.syncParameters)
 			if !tt.wantErr && err != nil {
 				t.Errorf("TestSyncFiles error: unexpected error when syncing files %v", err)
@@ -195,6 +206,59 @@ func TestSyncFiles(t *testing.T) {
 	}
 }
 
+func TestSyncFiles_multipleContainers(t *testing.T) {
+	directory := t.TempDir()
+	if err := helper.CreateFileWithContent(filepath.Join(directory, "red.js"), "hello world"); err != nil {
+		t.Fatalf("the red.js file was not created: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	kc := kclient.NewMockClientInterface(ctrl)
+	prefClient := preference.NewMockClient(ctrl)
+	prefClient.EXPECT().GetSyncCompression().Return(false).AnyTimes()
+
+	// The files are extracted into each container, in its own sync folder, after the previous files are deleted
+	// as the push is forced. The sync folder is created first when it is not the default one
+	gomock.InOrder(
+		kc.EXPECT().ExecCMDInContainer("abcd", "mypod", []string{"rm", "-rf", generator.DevfileSourceVolumeMount + "/*"},
+			gomock.Any(), gomock.Any(), nil, false).Return(nil),
+		kc.EXPECT().ExecCMDInContainer("abcd", "mypod", []string{"tar", "xf", "-", "-C", generator.DevfileSourceVolumeMount, "--no-same-owner"},
+			gomock.Any(), gomock.Any(), gomock.Any(), false).Return(nil),
+		kc.EXPECT().ExecCMDInContainer("efgh", "mypod", []string{"mkdir", "-p", "/src"},
+			gomock.Any(), gomock.Any(), nil, false).Return(nil),
+		kc.EXPECT().ExecCMDInContainer("efgh", "mypod", []string{"rm", "-rf", "/src/*"},
+			gomock.Any(), gomock.Any(), nil, false).Return(nil),
+		kc.EXPECT().ExecCMDInContainer("efgh", "mypod", []string{"tar", "xf", "-", "-C", "/src", "--no-same-owner"},
+			gomock.Any(), gomock.Any(), gomock.Any(), false).Return(nil),
+	)
+
+	syncAdapter := NewSyncClient(kc, exec.NewExecClient(kc), prefClient)
+	isPushRequired, err := syncAdapter.SyncFiles(SyncParameters{
+		Path: directory,
+		CompInfos: []ComponentInfo{
+			{
+				ComponentName: "test",
+				ContainerName: "abcd",
+				PodName:       "mypod",
+				SyncFolder:    generator.DevfileSourceVolumeMount,
+			},
+			{
+				ComponentName: "test",
+				ContainerName: "efgh",
+				PodName:       "mypod",
+				SyncFolder:    "/src",
+			},
+		},
+		ForcePush: true,
+	})
+	if err != nil {
+		t.Fatalf("SyncFiles() unexpected error: %v", err)
+	}
+	if !isPushRequired {
+		t.Errorf("SyncFiles() isPushRequired = false, want true")
+	}
+}
+
 func TestPushLocal(t *testing.T) {
 
 	testComponentName := "test"
@@ -214,6 +278,8 @@ func TestPushLocal(t *testing.T) {
 	kc := kclient.NewMockClientInterface(ctrl)
 	kc.EXPECT().ExecCMDInContainer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil).AnyTimes()
+	prefClient := preference.NewMockClient(ctrl)
+	prefClient.EXPECT().GetSyncCompression().Return(true).AnyTimes()
 
 	// Assert that Bar() is invoked.
 	defer ctrl.Finish()
@@ -314,8 +380,8 @@ func TestPushLocal(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			execClient := exec.NewExecClient(kc)
-			syncAdapter := NewSyncClient(kc, execClient)
-			err := syncAdapter.pushLocal(tt.path, tt.files, tt.delFiles, tt.isForcePush, []string{}, tt.compInfo, util.IndexerRet{})
+			syncAdapter := NewSyncClient(kc, execClient, prefClient)
+			_, err := syncAdapter.pushLocal(tt.path, tt.files, tt.delFiles, tt.isForcePush, []string{}, tt.compInfo, util.IndexerRet{})
 			if !tt.wantErr && err != nil {
 				t.Errorf("TestPushLocal error: error pushing files: %v", err)
 			}
@@ -1,12 +1,17 @@
 package util
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	dfutil "github.com/devfile/library/pkg/util"
@@ -26,6 +31,16 @@ const DotGitIgnoreFile = ".gitignore"
 type FileIndex struct {
 	metav1.TypeMeta
 	Files map[string]FileData
+	// Summary describes the run of the indexer which generated the index, if any
+	Summary *IndexSummary `json:",omitempty"`
+}
+
+// IndexSummary describes a run of the indexer
+type IndexSummary struct {
+	// RulesHash is a hash of the ignore rules and remote directories used to select the files to index
+	RulesHash string
+	// StartedAt is the time at which the indexer started to check the files
+	StartedAt time.Time
 }
 
 // NewFileIndex returns a fileIndex
@@ -153,6 +168,7 @@ type IndexerRet struct {
 	RemoteDeleted []string
 	NewFileMap    map[string]FileData
 	ResolvedPath  string
+	Summary       *IndexSummary
 }
 
 // CalculateFileDataKeyFromPath converts an absolute path to relative (and converts to OS-specific paths) for use
@@ -188,6 +204,33 @@ func GenerateNewFileDataEntry(absolutePath string, rootDirectory string) (string
 	}, nil
 }
 
+// RemoveUnchangedFiles returns the files, given as absolute paths, which are not recorded in the index of rootDirectory
+// with their current size and modification time. Files which cannot be checked are considered as changed.
+func RemoveUnchangedFiles(files []string, rootDirectory string) ([]string, error) {
+	indexFilePath, err := ResolveIndexFilePath(rootDirectory)
+	if err != nil {
+		return nil, err
+	}
+	fileIndex, err := ReadFileIndex(indexFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, file := range files {
+		relativeFilename, fileData, err := GenerateNewFileDataEntry(file, rootDirectory)
+		if err == nil {
+			if indexed, found := fileIndex.Files[relativeFilename]; found &&
+				indexed.Size == fileData.Size && indexed.LastModifiedDate.Equal(fileData.LastModifiedDate) {
+				klog.V(4).Infof("File %s is unchanged since it was recorded in the index", relativeFilename)
+				continue
+			}
+		}
+		result = append(result, file)
+	}
+	return result, nil
+}
+
 // write writes the map of walked files and info about them, in a file
 // filePath is the location of the file to which it is supposed to be written
 func write(filePath string, fi *FileIndex) error {
@@ -210,6 +253,14 @@ func WriteFile(newFileMap map[string]FileData, resolvedPath string) error {
 	return err
 }
 
+// WriteFileIndex writes the files of the file index and the summary of the run of the indexer to the file resolvedPath
+func WriteFileIndex(fi *FileIndex, resolvedPath string) error {
+	newfi := NewFileIndex()
+	newfi.Files = fi.Files
+	newfi.Summary = fi.Summary
+	return write(resolvedPath, newfi)
+}
+
 // RunIndexerWithRemote reads the existing index from the given directory and runs the indexer on it
 // with the given ignore rules
 // it also adds the file index to the .gitignore file and resolves the path
@@ -234,6 +285,12 @@ func RunIndexerWithRemote(directory string, originalIgnoreRules []string, remote
 	return returnedIndex, nil
 }
 
+// RunIndexerWithExistingFileIndex runs the indexer on the given directory with the given ignore rules
+// and returns the changes since existingFileIndex was created, without reading nor writing the index file
+func RunIndexerWithExistingFileIndex(directory string, ignoreRules []string, existingFileIndex *FileIndex) (IndexerRet, error) {
+	return runIndexerWithExistingFileIndex(filepath.FromSlash(directory), ignoreRules, nil, existingFileIndex)
+}
+
 // runIndexerWithExistingFileIndex visits the given directory and creates the new index data
 // it ignores the files and folders satisfying the ignoreRules
 func runIndexerWithExistingFileIndex(directory string, ignoreRules []string, remoteDirectories map[string]string, existingFileIndex *FileIndex) (ret IndexerRet, err error) {
@@ -241,6 +298,10 @@ func runIndexerWithExistingFileIndex(directory string, ignoreRules []string, rem
 	srcPath := directory
 
 	ret.NewFileMap = make(map[string]FileData)
+	ret.Summary = &IndexSummary{
+		RulesHash: hashIndexerRules(ignoreRules, remoteDirectories),
+		StartedAt: time.Now(),
+	}
 
 	fileChanged := make(map[string]bool)
 	filesDeleted := make(map[string]bool)
@@ -339,6 +400,7 @@ func runIndexerWithExistingFileIndex(directory string, ignoreRules []string, rem
 	}
 
 	// find files which are deleted/renamed
+	ignoreMatcher := gitignore.CompileIgnoreLines(ignoreRules...)
 	for fileName, value := range existingFileIndex.Files {
 		if _, ok := ret.NewFileMap[fileName]; !ok {
 			klog.V(4).Infof("Deleting file: %s", fileName)
@@ -349,7 +411,6 @@ func runIndexerWithExistingFileIndex(directory string, ignoreRules []string, rem
 					fileRemoteChanged[remote] = true
 				}
 			} else {
-				ignoreMatcher := gitignore.CompileIgnoreLines(ignoreRules...)
 				matched := ignoreMatcher.MatchesPath(fileName)
 				if matched {
 					continue
@@ -396,6 +457,7 @@ type recursiveCheckerPathOptions struct {
 // ignoreRules are used to ignore file and folders
 // remoteDirectories are used to find the remote destination of the file/folder and to delete files/folders left behind after the attributes are changed
 // existingFileIndex is used to check for file/folder changes
+// The inner files and folders are checked concurrently, by at most indexerWorkers goroutines.
 func recursiveChecker(pathOptions recursiveCheckerPathOptions, ignoreRules []string, remoteDirectories map[string]string, existingFileIndex FileIndex) (IndexerRet, error) {
 	klog.V(4).Infof("recursiveTar arguments: srcBase: %s, srcFile: %s, destBase: %s, destFile: %s", pathOptions.srcBase, pathOptions.srcFile, pathOptions.destBase, pathOptions.destFile)
 
@@ -420,16 +482,11 @@ func recursiveChecker(pathOptions recursiveCheckerPathOptions, ignoreRules []str
 		return IndexerRet{}, err
 	}
 
-	var ret IndexerRet
-	ret.NewFileMap = make(map[string]FileData)
+	c := newChecker(pathOptions.directory, ignoreRules, remoteDirectories, existingFileIndex)
 
-	fileChanged := make(map[string]bool)
-	fileRemoteChanged := make(map[string]bool)
-
-	ignoreMatcher := gitignore.CompileIgnoreLines(ignoreRules...)
-
-	for _, matchedPath := range matchedPathsDir {
-		stat, err := os.Stat(matchedPath)
+	stats := make([]os.FileInfo, len(matchedPathsDir))
+	for i, matchedPath := range matchedPathsDir {
+		stats[i], err = os.Stat(matchedPath)
 		if err != nil {
 			return IndexerRet{}, err
 		}
@@ -439,109 +496,264 @@ func recursiveChecker(pathOptions recursiveCheckerPathOptions, ignoreRules []str
 		if err != nil {
 			return IndexerRet{}, err
 		}
-		match := ignoreMatcher.MatchesPath(rel)
 		// the folder matches a glob rule and thus should be skipped
-		if match {
+		if c.ignoreMatcher.MatchesPath(rel) || isSkippedDirectory(stats[i]) {
 			return IndexerRet{}, nil
 		}
+	}
 
-		if joinedRelPath != "." {
-			// check for changes in the size and the modified date of the file or folder
-			// and if the file is newly added
-			if _, ok := existingFileIndex.Files[joinedRelPath]; !ok {
-				fileChanged[matchedPath] = true
-				klog.V(4).Infof("file added: %s", matchedPath)
-			} else if !stat.ModTime().Equal(existingFileIndex.Files[joinedRelPath].LastModifiedDate) {
-				fileChanged[matchedPath] = true
-				klog.V(4).Infof("last modified date changed: %s", matchedPath)
-			} else if stat.Size() != existingFileIndex.Files[joinedRelPath].Size {
-				fileChanged[matchedPath] = true
-				klog.V(4).Infof("size changed: %s", matchedPath)
-			}
-		}
+	for i, matchedPath := range matchedPathsDir {
+		c.visit(matchedPath, joinedRelPath, pathOptions.destFile, stats[i])
+	}
+	c.wg.Wait()
+	if c.err != nil {
+		return IndexerRet{}, c.err
+	}
 
-		if stat.IsDir() {
+	ret := IndexerRet{
+		NewFileMap: c.newFileMap,
+	}
+	// remove duplicates in the records
+	if len(c.fileRemoteChanged) > 0 {
+		ret.RemoteDeleted = []string{}
+	}
+	if len(c.fileChanged) > 0 {
+		ret.FilesChanged = []string{}
+	}
+	for remote := range c.fileRemoteChanged {
+		ret.RemoteDeleted = append(ret.RemoteDeleted, remote)
+	}
+	for file := range c.fileChanged {
+		ret.FilesChanged = append(ret.FilesChanged, file)
+	}
 
-			if stat.Name() == DotOdoDirectory || stat.Name() == ".git" {
-				return IndexerRet{}, nil
-			}
+	return ret, nil
+}
 
-			if joinedRelPath != "." {
-				folderData, folderChangedData, folderRemoteChangedData := handleRemoteDataFolder(pathOptions.destFile, matchedPath, joinedRelPath, remoteDirectories, existingFileIndex)
-				folderData.Size = stat.Size()
-				folderData.LastModifiedDate = stat.ModTime()
-				ret.NewFileMap[joinedRelPath] = folderData
+// indexerWorkers is the maximum number of goroutines checking files and folders concurrently
+var indexerWorkers = 4 * runtime.NumCPU()
+
+// directoryModTimeGranularity is the largest granularity of the modification times of the directories
+// among the supported filesystems
+const directoryModTimeGranularity = 2 * time.Second
+
+// checker holds the state shared by the goroutines checking the files and folders of a directory
+type checker struct {
+	directory         string
+	ignoreMatcher     *gitignore.GitIgnore
+	remoteDirectories map[string]string
+	existingFileIndex FileIndex
+
+	// children are the names of the inner files and folders of each folder recorded in the existing index,
+	// indexed by the relative path of the folder. It is nil when the existing index cannot be used to list the folders
+	children map[string][]string
+	// trustedBefore is the time before which a folder must have been modified to be listed from the existing index
+	trustedBefore time.Time
+
+	workers chan struct{}
+	wg      sync.WaitGroup
+
+	mu                sync.Mutex
+	newFileMap        map[string]FileData
+	fileChanged       map[string]bool
+	fileRemoteChanged map[string]bool
+	err               error
+}
 
-				for data, value := range folderChangedData {
-					fileChanged[data] = value
-				}
+func newChecker(directory string, ignoreRules []string, remoteDirectories map[string]string, existingFileIndex FileIndex) *checker {
+	c := &checker{
+		directory:         directory,
+		ignoreMatcher:     gitignore.CompileIgnoreLines(ignoreRules...),
+		remoteDirectories: remoteDirectories,
+		existingFileIndex: existingFileIndex,
+		workers:           make(chan struct{}, indexerWorkers),
+		newFileMap:        make(map[string]FileData),
+		fileChanged:       make(map[string]bool),
+		fileRemoteChanged: make(map[string]bool),
+	}
+
+	// The name of an entry is added to or removed from a folder only when the modification time of the folder changes.
+	// So the inner files and folders of a folder whose modification time is unchanged since the last run of the indexer
+	// are the ones recorded in the index, if the index was generated with the same rules, long enough after the last modification.
+	summary := existingFileIndex.Summary
+	if len(remoteDirectories) == 0 && summary != nil && summary.RulesHash == hashIndexerRules(ignoreRules, remoteDirectories) {
+		c.trustedBefore = summary.StartedAt.Add(-directoryModTimeGranularity)
+		c.children = make(map[string][]string)
+		for relPath := range existingFileIndex.Files {
+			parent := filepath.Dir(relPath)
+			c.children[parent] = append(c.children[parent], filepath.Base(relPath))
+		}
+	}
+	return c
+}
 
-				for data, value := range folderRemoteChangedData {
-					fileRemoteChanged[data] = value
-				}
-			}
+// visit records the file or folder at path into the index, and schedules the check of its inner files and folders
+func (c *checker) visit(path, relPath, destFile string, stat os.FileInfo) {
+	if relPath != "." {
+		changed := make(map[string]bool)
+		// check for changes in the size and the modified date of the file or folder
+		// and if the file is newly added
+		if existing, ok := c.existingFileIndex.Files[relPath]; !ok {
+			changed[path] = true
+			klog.V(4).Infof("file added: %s", path)
+		} else if !stat.ModTime().Equal(existing.LastModifiedDate) {
+			changed[path] = true
+			klog.V(4).Infof("last modified date changed: %s", path)
+		} else if stat.Size() != existing.Size {
+			changed[path] = true
+			klog.V(4).Infof("size changed: %s", path)
+		}
 
-			// read the current folder and read inner files and folders
-			files, err := ioutil.ReadDir(matchedPath)
-			if err != nil {
-				return IndexerRet{}, err
-			}
-			if len(files) == 0 {
-				continue
+		var (
+			data          FileData
+			dataChanged   map[string]bool
+			remoteChanged map[string]bool
+		)
+		if stat.IsDir() {
+			data, dataChanged, remoteChanged = handleRemoteDataFolder(destFile, path, relPath, c.remoteDirectories, c.existingFileIndex)
+		} else {
+			data, dataChanged, remoteChanged = handleRemoteDataFile(destFile, path, relPath, c.remoteDirectories, c.existingFileIndex)
+		}
+		data.Size = stat.Size()
+		data.LastModifiedDate = stat.ModTime()
+
+		c.mu.Lock()
+		c.newFileMap[relPath] = data
+		for _, m := range []map[string]bool{changed, dataChanged} {
+			for file, value := range m {
+				c.fileChanged[file] = value
 			}
-			for _, f := range files {
-				if _, ok := remoteDirectories[filepath.Join(joinedRelPath, f.Name())]; ok {
-					continue
-				}
-
-				opts := recursiveCheckerPathOptions{pathOptions.directory, pathOptions.srcBase, filepath.Join(pathOptions.srcFile, f.Name()), pathOptions.destBase, filepath.Join(pathOptions.destFile, f.Name())}
-				innerRet, err := recursiveChecker(opts, ignoreRules, remoteDirectories, existingFileIndex)
-				if err != nil {
-					return IndexerRet{}, err
-				}
-
-				for k, v := range innerRet.NewFileMap {
-					ret.NewFileMap[k] = v
-				}
+		}
+		for remote, value := range remoteChanged {
+			c.fileRemoteChanged[remote] = value
+		}
+		c.mu.Unlock()
+	}
 
-				for _, remote := range innerRet.FilesChanged {
-					fileChanged[remote] = true
-				}
-				for _, remote := range innerRet.RemoteDeleted {
-					fileRemoteChanged[remote] = true
-				}
-			}
-		} else {
-			fileData, fileChangedData, fileRemoteChangedData := handleRemoteDataFile(pathOptions.destFile, matchedPath, joinedRelPath, remoteDirectories, existingFileIndex)
-			fileData.Size = stat.Size()
-			fileData.LastModifiedDate = stat.ModTime()
-			ret.NewFileMap[joinedRelPath] = fileData
+	if !stat.IsDir() {
+		return
+	}
 
-			for data, value := range fileChangedData {
-				fileChanged[data] = value
-			}
+	// read the current folder and read inner files and folders
+	names, listedFromIndex, err := c.list(path, relPath, stat)
+	if err != nil {
+		c.fail(err)
+		return
+	}
+	for _, name := range names {
+		childRelPath := filepath.Join(relPath, name)
+		if _, ok := c.remoteDirectories[childRelPath]; ok {
+			continue
+		}
+		childPath := filepath.Join(path, name)
+		childDestFile := filepath.Join(destFile, name)
+		c.schedule(func() {
+			c.visitChild(childPath, childRelPath, childDestFile, listedFromIndex)
+		})
+	}
+}
 
-			for data, value := range fileRemoteChangedData {
-				fileRemoteChanged[data] = value
-			}
+// visitChild visits the inner file or folder at path, unless it is ignored.
+// If listedFromIndex is true, the file or folder is known not to be ignored.
+// A file or folder removed since its parent folder was listed, as the temporary files of editors and build tools,
+// is not recorded in the index, and so is considered as deleted
+func (c *checker) visitChild(path, relPath, destFile string, listedFromIndex bool) {
+	if c.failed() {
+		return
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			klog.V(4).Infof("file removed while being indexed: %s", path)
+			return
 		}
+		c.fail(err)
+		return
+	}
+	if !listedFromIndex && c.ignoreMatcher.MatchesPath(relPath) {
+		return
 	}
+	if isSkippedDirectory(stat) {
+		return
+	}
+	c.visit(path, relPath, destFile, stat)
+}
 
-	// remove duplicates in the records
-	if len(fileRemoteChanged) > 0 {
-		ret.RemoteDeleted = []string{}
+// list returns the names of the inner files and folders of the folder at path, and true if the names are read from
+// the existing index, in which case the names are known not to be ignored.
+// No name is returned for an inner folder removed since it was checked
+func (c *checker) list(path, relPath string, stat os.FileInfo) ([]string, bool, error) {
+	if c.children != nil && relPath != "." {
+		existing, ok := c.existingFileIndex.Files[relPath]
+		if ok && existing.Size == stat.Size() && existing.LastModifiedDate.Equal(stat.ModTime()) && stat.ModTime().Before(c.trustedBefore) {
+			return c.children[relPath], true, nil
+		}
 	}
-	if len(fileChanged) > 0 {
-		ret.FilesChanged = []string{}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if relPath != "." && os.IsNotExist(err) {
+			klog.V(4).Infof("folder removed while being indexed: %s", path)
+			return nil, false, nil
+		}
+		return nil, false, err
 	}
-	for remote := range fileRemoteChanged {
-		ret.RemoteDeleted = append(ret.RemoteDeleted, remote)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
 	}
-	for file := range fileChanged {
-		ret.FilesChanged = append(ret.FilesChanged, file)
+	return names, false, nil
+}
+
+// schedule runs f in a new goroutine if a worker is available, or else runs f in the current goroutine
+func (c *checker) schedule(f func()) {
+	select {
+	case c.workers <- struct{}{}:
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			defer func() { <-c.workers }()
+			f()
+		}()
+	default:
+		f()
 	}
+}
 
-	return ret, nil
+// fail records the first error occurring during the check
+func (c *checker) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+func (c *checker) failed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err != nil
+}
+
+// isSkippedDirectory returns true if stat describes a folder never synced into the containers
+func isSkippedDirectory(stat os.FileInfo) bool {
+	return stat.IsDir() && (stat.Name() == DotOdoDirectory || stat.Name() == ".git")
+}
+
+// hashIndexerRules returns a hash of the rules used by the indexer to select the files to index
+func hashIndexerRules(ignoreRules []string, remoteDirectories map[string]string) string {
+	h := sha256.New()
+	for _, rule := range ignoreRules {
+		fmt.Fprintf(h, "ignore:%s\n", rule)
+	}
+	remotes := make([]string, 0, len(remoteDirectories))
+	for local, remote := range remoteDirectories {
+		remotes = append(remotes, fmt.Sprintf("remote:%s:%s\n", local, remote))
+	}
+	sort.Strings(remotes)
+	for _, remote := range remotes {
+		fmt.Fprint(h, remote)
+	}
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 // handleRemoteDataFile handles remote addition, deletion etc for the given file
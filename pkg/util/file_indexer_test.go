@@ -1,11 +1,14 @@
 package util
 
 import (
+	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -1623,3 +1626,193 @@ func Test_runIndexerWithExistingFileIndex(t *testing.T) {
 		})
 	}
 }
+
+// createIndexerBenchmarkTree creates a tree of dirs*subdirs directories containing files files each under directory
+func createIndexerBenchmarkTree(b *testing.B, directory string, dirs, subdirs, files int) {
+	for i := 0; i < dirs; i++ {
+		for j := 0; j < subdirs; j++ {
+			dir := filepath.Join(directory, fmt.Sprintf("dir%d", i), fmt.Sprintf("sub%d", j))
+			if err := os.MkdirAll(dir, 0750); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+			for k := 0; k < files; k++ {
+				if err := ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("file%d.go", k)), []byte("package main\n"), 0600); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(directory, DotOdoDirectory), 0750); err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+	// the sources of a project are generally not modified just before running the indexer
+	past := time.Now().Add(-time.Hour)
+	err := filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, past, past)
+	})
+	if err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func BenchmarkRunIndexerWithRemote(b *testing.B) {
+	directory := b.TempDir()
+	// 10000 files in 220 directories
+	createIndexerBenchmarkTree(b, directory, 20, 10, 50)
+	ignoreRules := []string{".git", ".odo", "*.log", "*.tmp", "node_modules/", "dist/", "build/", "target/", ".idea/", ".vscode/", "coverage/", "*.swp"}
+
+	b.Run("without existing index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := DeleteIndexFile(directory); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := RunIndexerWithRemote(directory, ignoreRules, nil); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+
+	b.Run("with unchanged existing index", func(b *testing.B) {
+		if err := DeleteIndexFile(directory); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+		ret, err := RunIndexerWithRemote(directory, ignoreRules, nil)
+		if err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+		if err = WriteFileIndex(&FileIndex{Files: ret.NewFileMap, Summary: ret.Summary}, ret.ResolvedPath); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			ret, err = RunIndexerWithRemote(directory, ignoreRules, nil)
+			if err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+			if len(ret.FilesChanged) != 0 {
+				b.Fatalf("no file should be changed, got %d", len(ret.FilesChanged))
+			}
+		}
+	})
+}
+
+func Test_runIndexerWithExistingFileIndex_directorySummaries(t *testing.T) {
+	ignoreRules := []string{"*.log"}
+	tests := []struct {
+		name string
+		// summary returns the summary of the existing index, given the modification time of the directory
+		summary func(dirModTime time.Time) *IndexSummary
+		// touchDirectory is true to update the modification time of the directory after adding the new file
+		touchDirectory bool
+		// wantNewFileFound is true if the file added to the directory is expected to be detected
+		wantNewFileFound bool
+	}{
+		{
+			name: "unchanged directory is listed from the index",
+			summary: func(time.Time) *IndexSummary {
+				return &IndexSummary{RulesHash: hashIndexerRules(ignoreRules, nil), StartedAt: time.Now()}
+			},
+			wantNewFileFound: false,
+		},
+		{
+			name: "modified directory is read",
+			summary: func(time.Time) *IndexSummary {
+				return &IndexSummary{RulesHash: hashIndexerRules(ignoreRules, nil), StartedAt: time.Now()}
+			},
+			touchDirectory:   true,
+			wantNewFileFound: true,
+		},
+		{
+			name: "directory is read when the rules changed",
+			summary: func(time.Time) *IndexSummary {
+				return &IndexSummary{RulesHash: hashIndexerRules([]string{"*.tmp"}, nil), StartedAt: time.Now()}
+			},
+			wantNewFileFound: true,
+		},
+		{
+			name: "directory is read when modified just before the index",
+			summary: func(dirModTime time.Time) *IndexSummary {
+				return &IndexSummary{RulesHash: hashIndexerRules(ignoreRules, nil), StartedAt: dirModTime.Add(time.Second)}
+			},
+			wantNewFileFound: true,
+		},
+		{
+			name:             "directory is read without summary",
+			summary:          func(time.Time) *IndexSummary { return nil },
+			wantNewFileFound: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			directory := t.TempDir()
+			subDir := filepath.Join(directory, "views")
+			if err := os.Mkdir(subDir, 0750); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			existingFile := filepath.Join(subDir, "index.html")
+			if err := ioutil.WriteFile(existingFile, []byte("index"), 0600); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			past := time.Now().Add(-2 * time.Hour)
+			if err := os.Chtimes(subDir, past, past); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			ret, err := runIndexerWithExistingFileIndex(directory, ignoreRules, nil, NewFileIndex())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			existingFileIndex := NewFileIndex()
+			existingFileIndex.Files = ret.NewFileMap
+			existingFileIndex.Summary = tt.summary(past)
+
+			newFile := filepath.Join(subDir, "new.html")
+			if err = ioutil.WriteFile(newFile, []byte("new"), 0600); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.touchDirectory {
+				if err = os.Chtimes(subDir, past, past); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			ret, err = runIndexerWithExistingFileIndex(directory, ignoreRules, nil, existingFileIndex)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_, found := ret.NewFileMap[filepath.Join("views", "new.html")]
+			if found != tt.wantNewFileFound {
+				t.Errorf("new file found = %v, want %v", found, tt.wantNewFileFound)
+			}
+			if _, found = ret.NewFileMap[filepath.Join("views", "index.html")]; !found {
+				t.Errorf("existing file not found in index")
+			}
+		})
+	}
+}
+
+func Test_runIndexerWithExistingFileIndex_removedFiles(t *testing.T) {
+	directory := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(directory, "main.go"), []byte("main"), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// a dangling symlink is listed in its folder, but cannot be read, as a file removed while the folder is indexed
+	if err := os.Symlink(filepath.Join(directory, "removed.swp"), filepath.Join(directory, ".main.go.swp")); err != nil {
+		t.Skipf("unable to create symlink: %v", err)
+	}
+
+	ret, err := runIndexerWithExistingFileIndex(directory, nil, nil, NewFileIndex())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for relPath := range ret.NewFileMap {
+		got = append(got, relPath)
+	}
+	if diff := cmp.Diff([]string{"main.go"}, got); diff != "" {
+		t.Errorf("runIndexerWithExistingFileIndex() files mismatch (-want +got):\n%s", diff)
+	}
+}
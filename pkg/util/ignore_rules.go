@@ -0,0 +1,168 @@
+package util
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gitignore "github.com/sabhiram/go-gitignore"
+	"k8s.io/klog"
+)
+
+const (
+	// DotOdoIgnoreFile is the name of the file defining the files specifically ignored by odo, at the root of the component directory
+	DotOdoIgnoreFile = ".odoignore"
+
+	dotGitDirectory = ".git"
+)
+
+// regexpSpecialChars are the characters of directory names to escape in the ignore rules,
+// as the rules are converted into regular expressions
+var regexpSpecialChars = strings.NewReplacer(
+	`+`, `\+`, `(`, `\(`, `)`, `\)`, `[`, `\[`, `]`, `\]`,
+	`{`, `\{`, `}`, `\}`, `^`, `\^`, `$`, `\$`, `|`, `\|`,
+)
+
+// GetIgnoreRulesFromDirectory returns the ignore rules of the component in directory, as a list of rules relative to directory:
+//   - if directory contains a .odoignore file, the rules of this file, which replace the rules of the .gitignore files,
+//   - otherwise, the rules of the .gitignore files of directory and of its subdirectories, a rule of a .gitignore file
+//     in a subdirectory applying only to the files under this subdirectory.
+//
+// The .gitignore files of ignored directories are not read.
+func GetIgnoreRulesFromDirectory(directory string) ([]string, error) {
+	rules := []string{dotGitDirectory}
+
+	odoIgnoreLines, found, err := readIgnoreFile(filepath.Join(directory, DotOdoIgnoreFile))
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		rules = append(rules, odoIgnoreLines...)
+		klog.V(4).Infof("ignore rules of directory %s, read from %s: %v", directory, DotOdoIgnoreFile, rules)
+		return rules, nil
+	}
+
+	// each rule is compiled once, when its .gitignore file is read, to check if the next directories are ignored
+	matchers := []ignoreMatcher{newIgnoreMatcher(dotGitDirectory)}
+	err = filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(directory, path)
+		if err != nil {
+			return err
+		}
+		if rel != "." {
+			if d.Name() == dotGitDirectory || d.Name() == DotOdoDirectory || isIgnored(matchers, filepath.ToSlash(rel)+"/") {
+				return filepath.SkipDir
+			}
+		}
+
+		lines, _, err := readIgnoreFile(filepath.Join(path, DotGitIgnoreFile))
+		if err != nil {
+			return err
+		}
+		for _, line := range lines {
+			rule := toComponentIgnoreRule(line, rel)
+			rules = append(rules, rule)
+			matchers = append(matchers, newIgnoreMatcher(rule))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	klog.V(4).Infof("ignore rules of directory %s, read from the %s files: %v", directory, DotGitIgnoreFile, rules)
+	return rules, nil
+}
+
+// ignoreMatcher is a single compiled ignore rule
+type ignoreMatcher struct {
+	// matcher matches the paths matched by the rule, without its negation
+	matcher *gitignore.GitIgnore
+	negate  bool
+}
+
+func newIgnoreMatcher(rule string) ignoreMatcher {
+	negate := strings.HasPrefix(rule, "!")
+	return ignoreMatcher{
+		matcher: gitignore.CompileIgnoreLines(strings.TrimPrefix(rule, "!")),
+		negate:  negate,
+	}
+}
+
+// isIgnored returns true if path is ignored by the rules of matchers, the last rule matching path taking precedence
+func isIgnored(matchers []ignoreMatcher, path string) bool {
+	ignored := false
+	for _, m := range matchers {
+		if m.matcher.MatchesPath(path) {
+			ignored = !m.negate
+		}
+	}
+	return ignored
+}
+
+// readIgnoreFile returns the rules defined in the ignore file at path, without the comments and blank lines,
+// and whether the file exists
+func readIgnoreFile(path string) ([]string, bool, error) {
+	// #nosec G304 -- path is an ignore file of the component directory
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	defer file.Close() // #nosec G307
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := trimTrailingSpaces(strings.TrimSuffix(scanner.Text(), "\r"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, true, scanner.Err()
+}
+
+// trimTrailingSpaces removes the trailing spaces of line, except a space escaped with a backslash, as Git does
+func trimTrailingSpaces(line string) string {
+	trimmed := strings.TrimRight(line, " ")
+	if len(trimmed) == len(line) {
+		return line
+	}
+	backslashes := len(trimmed) - len(strings.TrimRight(trimmed, `\`))
+	if backslashes%2 == 1 {
+		// keep the escaped space
+		return line[:len(trimmed)+1]
+	}
+	return trimmed
+}
+
+// toComponentIgnoreRule converts a rule of the .gitignore file of the directory dir, relative to the component directory,
+// into a rule relative to the component directory:
+//   - a rule containing a slash, except a trailing one, is anchored to dir,
+//   - a rule without slash applies to the files at any depth under dir.
+func toComponentIgnoreRule(rule string, dir string) string {
+	if dir == "." {
+		return rule
+	}
+	negation := ""
+	if strings.HasPrefix(rule, "!") {
+		negation = "!"
+		rule = rule[1:]
+	}
+	prefix := "/" + regexpSpecialChars.Replace(filepath.ToSlash(dir))
+	if strings.Contains(strings.TrimSuffix(rule, "/"), "/") {
+		return negation + prefix + "/" + strings.TrimPrefix(rule, "/")
+	}
+	return negation + prefix + "/**/" + rule
+}
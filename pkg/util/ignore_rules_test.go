@@ -0,0 +1,267 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	gitignore "github.com/sabhiram/go-gitignore"
+)
+
+func TestGetIgnoreRulesFromDirectory_nestedIgnoreFiles(t *testing.T) {
+	directory := t.TempDir()
+	files := map[string]string{
+		".gitignore":                        "# comment\n*.log\n\n/dist\n",
+		"dist/.gitignore":                   "should-not-be-read\n",
+		"packages/front/.gitignore":         "node_modules/\n/build\n!keep.log\ndocs/*.html\n",
+		"packages/back/target/.gitignore":   "*\n",
+		"packages/back/.gitignore":          "target\n",
+		"packages/c++/.gitignore":           "out\n",
+		".git/.gitignore":                   "should-not-be-read\n",
+		"packages/front/src/.gitignore.bak": "should-not-be-read\n",
+	}
+	writeFiles(t, directory, files)
+
+	got, err := GetIgnoreRulesFromDirectory(directory)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		".git",
+		"*.log",
+		"/dist",
+		"/packages/back/**/target",
+		`/packages/c\+\+/**/out`,
+		"/packages/front/**/node_modules/",
+		"/packages/front/build",
+		"!/packages/front/**/keep.log",
+		"/packages/front/docs/*.html",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetIgnoreRulesFromDirectory() mismatch (-want +got):\n%s", diff)
+	}
+
+	matcher := gitignore.CompileIgnoreLines(got...)
+	for path, wantIgnored := range map[string]bool{
+		"app.log":                                   true,
+		"packages/front/keep.log":                   false,
+		"packages/front/sub/keep.log":               false,
+		"packages/back/keep.log":                    true,
+		"dist/main.js":                              true,
+		"packages/dist/main.js":                     false,
+		"packages/front/node_modules/lib/index.js":  true,
+		"packages/front/src/node_modules/lib/a.js":  true,
+		"packages/back/node_modules/lib/index.js":   false,
+		"packages/front/build/main.js":              true,
+		"packages/front/build/index.html":           true,
+		"packages/front/src/build/main.js":          false,
+		"packages/front/docs/index.html":            true,
+		"packages/front/docs/api/index.html":        false,
+		"packages/back/target/classes/Main.class":   true,
+		"packages/c++/out/main.o":                   true,
+		"packages/cxx/out/main.o":                   false,
+		"README.md":                                 false,
+		"packages/front/src/.gitignore.bak":         false,
+		"packages/front/src/should-not-be-read.txt": false,
+	} {
+		if got := matcher.MatchesPath(path); got != wantIgnored {
+			t.Errorf("MatchesPath(%q) = %v, want %v", path, got, wantIgnored)
+		}
+	}
+}
+
+func TestGetIgnoreRulesFromDirectory_odoIgnoreFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name: "the .odoignore file replaces the .gitignore files",
+			files: map[string]string{
+				".gitignore":         "/build\n",
+				"src/.gitignore":     "*.tmp\n",
+				".odoignore":         "README.md\n!build/\n",
+				"build/.gitignore":   "*\n",
+				"build/generated.go": "",
+			},
+			want: []string{".git", "README.md", "!build/"},
+		},
+		{
+			name: "an empty .odoignore file replaces the .gitignore files",
+			files: map[string]string{
+				".gitignore": "/build\n",
+				".odoignore": "# nothing to ignore\n",
+			},
+			want: []string{".git"},
+		},
+		{
+			name: "no ignore file",
+			files: map[string]string{
+				"main.go": "",
+			},
+			want: []string{".git"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			directory := t.TempDir()
+			writeFiles(t, directory, tt.files)
+
+			got, err := GetIgnoreRulesFromDirectory(directory)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetIgnoreRulesFromDirectory() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetIgnoreRulesFromDirectory_spaces(t *testing.T) {
+	directory := t.TempDir()
+	writeFiles(t, directory, map[string]string{
+		".gitignore":     "trailing  \nescaped\\ \n leading\n   \nwindows\r\n",
+		"sub/.gitignore": "nested  \n",
+	})
+
+	got, err := GetIgnoreRulesFromDirectory(directory)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		".git",
+		"trailing",
+		`escaped\ `,
+		" leading",
+		"windows",
+		"/sub/**/nested",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetIgnoreRulesFromDirectory() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_trimTrailingSpaces(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{line: "build", want: "build"},
+		{line: "build  ", want: "build"},
+		{line: "  build", want: "  build"},
+		{line: `build\ `, want: `build\ `},
+		{line: `build\   `, want: `build\ `},
+		{line: `build\\ `, want: `build\\`},
+		{line: `build\\\ `, want: `build\\\ `},
+		{line: "build\t", want: "build\t"},
+		{line: "   ", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if got := trimTrailingSpaces(tt.line); got != tt.want {
+				t.Errorf("trimTrailingSpaces(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isIgnored(t *testing.T) {
+	matchers := []ignoreMatcher{
+		newIgnoreMatcher(".git"),
+		newIgnoreMatcher("/build"),
+		newIgnoreMatcher("!/build/keep/"),
+		newIgnoreMatcher("/a/**/node_modules/"),
+		newIgnoreMatcher("!/a/b/node_modules/"),
+	}
+	for path, want := range map[string]bool{
+		".git/":                 true,
+		"build/":                true,
+		"build/keep/":           false,
+		"src/":                  false,
+		"a/node_modules/":       true,
+		"a/c/node_modules/":     true,
+		"a/b/node_modules/":     false,
+		"other/node_modules/":   false,
+		"build/keep/sub/build/": false,
+	} {
+		if got := isIgnored(matchers, path); got != want {
+			t.Errorf("isIgnored(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+// writeFiles creates the files in directory, with their content
+func writeFiles(t *testing.T, directory string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(directory, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func Test_toComponentIgnoreRule(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		dir  string
+		want string
+	}{
+		{
+			name: "rule of the root directory",
+			rule: "docs/*.html",
+			dir:  ".",
+			want: "docs/*.html",
+		},
+		{
+			name: "name matching at any depth",
+			rule: "*.log",
+			dir:  "a/b",
+			want: "/a/b/**/*.log",
+		},
+		{
+			name: "directory name matching at any depth",
+			rule: "node_modules/",
+			dir:  "a",
+			want: "/a/**/node_modules/",
+		},
+		{
+			name: "rule anchored by a leading slash",
+			rule: "/build",
+			dir:  "a",
+			want: "/a/build",
+		},
+		{
+			name: "rule anchored by a middle slash",
+			rule: "docs/*.html",
+			dir:  "a",
+			want: "/a/docs/*.html",
+		},
+		{
+			name: "rule starting with **",
+			rule: "**/tmp",
+			dir:  "a",
+			want: "/a/**/tmp",
+		},
+		{
+			name: "negation",
+			rule: "!/build/keep",
+			dir:  "a",
+			want: "!/a/build/keep",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toComponentIgnoreRule(tt.rule, tt.dir); got != tt.want {
+				t.Errorf("toComponentIgnoreRule() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
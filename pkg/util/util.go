@@ -784,6 +784,20 @@ func IsPortFree(port int) bool {
 	return err == nil
 }
 
+// GetRandomFreePort returns a free port on localhost, randomly chosen by the system
+func GetRandomFreePort() (int, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	err = listener.Close()
+	if err != nil {
+		return 0, err
+	}
+	return port, nil
+}
+
 // WriteToJSONFile writes a struct to json file
 func WriteToJSONFile(c interface{}, filename string) error {
 	data, err := json.Marshal(c)
@@ -0,0 +1,91 @@
+package watch
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/redhat-developer/odo/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ContainerStates records the states of the containers of a pod, to display their transitions
+type ContainerStates struct {
+	podUID types.UID
+	states map[string]containerState
+}
+
+type containerState struct {
+	description  string
+	running      bool
+	restartCount int32
+}
+
+func NewContainerStates() ContainerStates {
+	return ContainerStates{
+		states: map[string]containerState{},
+	}
+}
+
+// Update displays the transitions of the states of the containers of pod since the previous update,
+// and returns true if a container has been restarted.
+// The recorded states are reset when the pod is replaced by another one.
+func (o *ContainerStates) Update(out io.Writer, pod *corev1.Pod) bool {
+	if o.podUID != pod.GetUID() {
+		o.podUID = pod.GetUID()
+		o.states = map[string]containerState{}
+	}
+
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.ContainerStatuses...)
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	restarted := false
+	for _, status := range statuses {
+		state := getContainerState(status)
+		previous, found := o.states[status.Name]
+		o.states[status.Name] = state
+		if found && state.restartCount > previous.restartCount {
+			restarted = true
+			log.Fwarning(out, fmt.Sprintf("Container %q has been restarted (%d restarts)", status.Name, state.restartCount))
+		}
+		if found && previous.description == state.description {
+			continue
+		}
+		if state.running {
+			log.Fsuccess(out, fmt.Sprintf("Container %q is %s", status.Name, state.description))
+			continue
+		}
+		log.Fwarning(out, fmt.Sprintf("Container %q is %s", status.Name, state.description))
+	}
+	return restarted
+}
+
+// Reset forgets the recorded states
+func (o *ContainerStates) Reset() {
+	o.podUID = ""
+	o.states = map[string]containerState{}
+}
+
+func getContainerState(status corev1.ContainerStatus) containerState {
+	state := containerState{
+		restartCount: status.RestartCount,
+	}
+	switch {
+	case status.State.Running != nil:
+		state.running = true
+		state.description = "running"
+	case status.State.Waiting != nil:
+		state.description = "waiting"
+		if status.State.Waiting.Reason != "" {
+			state.description += ": " + status.State.Waiting.Reason
+		}
+	case status.State.Terminated != nil:
+		state.description = fmt.Sprintf("terminated: %s (exit code %d)", status.State.Terminated.Reason, status.State.Terminated.ExitCode)
+	default:
+		state.description = "in an unknown state"
+	}
+	return state
+}
@@ -0,0 +1,148 @@
+package watch
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	containerRunning = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	containerExited  = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}}
+	containerWaiting = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+)
+
+// podWithContainer returns a pod with the given UID, running a single container "runtime" in the given state
+func podWithContainer(uid types.UID, state corev1.ContainerState, restartCount int32) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mycomponent-app",
+			UID:  uid,
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:         "runtime",
+					State:        state,
+					RestartCount: restartCount,
+				},
+			},
+		},
+	}
+}
+
+func TestContainerStates_Update(t *testing.T) {
+	type step struct {
+		pod           *corev1.Pod
+		wantRestarted bool
+		// wantMessages are the messages displayed by the step, one per line
+		wantMessages []string
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "container running, exited, then restarted",
+			steps: []step{
+				{
+					pod:          podWithContainer("uid1", containerRunning, 0),
+					wantMessages: []string{`Container "runtime" is running`},
+				},
+				{
+					pod:          podWithContainer("uid1", containerExited, 0),
+					wantMessages: []string{`Container "runtime" is terminated: Error (exit code 1)`},
+				},
+				{
+					pod:           podWithContainer("uid1", containerRunning, 1),
+					wantRestarted: true,
+					wantMessages: []string{
+						`Container "runtime" has been restarted (1 restarts)`,
+						`Container "runtime" is running`,
+					},
+				},
+			},
+		},
+		{
+			name: "duplicate events",
+			steps: []step{
+				{
+					pod:          podWithContainer("uid1", containerWaiting, 0),
+					wantMessages: []string{`Container "runtime" is waiting: ContainerCreating`},
+				},
+				{
+					pod: podWithContainer("uid1", containerWaiting, 0),
+				},
+				{
+					pod:          podWithContainer("uid1", containerRunning, 0),
+					wantMessages: []string{`Container "runtime" is running`},
+				},
+				{
+					pod: podWithContainer("uid1", containerRunning, 0),
+				},
+			},
+		},
+		{
+			name: "pod replaced by a new pod",
+			steps: []step{
+				{
+					pod:          podWithContainer("uid1", containerRunning, 2),
+					wantMessages: []string{`Container "runtime" is running`},
+				},
+				{
+					// the restart count of the container of the new pod is not compared with the one of the previous pod
+					pod:          podWithContainer("uid2", containerRunning, 0),
+					wantMessages: []string{`Container "runtime" is running`},
+				},
+				{
+					pod:           podWithContainer("uid2", containerRunning, 1),
+					wantRestarted: true,
+					wantMessages:  []string{`Container "runtime" has been restarted (1 restarts)`},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewContainerStates()
+			for i, step := range tt.steps {
+				out := &bytes.Buffer{}
+				if got := o.Update(out, step.pod); got != step.wantRestarted {
+					t.Errorf("step %d: Update() = %v, want %v", i, got, step.wantRestarted)
+				}
+				var lines []string
+				if out.Len() > 0 {
+					lines = strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+				}
+				if len(lines) != len(step.wantMessages) {
+					t.Fatalf("step %d: output = %q, want messages %q", i, out.String(), step.wantMessages)
+				}
+				for j, msg := range step.wantMessages {
+					if !strings.Contains(lines[j], msg) {
+						t.Errorf("step %d: line %d = %q, want it to contain %q", i, j, lines[j], msg)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestContainerStates_Reset(t *testing.T) {
+	o := NewContainerStates()
+	o.Update(&bytes.Buffer{}, podWithContainer("uid1", containerRunning, 0))
+	o.Reset()
+
+	// after a reset, the states are displayed again, and a restart is not detected
+	out := &bytes.Buffer{}
+	if o.Update(out, podWithContainer("uid1", containerRunning, 1)) {
+		t.Errorf("Update() = true after Reset, want false")
+	}
+	if !strings.Contains(out.String(), `Container "runtime" is running`) {
+		t.Errorf("output = %q, want the state of the container", out.String())
+	}
+}
@@ -1,34 +1,46 @@
 package watch
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"syscall"
 
-	dfutil "github.com/devfile/library/pkg/util"
 	"github.com/fsnotify/fsnotify"
 	"github.com/redhat-developer/odo/pkg/util"
 	gitignore "github.com/sabhiram/go-gitignore"
 	"k8s.io/klog"
 )
 
-func getFullSourcesWatcher(path string, fileIgnores []string) (*fsnotify.Watcher, error) {
-	absIgnorePaths := dfutil.GetAbsGlobExps(path, fileIgnores)
+// newFsnotifyWatcher creates the watcher of the sources, it can be replaced in tests
+var newFsnotifyWatcher = fsnotify.NewWatcher
 
-	watcher, err := fsnotify.NewWatcher()
+func getFullSourcesWatcher(path string, fileIgnores []string) (*fsnotify.Watcher, error) {
+	watcher, err := newFsnotifyWatcher()
 	if err != nil {
-		return nil, fmt.Errorf("error setting up filesystem watcher: %v", err)
+		return nil, fmt.Errorf("error setting up filesystem watcher: %w", err)
 	}
 
 	// adding watch on the root folder and the sub folders recursively
 	// so directory and the path in addRecursiveWatch() are the same
-	err = addRecursiveWatch(watcher, path, path, absIgnorePaths)
+	err = addRecursiveWatch(watcher, path, path, fileIgnores)
 	if err != nil {
-		return nil, fmt.Errorf("error watching source path %s: %v", path, err)
+		_ = watcher.Close()
+		return nil, fmt.Errorf("error watching source path %s: %w", path, err)
 	}
 	return watcher, nil
 }
 
+// isWatchLimitError returns true if err indicates that the limit of filesystem watches of the system is reached
+func isWatchLimitError(err error) bool {
+	// Linux "no space left on device" issues are usually resolved via
+	// $ sudo sysctl fs.inotify.max_user_watches=65536
+	// BSD / OSX: "too many open files" issues are ussualy resolved via
+	// $ sysctl variables "kern.maxfiles" and "kern.maxfilesperproc",
+	return errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EMFILE)
+}
+
 // addRecursiveWatch handles adding watches recursively for the path provided
 // and its subdirectories.  If a non-directory is specified, this call is a no-op.
 // Files matching glob pattern defined in ignores will be ignored.
@@ -36,6 +48,7 @@ func getFullSourcesWatcher(path string, fileIgnores []string) (*fsnotify.Watcher
 // rootPath is the root path of the file or directory,
 // path is the recursive path of the file or the directory,
 // ignores contains the glob rules for matching
+// An error is returned when the limit of filesystem watches is reached, see isWatchLimitError
 func addRecursiveWatch(watcher *fsnotify.Watcher, rootPath string, path string, ignores []string) error {
 
 	file, err := os.Stat(path)
@@ -66,6 +79,9 @@ func addRecursiveWatch(watcher *fsnotify.Watcher, rootPath string, path string,
 
 			err = watcher.Add(path)
 			if err != nil {
+				if isWatchLimitError(err) {
+					return fmt.Errorf("unable to watch %s: %w", path, err)
+				}
 				klog.V(4).Infof("error adding watcher for path %s: %v", path, err)
 			}
 			return nil
@@ -126,10 +142,9 @@ func addRecursiveWatch(watcher *fsnotify.Watcher, rootPath string, path string,
 		klog.V(4).Infof("adding watch on path %s", folder)
 		err = watcher.Add(folder)
 		if err != nil {
-			// Linux "no space left on device" issues are usually resolved via
-			// $ sudo sysctl fs.inotify.max_user_watches=65536
-			// BSD / OSX: "too many open files" issues are ussualy resolved via
-			// $ sysctl variables "kern.maxfiles" and "kern.maxfilesperproc",
+			if isWatchLimitError(err) {
+				return fmt.Errorf("unable to watch %s: %w", folder, err)
+			}
 			klog.V(4).Infof("error adding watcher for path %s: %v", folder, err)
 		}
 	}
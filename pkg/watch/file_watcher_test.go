@@ -0,0 +1,52 @@
+package watch
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func Test_getFullSourcesWatcher_watcherCreationError(t *testing.T) {
+	tests := []struct {
+		name              string
+		err               error
+		wantWatchLimitErr bool
+	}{
+		{
+			name:              "too many open files",
+			err:               syscall.EMFILE,
+			wantWatchLimitErr: true,
+		},
+		{
+			name:              "inotify instances exhausted",
+			err:               syscall.ENOSPC,
+			wantWatchLimitErr: true,
+		},
+		{
+			name: "other error",
+			err:  errors.New("an error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(f func() (*fsnotify.Watcher, error)) { newFsnotifyWatcher = f }(newFsnotifyWatcher)
+			newFsnotifyWatcher = func() (*fsnotify.Watcher, error) {
+				return nil, tt.err
+			}
+
+			watcher, err := getFullSourcesWatcher(t.TempDir(), nil)
+			if err == nil {
+				_ = watcher.Close()
+				t.Fatalf("getFullSourcesWatcher() error = nil, want an error")
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("getFullSourcesWatcher() error = %v, want it to wrap %v", err, tt.err)
+			}
+			if got := isWatchLimitError(err); got != tt.wantWatchLimitErr {
+				t.Errorf("isWatchLimitError(%v) = %v, want %v", err, got, tt.wantWatchLimitErr)
+			}
+		})
+	}
+}
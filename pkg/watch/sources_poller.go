@@ -0,0 +1,139 @@
+package watch
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"k8s.io/klog"
+
+	"github.com/redhat-developer/odo/pkg/util"
+)
+
+// DefaultPollInterval is the interval at which the sources are polled for changes
+// when filesystem notifications cannot be used to watch them
+const DefaultPollInterval = time.Second
+
+// sourcesPoller detects the changes of the sources by comparing the state of the files at a regular interval
+// with their state at the previous poll. It is used on the filesystems not delivering filesystem notifications
+// (NFS, SSHFS, some shared folders of virtual machines) or when the number of filesystem watches is exhausted.
+// The changes are reported as fsnotify events, so they are processed the same way as the filesystem notifications.
+type sourcesPoller struct {
+	path     string
+	ignores  []string
+	interval time.Duration
+	events   chan fsnotify.Event
+	errors   chan error
+
+	// snapshot is the state of the files at the previous poll
+	snapshot *util.FileIndex
+}
+
+// newSourcesPoller returns a poller of the sources in path, which does not start polling until started.
+// The initial state of the files is recorded when the poller is created
+func newSourcesPoller(path string, ignores []string, interval time.Duration) (*sourcesPoller, error) {
+	o := &sourcesPoller{
+		path:     path,
+		ignores:  ignores,
+		interval: interval,
+		events:   make(chan fsnotify.Event),
+		errors:   make(chan error),
+		snapshot: util.NewFileIndex(),
+	}
+	if _, err := o.poll(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Events returns the channel receiving the changes of the sources, or nil if o is nil
+func (o *sourcesPoller) Events() <-chan fsnotify.Event {
+	if o == nil {
+		return nil
+	}
+	return o.events
+}
+
+// Errors returns the channel receiving the errors occurring while polling the sources, or nil if o is nil.
+// The polling continues after an error, and an error is reported only when the polls start failing
+func (o *sourcesPoller) Errors() <-chan error {
+	if o == nil {
+		return nil
+	}
+	return o.errors
+}
+
+// start polls the sources at the poller interval, until ctx is done.
+// A failing poll is retried at the next interval
+func (o *sourcesPoller) start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(o.interval)
+		defer ticker.Stop()
+		// failing is true when the previous poll has failed
+		failing := false
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			events, err := o.poll()
+			if err != nil {
+				klog.V(4).Infof("error polling %s: %v", o.path, err)
+				if failing {
+					continue
+				}
+				failing = true
+				select {
+				case o.errors <- err:
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+			failing = false
+			for _, event := range events {
+				select {
+				case o.events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+}
+
+// poll returns the changes of the sources since the previous poll, and records the current state of the files
+func (o *sourcesPoller) poll() ([]fsnotify.Event, error) {
+	ret, err := util.RunIndexerWithExistingFileIndex(o.path, o.ignores, o.snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []fsnotify.Event
+	for _, file := range ret.FilesChanged {
+		op := fsnotify.Write
+		rel, err := filepath.Rel(o.path, file)
+		if err != nil {
+			return nil, err
+		}
+		if _, found := o.snapshot.Files[rel]; !found {
+			op = fsnotify.Create
+		}
+		events = append(events, fsnotify.Event{Name: file, Op: op})
+	}
+	for _, rel := range ret.FilesDeleted {
+		events = append(events, fsnotify.Event{Name: filepath.Join(o.path, rel), Op: fsnotify.Remove})
+	}
+	if len(events) > 0 {
+		klog.V(4).Infof("polling %s: %d changes", o.path, len(events))
+	}
+
+	o.snapshot = &util.FileIndex{
+		Files:   ret.NewFileMap,
+		Summary: ret.Summary,
+	}
+	return events, nil
+}
@@ -0,0 +1,189 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_sourcesPoller_poll(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string, modTime time.Time) {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	initialTime := time.Now().Add(-time.Hour)
+	write("unchanged.txt", "a", initialTime)
+	write("modified.txt", "a", initialTime)
+	write("deleted.txt", "a", initialTime)
+	write("ignored.log", "a", initialTime)
+
+	poller, err := newSourcesPoller(dir, []string{"*.log"}, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := poller.poll()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("poll() without changes returned %v", got)
+	}
+
+	write("modified.txt", "a", initialTime.Add(time.Second))
+	write("created.txt", "a", initialTime)
+	write("ignored.log", "b", initialTime.Add(time.Second))
+	if err = os.Remove(filepath.Join(dir, "deleted.txt")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err = poller.poll()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var gotFiles []string
+	for _, event := range got {
+		gotFiles = append(gotFiles, fmt.Sprintf("%s %s", event.Op, filepath.Base(event.Name)))
+	}
+	sort.Strings(gotFiles)
+	want := []string{
+		fsnotify.Create.String() + " created.txt",
+		fsnotify.Remove.String() + " deleted.txt",
+		fsnotify.Write.String() + " modified.txt",
+	}
+	if diff := cmp.Diff(want, gotFiles); diff != "" {
+		t.Errorf("poll() mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err = poller.poll()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("poll() after the changes were reported returned %v", got)
+	}
+}
+
+func Test_sourcesPoller_start(t *testing.T) {
+	dir := t.TempDir()
+	poller, err := newSourcesPoller(dir, nil, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	poller.start(ctx)
+
+	path := filepath.Join(dir, "created.txt")
+	if err = os.WriteFile(path, []byte("a"), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case event := <-poller.Events():
+			if event.Name == path && event.Op == fsnotify.Create {
+				return
+			}
+		case err = <-poller.Errors():
+			t.Fatalf("unexpected error: %v", err)
+		case <-timeout:
+			t.Fatalf("no event received for %s", path)
+		}
+	}
+}
+
+func Test_sourcesPoller_start_retry(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "sources")
+	if err := os.Mkdir(dir, 0700); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	poller, err := newSourcesPoller(dir, nil, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	poller.start(ctx)
+
+	// the polls fail while the sources directory does not exist
+	if err = os.Remove(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	timeout := time.After(10 * time.Second)
+	select {
+	case <-poller.Errors():
+	case <-timeout:
+		t.Fatalf("no error received for %s", dir)
+	}
+
+	if err = os.Mkdir(dir, 0700); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	path := filepath.Join(dir, "created.txt")
+	if err = os.WriteFile(path, []byte("a"), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for {
+		select {
+		case event := <-poller.Events():
+			if event.Name == path && event.Op == fsnotify.Create {
+				return
+			}
+		case err = <-poller.Errors():
+			t.Fatalf("unexpected error: %v", err)
+		case <-timeout:
+			t.Fatalf("no event received for %s", path)
+		}
+	}
+}
+
+func Test_isWatchLimitError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "no error",
+		},
+		{
+			name: "inotify watches exhausted",
+			err:  fmt.Errorf("error watching source path: %w", syscall.ENOSPC),
+			want: true,
+		},
+		{
+			name: "too many open files",
+			err:  fmt.Errorf("error watching source path: %w", syscall.EMFILE),
+			want: true,
+		},
+		{
+			name: "other error",
+			err:  fmt.Errorf("error watching source path: %w", syscall.EACCES),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isWatchLimitError(tt.err); got != tt.want {
+				t.Errorf("isWatchLimitError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,16 @@ type ComponentStatus struct {
 	State               State
 	PostStartEventsDone bool
 	EndpointsForwarded  map[string][]int
+	// stateChanged is called with the new state when the state is changed with SetState, if not nil
+	stateChanged func(State)
+}
+
+// SetState changes the state of the component
+func (o *ComponentStatus) SetState(state State) {
+	o.State = state
+	if o.stateChanged != nil {
+		o.stateChanged(state)
+	}
 }
 
 func componentCanSyncFile(state State) bool {
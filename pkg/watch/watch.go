@@ -12,11 +12,18 @@ import (
 
 	"github.com/devfile/library/pkg/devfile/parser"
 
+	"github.com/redhat-developer/odo/pkg/api"
+	"github.com/redhat-developer/odo/pkg/apiserver"
 	"github.com/redhat-developer/odo/pkg/devfile/adapters"
 	"github.com/redhat-developer/odo/pkg/kclient"
 	"github.com/redhat-developer/odo/pkg/labels"
 	"github.com/redhat-developer/odo/pkg/libdevfile"
 	"github.com/redhat-developer/odo/pkg/log"
+	"github.com/redhat-developer/odo/pkg/machineoutput"
+	"github.com/redhat-developer/odo/pkg/podman"
+	"github.com/redhat-developer/odo/pkg/state"
+	"github.com/redhat-developer/odo/pkg/sync"
+	"github.com/redhat-developer/odo/pkg/util"
 
 	"github.com/fsnotify/fsnotify"
 	gitignore "github.com/sabhiram/go-gitignore"
@@ -32,27 +39,41 @@ import (
 const (
 	// PushErrorString is the string that is printed when an error occurs during watch's Push operation
 	PushErrorString = "Error occurred on Push"
+	// SyncBackErrorString is the string that is printed when an error occurs while copying files back from the container
+	SyncBackErrorString = "Error occurred on Sync back"
 )
 
 type WatchClient struct {
-	kubeClient kclient.ClientInterface
+	kubeClient   kclient.ClientInterface
+	podmanClient podman.Client
+	stateClient  state.Client
 
-	sourcesWatcher    *fsnotify.Watcher
+	sourcesWatcher *fsnotify.Watcher
+	// sourcesPoller detects the changes of the sources when they are polled instead of watched, nil otherwise
+	sourcesPoller     *sourcesPoller
 	deploymentWatcher watch.Interface
 	devfileWatcher    *fsnotify.Watcher
 	podWatcher        watch.Interface
 	warningsWatcher   watch.Interface
 	keyWatcher        <-chan byte
+	// apiServer serves the API of the session, nil if not started
+	apiServer *apiserver.Server
 
 	// true to force sync, used when manual sync
 	forceSync bool
+	// true to synchronize all the files at the next push, regardless of the files already synchronized
+	forcePush bool
+	// true to restart the run or debug command at the next push
+	restartCommand bool
 }
 
 var _ Client = (*WatchClient)(nil)
 
-func NewWatchClient(kubeClient kclient.ClientInterface) *WatchClient {
+func NewWatchClient(kubeClient kclient.ClientInterface, podmanClient podman.Client, stateClient state.Client) *WatchClient {
 	return &WatchClient{
-		kubeClient: kubeClient,
+		kubeClient:   kubeClient,
+		podmanClient: podmanClient,
+		stateClient:  stateClient,
 	}
 }
 
@@ -92,20 +113,34 @@ type WatchParameters struct {
 	RandomPorts bool
 	// WatchFiles indicates to watch for file changes and sync changes to the container
 	WatchFiles bool
+	// PollInterval is the interval at which the files are polled for changes, instead of using filesystem notifications.
+	// Zero to use filesystem notifications, the files being polled only if the limit of filesystem watches is reached
+	PollInterval time.Duration
 	// WatchCluster indicates to watch Cluster-related objects (Deployment, Pod, etc)
 	WatchCluster bool
+	// WatchPodman indicates to watch Podman-related objects (Pod and its containers)
+	WatchPodman bool
 	// ErrOut is a Writer to output forwarded port information
 	Out io.Writer
 	// ErrOut is a Writer to output forwarded port information
 	ErrOut io.Writer
 	// PromptMessage
 	PromptMessage string
+	// SyncBack are the paths to copy from the container back to the local workspace, on demand
+	SyncBack []sync.SyncBackPath
+	// SyncBackHandler copies the SyncBack paths from the container of the component back to the local workspace
+	SyncBackHandler func(context.Context, WatchParameters) error
+	// ToggleLogsHandler shows the logs of the application if hidden, hides them otherwise, and returns true if the logs are shown
+	ToggleLogsHandler func() bool
+	// APIServerAddress is the address on which the API server of the session listens, in the form host:port.
+	// The API server is not started if empty
+	APIServerAddress string
 }
 
 // evaluateChangesFunc evaluates any file changes for the events by ignoring the files in fileIgnores slice and removes
-// any deleted paths from the watcher. It returns a slice of changed files (if any) and paths that are deleted (if any)
-// by the events
-type evaluateChangesFunc func(events []fsnotify.Event, path string, fileIgnores []string, watcher *fsnotify.Watcher) (changedFiles, deletedPaths []string)
+// any deleted paths from the watcher, if not nil. It returns a slice of changed files (if any) and paths that are deleted (if any)
+// by the events, and the error occurring while adding the new paths to the watcher (if any)
+type evaluateChangesFunc func(events []fsnotify.Event, path string, fileIgnores []string, watcher *fsnotify.Watcher) (changedFiles, deletedPaths []string, watchErr error)
 
 // processEventsFunc processes the events received on the watcher. It uses the WatchParameters to trigger watch handler and writes to out
 // It returns a Duration after which to recall in case of error
@@ -115,18 +150,31 @@ func (o *WatchClient) WatchAndPush(out io.Writer, parameters WatchParameters, ct
 	klog.V(4).Infof("starting WatchAndPush, path: %s, component: %s, ignores %s", parameters.Path, parameters.ComponentName, parameters.FileIgnores)
 
 	var err error
-	if parameters.WatchFiles {
+	o.sourcesWatcher, o.sourcesPoller = nil, nil
+	pollInterval := parameters.PollInterval
+	if parameters.WatchFiles && pollInterval == 0 {
 		o.sourcesWatcher, err = getFullSourcesWatcher(parameters.Path, parameters.FileIgnores)
-		if err != nil {
+		if isWatchLimitError(err) {
+			pollInterval = DefaultPollInterval
+			warnWatchLimit(out, err, pollInterval)
+		} else if err != nil {
 			return err
 		}
-	} else {
+	}
+	if o.sourcesWatcher == nil {
+		// the sources are not watched, or are polled: the watcher does not watch any file
 		o.sourcesWatcher, err = fsnotify.NewWatcher()
 		if err != nil {
 			return err
 		}
 	}
 	defer o.sourcesWatcher.Close()
+	if parameters.WatchFiles && pollInterval > 0 {
+		err = o.pollSources(ctx, parameters, pollInterval)
+		if err != nil {
+			return err
+		}
+	}
 
 	if parameters.WatchCluster {
 		selector := labels.GetSelector(parameters.ComponentName, parameters.ApplicationName, labels.ComponentDevMode, true)
@@ -139,6 +187,13 @@ func (o *WatchClient) WatchAndPush(out io.Writer, parameters WatchParameters, ct
 		if err != nil {
 			return err
 		}
+	} else if parameters.WatchPodman {
+		selector := labels.GetSelector(parameters.ComponentName, parameters.ApplicationName, labels.ComponentDevMode, true)
+		o.deploymentWatcher = NewNoOpWatcher()
+		o.podWatcher, err = o.podmanClient.PodWatcher(ctx, selector)
+		if err != nil {
+			return fmt.Errorf("error watching podman pod: %w", err)
+		}
 	} else {
 		o.deploymentWatcher = NewNoOpWatcher()
 		o.podWatcher = NewNoOpWatcher()
@@ -176,6 +231,21 @@ func (o *WatchClient) WatchAndPush(out io.Writer, parameters WatchParameters, ct
 		o.warningsWatcher = NewNoOpWatcher()
 	}
 
+	o.apiServer = nil
+	if parameters.APIServerAddress != "" {
+		o.apiServer, err = apiserver.Start(ctx, parameters.APIServerAddress, o.getForwardedPorts)
+		if err != nil {
+			return fmt.Errorf("unable to start the API server: %w", err)
+		}
+		if o.stateClient != nil {
+			err = o.stateClient.SetAPIServer(o.apiServer.Address(), o.apiServer.Token())
+			if err != nil {
+				return err
+			}
+		}
+		log.Finfof(out, "API server listening on http://%s\n", o.apiServer.Address())
+	}
+
 	o.keyWatcher = getKeyWatcher(ctx, out)
 	return o.eventWatcher(ctx, parameters, out, evaluateFileChanges, o.processEvents, componentStatus)
 }
@@ -218,6 +288,13 @@ func (o *WatchClient) eventWatcher(
 	<-retryTimer.C
 
 	podsPhases := NewPodPhases()
+	containerStates := NewContainerStates()
+
+	// the state is published by the API server as soon as it is changed
+	componentStatus.stateChanged = func(state State) {
+		o.apiServer.SetState(string(state), parameters.Debug)
+	}
+	o.apiServer.SetState(string(componentStatus.State), parameters.Debug)
 
 	for {
 		select {
@@ -226,6 +303,10 @@ func (o *WatchClient) eventWatcher(
 			// We are waiting for more events in this interval
 			sourcesTimer.Reset(100 * time.Millisecond)
 
+		case event := <-o.sourcesPoller.Events():
+			events = append(events, event)
+			sourcesTimer.Reset(100 * time.Millisecond)
+
 		case <-sourcesTimer.C:
 			// timer has fired
 			if !componentCanSyncFile(componentStatus.State) {
@@ -236,15 +317,38 @@ func (o *WatchClient) eventWatcher(
 			var changedFiles, deletedPaths []string
 			if !o.forceSync {
 				// first find the files that have changed (also includes the ones newly created) or deleted
-				changedFiles, deletedPaths = evaluateChangesHandler(events, parameters.Path, parameters.FileIgnores, o.sourcesWatcher)
+				watcher := o.sourcesWatcher
+				if o.sourcesPoller != nil {
+					// the poller detects the changes in the new directories, they must not be watched
+					watcher = nil
+				}
+				var watchErr error
+				changedFiles, deletedPaths, watchErr = evaluateChangesHandler(events, parameters.Path, parameters.FileIgnores, watcher)
+				if isWatchLimitError(watchErr) {
+					// the new paths cannot be watched, the sources are polled instead
+					warnWatchLimit(out, watchErr, DefaultPollInterval)
+					if err := o.pollSources(ctx, parameters, DefaultPollInterval); err != nil {
+						return err
+					}
+				} else if watchErr != nil {
+					klog.V(4).Infof("unable to watch the new paths: %v", watchErr)
+				}
+				// ignore the files recorded in the index with their current state, as the files synced back from the container
+				if unchanged, err := util.RemoveUnchangedFiles(changedFiles, parameters.Path); err != nil {
+					klog.V(4).Infof("unable to compare changed files with the index: %v", err)
+				} else {
+					changedFiles = unchanged
+				}
 				// process the changes and sync files with remote pod
 				if len(changedFiles) == 0 && len(deletedPaths) == 0 {
 					continue
 				}
 			}
 
-			componentStatus.State = StateSyncOutdated
-			fmt.Fprintf(out, "Pushing files...\n\n")
+			componentStatus.SetState(StateSyncOutdated)
+			if !o.restartCommand {
+				fmt.Fprintf(out, "Pushing files...\n\n")
+			}
 			retry, err := processEventsHandler(ctx, changedFiles, deletedPaths, parameters, out, &componentStatus, expBackoff)
 			o.forceSync = false
 			if err != nil {
@@ -253,6 +357,8 @@ func (o *WatchClient) eventWatcher(
 			// empty the events to receive new events
 			if componentStatus.State == StateReady {
 				events = []fsnotify.Event{} // empty the events slice to capture new events
+				o.forcePush = false
+				o.restartCommand = false
 			}
 
 			if retry != nil {
@@ -265,10 +371,25 @@ func (o *WatchClient) eventWatcher(
 		case watchErr := <-o.sourcesWatcher.Errors:
 			return watchErr
 
+		case pollErr := <-o.sourcesPoller.Errors():
+			log.Fwarning(out, fmt.Sprintf("Unable to poll the files for changes (%v), retrying every %s", pollErr, o.sourcesPoller.interval))
+
 		case key := <-o.keyWatcher:
-			if key == 'p' {
-				o.forceSync = true
-				sourcesTimer.Reset(100 * time.Millisecond)
+			o.handleKey(ctx, key, out, &parameters, componentStatus, sourcesTimer)
+
+		case action := <-o.apiServer.Actions():
+			klog.V(4).Infof("action %q requested through the API server", action)
+			switch action {
+			case apiserver.ActionSync:
+				o.handleKey(ctx, 'p', out, &parameters, componentStatus, sourcesTimer)
+			case apiserver.ActionRestart:
+				o.handleKey(ctx, 'r', out, &parameters, componentStatus, sourcesTimer)
+			case apiserver.ActionDebug, apiserver.ActionRun:
+				if parameters.Debug != (action == apiserver.ActionDebug) {
+					o.handleKey(ctx, 'd', out, &parameters, componentStatus, sourcesTimer)
+				}
+			case apiserver.ActionStop:
+				return errors.New("Dev mode stopped through the API server")
 			}
 
 		case ev := <-o.deploymentWatcher.ResultChan():
@@ -330,12 +451,23 @@ func (o *WatchClient) eventWatcher(
 					return errors.New("unable to decode watch event")
 				}
 				podsPhases.Delete(out, pod)
+				if parameters.WatchPodman {
+					// Nothing recreates the pod on Podman, the component needs to be deployed again
+					containerStates.Reset()
+					componentStatus.SetState(StateWaitDeployment)
+					componentStatus.PostStartEventsDone = false
+					deployTimer.Reset(300 * time.Millisecond)
+				}
 			case watch.Added, watch.Modified:
 				pod, ok := ev.Object.(*corev1.Pod)
 				if !ok {
 					return errors.New("unable to decode watch event")
 				}
 				podsPhases.Add(out, pod.GetCreationTimestamp(), pod)
+				if parameters.WatchPodman && containerStates.Update(out, pod) {
+					// The commands running in a restarted container are lost, the component needs to be reconciled
+					deployTimer.Reset(300 * time.Millisecond)
+				}
 			}
 
 		case ev := <-o.warningsWatcher.ResultChan():
@@ -363,9 +495,10 @@ func (o *WatchClient) eventWatcher(
 
 // evaluateFileChanges evaluates any file changes for the events. It ignores the files in fileIgnores slice related to path, and removes
 // any deleted paths from the watcher
-func evaluateFileChanges(events []fsnotify.Event, path string, fileIgnores []string, watcher *fsnotify.Watcher) ([]string, []string) {
+func evaluateFileChanges(events []fsnotify.Event, path string, fileIgnores []string, watcher *fsnotify.Watcher) ([]string, []string, error) {
 	var changedFiles []string
 	var deletedPaths []string
+	var watchError error
 
 	ignoreMatcher := gitignore.CompileIgnoreLines(fileIgnores...)
 
@@ -387,10 +520,9 @@ func evaluateFileChanges(events []fsnotify.Event, path string, fileIgnores []str
 		// ignores paths because, when a directory that is ignored, is deleted,
 		// because its parent is watched, the fsnotify automatically raises an event
 		// for it.
-		var watchError error
 		rel, err := filepath.Rel(path, event.Name)
 		if err != nil {
-			watchError = fmt.Errorf("unable to get relative path of %q on %q", event.Name, path)
+			klog.V(4).Infof("unable to get relative path of %q on %q", event.Name, path)
 		}
 		matched := ignoreMatcher.MatchesPath(rel)
 		if !alreadyInChangedFiles && !matched && !isIgnoreEvent {
@@ -404,8 +536,10 @@ func evaluateFileChanges(events []fsnotify.Event, path string, fileIgnores []str
 		// Also weirdly, fsnotify raises a RENAME event for deletion of files/folders with space in their name so even that should be handled here
 		if event.Op&fsnotify.Remove == fsnotify.Remove || event.Op&fsnotify.Rename == fsnotify.Rename {
 			// On remove/rename, stop watching the resource
-			if e := watcher.Remove(event.Name); e != nil {
-				klog.V(4).Infof("error removing watch for %s: %v", event.Name, e)
+			if watcher != nil {
+				if e := watcher.Remove(event.Name); e != nil {
+					klog.V(4).Infof("error removing watch for %s: %v", event.Name, e)
+				}
 			}
 			// Append the file to list of deleted files
 			// When a file/folder is deleted, it raises 2 events:
@@ -414,7 +548,7 @@ func evaluateFileChanges(events []fsnotify.Event, path string, fileIgnores []str
 			if !alreadyInChangedFiles && !matched && event.Name != "" {
 				deletedPaths = append(deletedPaths, event.Name)
 			}
-		} else {
+		} else if watcher != nil {
 			// On other ops, recursively watch the resource (if applicable)
 			if e := addRecursiveWatch(watcher, path, event.Name, fileIgnores); e != nil && watchError == nil {
 				klog.V(4).Infof("Error occurred in addRecursiveWatch, setting watchError to %v", e)
@@ -424,7 +558,25 @@ func evaluateFileChanges(events []fsnotify.Event, path string, fileIgnores []str
 	}
 	deletedPaths = removeDuplicates(deletedPaths)
 
-	return changedFiles, deletedPaths
+	return changedFiles, deletedPaths, watchError
+}
+
+// pollSources starts polling the sources at the given interval, instead of watching them
+func (o *WatchClient) pollSources(ctx context.Context, parameters WatchParameters, interval time.Duration) error {
+	klog.V(4).Infof("polling %s every %s", parameters.Path, interval)
+	poller, err := newSourcesPoller(parameters.Path, parameters.FileIgnores, interval)
+	if err != nil {
+		return fmt.Errorf("error polling source path %s: %w", parameters.Path, err)
+	}
+	poller.start(ctx)
+	o.sourcesPoller = poller
+	return nil
+}
+
+// warnWatchLimit warns that the sources are polled at the given interval because the limit of filesystem watches is reached
+func warnWatchLimit(out io.Writer, err error, interval time.Duration) {
+	log.Fwarning(out, fmt.Sprintf("Unable to watch all the files for changes (%v), polling the files every %s instead. "+
+		"Increase the limit of filesystem watches of the system (fs.inotify.max_user_watches on Linux) to use filesystem notifications", err, interval))
 }
 
 func (o *WatchClient) processEvents(
@@ -452,17 +604,34 @@ func (o *WatchClient) processEvents(
 		DevfileRunCmd:            parameters.DevfileRunCmd,
 		DevfileDebugCmd:          parameters.DevfileDebugCmd,
 		DevfileScanIndexForWatch: !hasFirstSuccessfulPushOccurred,
+		ForcePush:                o.forcePush,
+		RestartCommand:           o.restartCommand,
 		Debug:                    parameters.Debug,
 		RandomPorts:              parameters.RandomPorts,
 		ErrOut:                   parameters.ErrOut,
 	}
 	oldStatus := *componentStatus
+	startTime := time.Now()
 	err := parameters.DevfileWatchHandler(ctx, pushParams, parameters, componentStatus)
+	result := apiserver.Result{
+		StartTime:    startTime,
+		EndTime:      time.Now(),
+		Command:      getCommandKind(parameters.Debug),
+		ChangedFiles: changedFiles,
+		DeletedFiles: deletedPaths,
+		Restart:      o.restartCommand,
+		Success:      err == nil,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	o.apiServer.AddResult(result)
 	if err != nil {
 		if isFatal(err) {
 			return nil, err
 		}
 		klog.V(4).Infof("Error from Push: %v", err)
+		machineoutput.NewMachineEventLoggingClient().ReportError(err, machineoutput.TimestampNow())
 		// Log and output, but intentionally not exiting on error here.
 		// We don't want to break watch when push failed, it might be fixed with the next push.
 		if kerrors.IsUnauthorized(err) || kerrors.IsForbidden(err) {
@@ -552,6 +721,109 @@ func PrintInfoMessage(out io.Writer, path string, watchFiles bool, promptMessage
 	)
 }
 
+// handleKey executes the keyboard command key.
+// The changes to apply to the component are applied when sourcesTimer fires
+func (o *WatchClient) handleKey(
+	ctx context.Context,
+	key byte,
+	out io.Writer,
+	parameters *WatchParameters,
+	componentStatus ComponentStatus,
+	sourcesTimer *time.Timer,
+) {
+	switch key {
+	case 'p':
+		o.forceSync = true
+		sourcesTimer.Reset(100 * time.Millisecond)
+	case 'f':
+		fmt.Fprintf(out, "Synchronizing all the files...\n\n")
+		o.forcePush = true
+		o.forceSync = true
+		sourcesTimer.Reset(100 * time.Millisecond)
+	case 'r':
+		fmt.Fprintf(out, "Restarting the application...\n\n")
+		o.restartCommand = true
+		o.forceSync = true
+		sourcesTimer.Reset(100 * time.Millisecond)
+	case 'd':
+		if !parameters.Debug && !libdevfile.HasDebugCommand(parameters.InitialDevfileObj.Data) {
+			log.Fwarning(out, "No debug command defined in the Devfile")
+			return
+		}
+		if parameters.Debug && !libdevfile.HasRunCommand(parameters.InitialDevfileObj.Data) {
+			log.Fwarning(out, "No run command defined in the Devfile")
+			return
+		}
+		parameters.Debug = !parameters.Debug
+		o.apiServer.SetState(string(componentStatus.State), parameters.Debug)
+		if parameters.Debug {
+			fmt.Fprintf(out, "Switching to the debug command...\n\n")
+		} else {
+			fmt.Fprintf(out, "Switching to the run command...\n\n")
+		}
+		o.restartCommand = true
+		o.forceSync = true
+		sourcesTimer.Reset(100 * time.Millisecond)
+	case 'l':
+		if parameters.ToggleLogsHandler == nil {
+			return
+		}
+		if parameters.ToggleLogsHandler() {
+			fmt.Fprintf(out, "Showing the logs of the application\n\n")
+		} else {
+			fmt.Fprintf(out, "Hiding the logs of the application\n\n")
+		}
+	case 's':
+		o.printStatus(out, componentStatus, *parameters)
+	case 'b':
+		if len(parameters.SyncBack) == 0 || parameters.SyncBackHandler == nil {
+			return
+		}
+		if componentStatus.State != StateReady {
+			klog.V(4).Infof("State of component is %q, don't sync files back", componentStatus.State)
+			return
+		}
+		if err := parameters.SyncBackHandler(ctx, *parameters); err != nil {
+			fmt.Fprintf(out, "%s - %s\n\n", SyncBackErrorString, err.Error())
+		}
+	}
+}
+
+// printStatus prints the state of the component, the command executed and the ports forwarded by the session
+func (o *WatchClient) printStatus(out io.Writer, componentStatus ComponentStatus, parameters WatchParameters) {
+	fmt.Fprintf(out, "\n %s %s (%s command)\n", log.Sbold("Component state:"), componentStatus.State, getCommandKind(parameters.Debug))
+
+	fwPorts, err := o.getForwardedPorts()
+	if err != nil {
+		klog.V(4).Infof("unable to get the forwarded ports: %v", err)
+	}
+	if len(fwPorts) == 0 {
+		fmt.Fprintf(out, " %s none\n\n", log.Sbold("Forwarded ports:"))
+		return
+	}
+	fmt.Fprintf(out, " %s\n", log.Sbold("Forwarded ports:"))
+	for _, fwPort := range fwPorts {
+		fmt.Fprintf(out, " -  Forwarding from %s:%d -> %d (container %s)\n", fwPort.LocalAddress, fwPort.LocalPort, fwPort.ContainerPort, fwPort.ContainerName)
+	}
+	fmt.Fprintln(out)
+}
+
+// getForwardedPorts returns the ports forwarded by the session, or no port if the state of the session is not available
+func (o *WatchClient) getForwardedPorts() ([]api.ForwardedPort, error) {
+	if o.stateClient == nil {
+		return nil, nil
+	}
+	return o.stateClient.GetForwardedPorts()
+}
+
+// getCommandKind returns the kind of the command executed by the component, debug if debug is true, run otherwise
+func getCommandKind(debug bool) string {
+	if debug {
+		return "debug"
+	}
+	return "run"
+}
+
 func isFatal(err error) bool {
 	return errors.As(err, &adapters.ErrPortForward{})
 }
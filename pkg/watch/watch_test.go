@@ -3,17 +3,25 @@ package watch
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/redhat-developer/odo/pkg/api"
+	"github.com/redhat-developer/odo/pkg/apiserver"
+	"github.com/redhat-developer/odo/pkg/testingutil"
 )
 
-func evaluateChangesHandler(events []fsnotify.Event, path string, fileIgnores []string, watcher *fsnotify.Watcher) ([]string, []string) {
+func evaluateChangesHandler(events []fsnotify.Event, path string, fileIgnores []string, watcher *fsnotify.Watcher) ([]string, []string, error) {
 	var changedFiles []string
 	var deletedPaths []string
 
@@ -37,7 +45,7 @@ func evaluateChangesHandler(events []fsnotify.Event, path string, fileIgnores []
 			changedFiles = append(changedFiles, event.Name)
 		}
 	}
-	return changedFiles, deletedPaths
+	return changedFiles, deletedPaths, nil
 }
 
 func processEventsHandler(ctx context.Context, changedFiles, deletedPaths []string, _ WatchParameters, out io.Writer, componentStatus *ComponentStatus, backo *ExpBackoff) (*time.Duration, error) {
@@ -151,3 +159,331 @@ func Test_eventWatcher(t *testing.T) {
 		})
 	}
 }
+
+func Test_eventWatcher_keys(t *testing.T) {
+	devfileWithDebug := testingutil.GetTestDevfileObjFromFile("devfile-with-debugrun.yaml")
+	devfileWithoutDebug := testingutil.GetTestDevfileObjFromFile("devfile.yaml")
+
+	tests := []struct {
+		name       string
+		key        byte
+		parameters WatchParameters
+		wantOut    string
+	}{
+		{
+			name:    "force a full sync",
+			key:     'f',
+			wantOut: "Synchronizing all the files...\n\nPushing files...\n\npush forcePush=true restartCommand=false debug=false\n",
+		},
+		{
+			name:    "restart the command",
+			key:     'r',
+			wantOut: "Restarting the application...\n\npush forcePush=false restartCommand=true debug=false\n",
+		},
+		{
+			name:       "switch to the debug command",
+			key:        'd',
+			parameters: WatchParameters{InitialDevfileObj: devfileWithDebug},
+			wantOut:    "Switching to the debug command...\n\npush forcePush=false restartCommand=true debug=true\n",
+		},
+		{
+			name:       "switch to the run command",
+			key:        'd',
+			parameters: WatchParameters{InitialDevfileObj: devfileWithDebug, Debug: true},
+			wantOut:    "Switching to the run command...\n\npush forcePush=false restartCommand=true debug=false\n",
+		},
+		{
+			name:       "no debug command to switch to",
+			key:        'd',
+			parameters: WatchParameters{InitialDevfileObj: devfileWithoutDebug},
+			wantOut:    "No debug command defined in the Devfile\n",
+		},
+		{
+			name:       "show the logs",
+			key:        'l',
+			parameters: WatchParameters{ToggleLogsHandler: func() bool { return true }},
+			wantOut:    "Showing the logs of the application\n\n",
+		},
+		{
+			name:    "show the status",
+			key:     's',
+			wantOut: "\n Component state: Ready (run command)\n Forwarded ports: none\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watcher, _ := fsnotify.NewWatcher()
+			fileWatcher, _ := fsnotify.NewWatcher()
+			ctx, cancel := context.WithCancel(context.Background())
+			out := &bytes.Buffer{}
+			keyWatcher := make(chan byte)
+
+			go func() {
+				keyWatcher <- tt.key
+				<-time.After(500 * time.Millisecond)
+				cancel()
+			}()
+
+			o := WatchClient{
+				sourcesWatcher:    watcher,
+				deploymentWatcher: fakeWatcher{},
+				podWatcher:        fakeWatcher{},
+				warningsWatcher:   fakeWatcher{},
+				devfileWatcher:    fileWatcher,
+				keyWatcher:        keyWatcher,
+			}
+			processEvents := func(_ context.Context, _, _ []string, parameters WatchParameters, out io.Writer, _ *ComponentStatus, _ *ExpBackoff) (*time.Duration, error) {
+				fmt.Fprintf(out, "push forcePush=%v restartCommand=%v debug=%v\n", o.forcePush, o.restartCommand, parameters.Debug)
+				return nil, nil
+			}
+			_ = o.eventWatcher(ctx, tt.parameters, out, evaluateChangesHandler, processEvents, ComponentStatus{State: StateReady})
+
+			if gotOut := out.String(); !strings.HasSuffix(gotOut, tt.wantOut) {
+				t.Errorf("eventWatcher() gotOut = %q, want suffix %q", gotOut, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_eventWatcher_apiServer(t *testing.T) {
+	devfileWithDebug := testingutil.GetTestDevfileObjFromFile("devfile-with-debugrun.yaml")
+
+	tests := []struct {
+		name       string
+		action     apiserver.Action
+		parameters WatchParameters
+		wantOut    string
+		wantErr    string
+	}{
+		{
+			name:    "sync",
+			action:  apiserver.ActionSync,
+			wantOut: "Pushing files...\n\npush restartCommand=false debug=false\n",
+			wantErr: "Dev mode interrupted by user",
+		},
+		{
+			name:    "restart the command",
+			action:  apiserver.ActionRestart,
+			wantOut: "Restarting the application...\n\npush restartCommand=true debug=false\n",
+			wantErr: "Dev mode interrupted by user",
+		},
+		{
+			name:       "switch to the debug command",
+			action:     apiserver.ActionDebug,
+			parameters: WatchParameters{InitialDevfileObj: devfileWithDebug},
+			wantOut:    "Switching to the debug command...\n\npush restartCommand=true debug=true\n",
+			wantErr:    "Dev mode interrupted by user",
+		},
+		{
+			name:       "already running the debug command",
+			action:     apiserver.ActionDebug,
+			parameters: WatchParameters{InitialDevfileObj: devfileWithDebug, Debug: true},
+			wantErr:    "Dev mode interrupted by user",
+		},
+		{
+			name:    "stop the session",
+			action:  apiserver.ActionStop,
+			wantErr: "Dev mode stopped through the API server",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watcher, _ := fsnotify.NewWatcher()
+			fileWatcher, _ := fsnotify.NewWatcher()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			out := &bytes.Buffer{}
+
+			server, err := apiserver.Start(ctx, "127.0.0.1:0", func() ([]api.ForwardedPort, error) {
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			go func() {
+				req, err := http.NewRequest(http.MethodPost, "http://"+server.Address()+"/api/v1/"+string(tt.action), nil)
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				req.Header.Set("Authorization", "Bearer "+server.Token())
+				resp, err := http.DefaultClient.Do(req)
+				if err == nil {
+					resp.Body.Close()
+				}
+				<-time.After(500 * time.Millisecond)
+				cancel()
+			}()
+
+			o := WatchClient{
+				sourcesWatcher:    watcher,
+				deploymentWatcher: fakeWatcher{},
+				podWatcher:        fakeWatcher{},
+				warningsWatcher:   fakeWatcher{},
+				devfileWatcher:    fileWatcher,
+				apiServer:         server,
+			}
+			processEvents := func(_ context.Context, _, _ []string, parameters WatchParameters, out io.Writer, _ *ComponentStatus, _ *ExpBackoff) (*time.Duration, error) {
+				fmt.Fprintf(out, "push restartCommand=%v debug=%v\n", o.restartCommand, parameters.Debug)
+				return nil, nil
+			}
+			err = o.eventWatcher(ctx, tt.parameters, out, evaluateChangesHandler, processEvents, ComponentStatus{State: StateReady})
+
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("eventWatcher() error = %v, want %q", err, tt.wantErr)
+			}
+			if gotOut := out.String(); gotOut != tt.wantOut {
+				t.Errorf("eventWatcher() gotOut = %q, want %q", gotOut, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_eventWatcher_apiServerState(t *testing.T) {
+	watcher, _ := fsnotify.NewWatcher()
+	fileWatcher, _ := fsnotify.NewWatcher()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server, err := apiserver.Start(ctx, "127.0.0.1:0", func() ([]api.ForwardedPort, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	getState := func() string {
+		req, err := http.NewRequest(http.MethodGet, "http://"+server.Address()+"/api/v1/status", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+server.Token())
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+		var status apiserver.Status
+		if err = json.NewDecoder(resp.Body).Decode(&status); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return status.State
+	}
+
+	keyWatcher := make(chan byte)
+	go func() {
+		keyWatcher <- 'p'
+		<-time.After(500 * time.Millisecond)
+		cancel()
+	}()
+
+	o := WatchClient{
+		sourcesWatcher:    watcher,
+		deploymentWatcher: fakeWatcher{},
+		podWatcher:        fakeWatcher{},
+		warningsWatcher:   fakeWatcher{},
+		devfileWatcher:    fileWatcher,
+		keyWatcher:        keyWatcher,
+		apiServer:         server,
+	}
+	// the states are published while the component is processed, without waiting for another event
+	var states []string
+	processEvents := func(_ context.Context, _, _ []string, _ WatchParameters, _ io.Writer, componentStatus *ComponentStatus, _ *ExpBackoff) (*time.Duration, error) {
+		states = append(states, getState())
+		componentStatus.SetState(StateWaitDeployment)
+		states = append(states, getState())
+		componentStatus.SetState(StateReady)
+		states = append(states, getState())
+		return nil, nil
+	}
+	_ = o.eventWatcher(ctx, WatchParameters{}, &bytes.Buffer{}, evaluateChangesHandler, processEvents, ComponentStatus{State: StateReady})
+
+	want := []string{string(StateSyncOutdated), string(StateWaitDeployment), string(StateReady)}
+	if diff := cmp.Diff(want, states); diff != "" {
+		t.Errorf("published states mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_eventWatcher_podmanPod(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []watch.Event
+		// wantPushes are the states of the component when the component is reconciled
+		wantPushes []State
+		wantOut    []string
+	}{
+		{
+			name: "container running, exited, then restarted",
+			events: []watch.Event{
+				{Type: watch.Added, Object: podWithContainer("uid1", containerRunning, 0)},
+				{Type: watch.Modified, Object: podWithContainer("uid1", containerExited, 0)},
+				{Type: watch.Modified, Object: podWithContainer("uid1", containerRunning, 1)},
+			},
+			wantPushes: []State{StateReady},
+			wantOut: []string{
+				`Container "runtime" is terminated: Error (exit code 1)`,
+				`Container "runtime" has been restarted (1 restarts)`,
+			},
+		},
+		{
+			name: "duplicate events",
+			events: []watch.Event{
+				{Type: watch.Added, Object: podWithContainer("uid1", containerRunning, 0)},
+				{Type: watch.Modified, Object: podWithContainer("uid1", containerRunning, 0)},
+				{Type: watch.Modified, Object: podWithContainer("uid1", containerRunning, 0)},
+			},
+			wantOut: []string{`Container "runtime" is running`},
+		},
+		{
+			name: "pod deleted",
+			events: []watch.Event{
+				{Type: watch.Added, Object: podWithContainer("uid1", containerRunning, 0)},
+				{Type: watch.Deleted, Object: podWithContainer("uid1", containerRunning, 0)},
+			},
+			wantPushes: []State{StateWaitDeployment},
+			wantOut:    []string{"No pod exists"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watcher, _ := fsnotify.NewWatcher()
+			fileWatcher, _ := fsnotify.NewWatcher()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			out := &bytes.Buffer{}
+			podWatcher := watch.NewFake()
+
+			go func() {
+				for _, event := range tt.events {
+					podWatcher.Action(event.Type, event.Object)
+				}
+				<-time.After(time.Second)
+				cancel()
+			}()
+
+			o := WatchClient{
+				sourcesWatcher:    watcher,
+				deploymentWatcher: fakeWatcher{},
+				podWatcher:        podWatcher,
+				warningsWatcher:   fakeWatcher{},
+				devfileWatcher:    fileWatcher,
+				keyWatcher:        make(chan byte),
+			}
+			var pushes []State
+			processEvents := func(_ context.Context, _, _ []string, _ WatchParameters, _ io.Writer, componentStatus *ComponentStatus, _ *ExpBackoff) (*time.Duration, error) {
+				pushes = append(pushes, componentStatus.State)
+				return nil, nil
+			}
+			_ = o.eventWatcher(ctx, WatchParameters{WatchPodman: true}, out, evaluateChangesHandler, processEvents, ComponentStatus{State: StateReady})
+
+			if diff := cmp.Diff(tt.wantPushes, pushes); diff != "" {
+				t.Errorf("eventWatcher() pushes mismatch (-want +got):\n%s", diff)
+			}
+			gotOut := out.String()
+			for _, want := range tt.wantOut {
+				if got := strings.Count(gotOut, want); got != 1 {
+					t.Errorf("eventWatcher() output contains %q %d times, want once. Output:\n%s", want, got, gotOut)
+				}
+			}
+		})
+	}
+}